@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/monitoring"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/portfolio"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func activityCmd() *cobra.Command {
+	var owner, reportDir, syncDir string
+	var repos []string
+	var threshold int
+	var fullResync, debug, quiet bool
+
+	activityCmd := &cobra.Command{
+		Use:   "activity",
+		Short: "Report repository activity and inactivity incrementally.",
+		Annotations: GetDescriptions([]string{
+			"This command reports the pull request, issue and commit activity of the specified repositories.",
+			"This command keeps a sync cursor per repository and fetches only the issues and pull requests updated since the previous run; --full-resync lists the whole history again.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			if quiet {
+				gl.Logger.SetLogLevel("error")
+			}
+			if owner == "" {
+				owner = os.Getenv("GITHUB_REPO_OWNER")
+			}
+
+			cfg, err := config.NewMainConfigType(reportDir, owner, repos, debug, false, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+			targets := repositoryTargets(owner, repos)
+			if len(targets) == 0 && cfg.GetGitHub() != nil {
+				for _, rc := range cfg.GetGitHub().GetRepos() {
+					targets = append(targets, portfolio.Repository{Owner: rc.GetOwner(), Name: rc.GetName()})
+				}
+			}
+			if len(targets) == 0 {
+				gl.Log("error", "No repositories specified or configured for the activity report.")
+				return
+			}
+
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+			store := incremental.NewStore(syncDir)
+			opts := incremental.Options{FullResync: fullResync}
+
+			reports := make([]*monitoring.ActivityReport, 0, len(targets))
+			for _, target := range targets {
+				report, err := monitoring.AnalyzeRepositoryActivityIncremental(ctx, ghc, store, target.Owner, target.Name, threshold, opts)
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to analyze activity of %s/%s: %v", target.Owner, target.Name, err))
+					continue
+				}
+				mode := "incremental"
+				switch {
+				case report.Sync.Skipped:
+					mode = "unchanged"
+				case report.Sync.Full:
+					mode = "full"
+				}
+				gl.Log("info", fmt.Sprintf("📈 %s/%s: %d open PRs, %d open issues, %d commits in 30 days, %d days inactive (%s sync: %d fetched, %d stored, %s)",
+					target.Owner, target.Name, report.PRStats.Open, report.IssueStats.Open, report.CommitStats.CommitsLast30,
+					report.DaysInactive, mode, report.Sync.Fetched, report.Sync.Total, report.Sync.Duration.Round(1e6)))
+				if report.IsInactive {
+					gl.Log("warning", fmt.Sprintf("%s/%s is inactive for %d days", target.Owner, target.Name, report.DaysInactive))
+				}
+				reports = append(reports, report)
+			}
+
+			if reportDir == "" {
+				return
+			}
+			if err := os.MkdirAll(reportDir, 0o755); err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to create report directory: %v", err))
+				return
+			}
+			data, _ := json.MarshalIndent(reports, "", "  ")
+			path := filepath.Join(reportDir, "activity.json")
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to write report: %v", err))
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Activity report saved to %s", path))
+		},
+	}
+
+	activityCmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	activityCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode")
+	activityCmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner of the repositories")
+	activityCmd.Flags().StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to analyze (default: all configured repositories)")
+	activityCmd.Flags().StringVarP(&reportDir, "report-dir", "R", "", "Directory to save the JSON report")
+	activityCmd.Flags().IntVarP(&threshold, "threshold", "t", 30, "Days without activity to consider a repository inactive")
+	activityCmd.Flags().StringVar(&syncDir, "sync-dir", "", "Sync store directory (default: $GHBEX_SYNC_DIR or ~/.kubex/ghbex/sync)")
+	activityCmd.Flags().BoolVar(&fullResync, "full-resync", false, "Discard the sync cursors and list the whole history again")
+
+	return activityCmd
+}
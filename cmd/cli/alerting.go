@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/alerting"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func AlertingCmd() *cobra.Command {
+	var configPath, historyDir, statePath string
+	var repos []string
+	var days int
+	var disableDryRun, debug bool
+
+	short := "Evaluate metric alert rules and notify"
+	long := "Evaluates the alert rules of each repository's monitoring rule (static thresholds, week-over-week deltas, z-score and EWMA anomaly detection) against the recorded metric history, and sends firing, repeat and resolve notifications through the configured notifiers with deduplication and cooldown windows. Without --no-dry-run it only prints what would be sent."
+
+	cmd := &cobra.Command{
+		Use:     "alerting",
+		Aliases: []string{"alert-rules"},
+		Short:   short,
+		Long:    long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+
+			if configPath == "" {
+				configPath = filepath.Join(config.GetBaseFilesPath(), "config", "sanitize.yaml")
+			}
+			if _, err := os.Stat(configPath); err != nil {
+				gl.Log("error", fmt.Sprintf("Configuration file %s not found: %v", configPath, err))
+				return
+			}
+			cfg, err := config.LoadFromFile(configPath)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to load configuration: %v", err))
+				return
+			}
+
+			engine, err := alerting.NewEngine(statePath)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			store := history.NewStore(historyDir)
+			opts := alerting.DefaultOptions()
+			now := time.Now()
+
+			var evals []alerting.Evaluation
+			for _, rc := range cfg.GetGitHub().GetRepos() {
+				repository := rc.GetOwner() + "/" + rc.GetName()
+				if len(repos) > 0 && !slices.Contains(repos, repository) {
+					continue
+				}
+				rules, errs := alerting.RulesFromConfig(rc.GetMonitoring(), opts)
+				for _, err := range errs {
+					gl.Log("warning", fmt.Sprintf("%s: %v", repository, err))
+				}
+				if len(rules) == 0 {
+					continue
+				}
+				points, err := store.Load(rc.GetOwner(), rc.GetName(), now.AddDate(0, 0, -days))
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("%s: %v", repository, err))
+					continue
+				}
+				for _, rule := range rules {
+					eval := alerting.Evaluate(repository, rule, points, opts)
+					switch {
+					case eval.Skipped != "":
+						gl.Log("debug", fmt.Sprintf("%s: %s skipped, %s", repository, rule.Name, eval.Skipped))
+					case eval.Breached:
+						gl.Log("warning", fmt.Sprintf("%s: %s breached (%s)", repository, rule.Name, formatObserved(eval.Observed)))
+					default:
+						gl.Log("info", fmt.Sprintf("%s: %s ok (%s)", repository, rule.Name, formatObserved(eval.Observed)))
+					}
+					evals = append(evals, eval)
+				}
+			}
+			if len(evals) == 0 {
+				gl.Log("info", "No alert rules configured for the selected repositories.")
+				return
+			}
+
+			events := engine.Process(evals, now)
+			if dryRun {
+				for _, ev := range events {
+					title, text := alerting.FormatEvent(ev)
+					fmt.Printf("[dry-run] %s\n%s\n", title, text)
+				}
+				gl.Log("info", fmt.Sprintf("Dry run: %d notifications would be sent; alert state not saved", len(events)))
+				return
+			}
+
+			// Only delivered events change the alert state, so the failed ones are sent again
+			// on the next run
+			delivered, err := alerting.Deliver(context.Background(), cfg.GetNotifiers().GetNotifiers(), events)
+			if err != nil {
+				gl.Log("error", err.Error())
+			}
+			engine.Delivered(delivered, now)
+			if err := engine.Save(); err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			gl.Log("success", fmt.Sprintf("%d rules evaluated, %d of %d notifications sent", len(evals), len(delivered), len(events)))
+		},
+	}
+
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "configuration file with the alert rules (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+	cmd.Flags().StringSliceVarP(&repos, "repo", "r", nil, "only evaluate these repositories (owner/name)")
+	cmd.Flags().IntVarP(&days, "days", "d", 90, "days of history to evaluate")
+	cmd.Flags().StringVar(&historyDir, "history-dir", "", "history store directory (default: $GHBEX_HISTORY_DIR or ~/.kubex/ghbex/history)")
+	cmd.Flags().StringVar(&statePath, "state", "", "alert state file (default: $GHBEX_ALERTS_STATE or ~/.kubex/ghbex/alerts/state.json)")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "send the notifications and save the alert state")
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "enable debug logging")
+
+	return cmd
+}
+
+func formatObserved(v float64) string {
+	if math.IsInf(v, 0) {
+		return fmt.Sprintf("%v", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/security"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func alertsCmd() *cobra.Command {
+	var owner, reportDir string
+	var repos []string
+	var limit int
+	var debug, quiet bool
+
+	alertsCmd := &cobra.Command{
+		Use:   "alerts",
+		Short: "Aggregate Dependabot, code-scanning and secret-scanning alerts.",
+		Annotations: GetDescriptions([]string{
+			"This command aggregates security alerts of the specified repositories.",
+			"This command groups open alerts by severity and age, computes the mean time to remediate and lists the oldest critical alerts.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			if quiet {
+				gl.Logger.SetLogLevel("error")
+			}
+			if len(repos) == 0 {
+				gl.Log("error", "No repositories specified for alert aggregation.")
+				return
+			}
+			if owner == "" {
+				owner = os.Getenv("GITHUB_REPO_OWNER")
+			}
+
+			cfg, err := config.NewMainConfigType(reportDir, owner, repos, debug, false, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+
+			reports := make([]*security.AlertsReport, 0, len(repos))
+			for _, repo := range repos {
+				repoOwner := owner
+				if parts := strings.SplitN(repo, "/", 2); len(parts) == 2 {
+					repoOwner, repo = parts[0], parts[1]
+				}
+				if repoOwner == "" {
+					gl.Log("warning", fmt.Sprintf("No owner for repository %s. Skipping...", repo))
+					continue
+				}
+
+				report, err := security.CollectSecurityAlerts(ctx, ghc, repoOwner, repo)
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to collect alerts for %s/%s: %v", repoOwner, repo, err))
+					continue
+				}
+				gl.Log("info", fmt.Sprintf("🔐 %s/%s: %d open alerts (critical: %d, high: %d), MTTR %.1fh",
+					repoOwner, repo, report.OpenTotal, report.BySeverity["critical"], report.BySeverity["high"], report.MTTRHours))
+				reports = append(reports, report)
+			}
+
+			markdown := security.AlertsToMarkdown(reports, limit)
+			if reportDir == "" {
+				fmt.Println(markdown)
+				return
+			}
+
+			if err := os.MkdirAll(reportDir, 0o755); err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to create report directory: %v", err))
+				return
+			}
+			if err := os.WriteFile(filepath.Join(reportDir, "security_alerts.md"), []byte(markdown), 0o644); err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to write markdown report: %v", err))
+				return
+			}
+			data, err := json.MarshalIndent(reports, "", "  ")
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to encode alerts report: %v", err))
+				return
+			}
+			if err := os.WriteFile(filepath.Join(reportDir, "security_alerts.json"), data, 0o644); err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to write JSON report: %v", err))
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Security alerts report saved to %s", reportDir))
+		},
+	}
+
+	alertsCmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	alertsCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode")
+	alertsCmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner of the repositories")
+	alertsCmd.Flags().StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to scan (name or owner/name)")
+	alertsCmd.Flags().StringVarP(&reportDir, "report-dir", "R", "", "Directory to write security_alerts.md and security_alerts.json")
+	alertsCmd.Flags().IntVarP(&limit, "limit", "l", 10, "Number of oldest critical alerts listed in the report")
+
+	alertsCmd.MarkFlagRequired("repo")
+
+	return alertsCmd
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/backfill"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/portfolio"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func BackfillCmd() *cobra.Command {
+	var owner, historyDir, syncDir string
+	var repos []string
+	var fullResync, debug, quiet bool
+	opts := backfill.DefaultOptions()
+
+	short := "Reconstruct past weekly metrics into the history store"
+	long := "Reconstructs one snapshot per past week (commits, merged pull requests and their cycle time, open pull requests, issue open/close flow, releases and DORA) from historical API data in a single pass per repository, and writes them into the history store as weekly points. Running it again replaces the previous backfill; metrics already recorded by real runs in a week are kept."
+
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: short,
+		Long:  long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			if quiet {
+				gl.Logger.SetLogLevel("error")
+			}
+			if owner == "" {
+				owner = os.Getenv("GITHUB_REPO_OWNER")
+			}
+
+			cfg, err := config.NewMainConfigType("", owner, repos, debug, false, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+			targets := repositoryTargets(owner, repos)
+			if len(targets) == 0 && cfg.GetGitHub() != nil {
+				for _, rc := range cfg.GetGitHub().GetRepos() {
+					targets = append(targets, portfolio.Repository{Owner: rc.GetOwner(), Name: rc.GetName()})
+				}
+			}
+			if len(targets) == 0 {
+				gl.Log("error", "No repositories specified or configured for the backfill.")
+				return
+			}
+
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+			store := history.NewStore(historyDir)
+			syncStore := incremental.NewStore(syncDir)
+			opts.Sync = incremental.Options{FullResync: fullResync}
+
+			for _, target := range targets {
+				started := time.Now()
+				result, err := backfill.Collect(ctx, ghc, syncStore, target.Owner, target.Name, opts, started)
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to backfill %s/%s: %v", target.Owner, target.Name, err))
+					continue
+				}
+				for _, e := range result.Errors {
+					gl.Log("warning", fmt.Sprintf("%s/%s: %s", target.Owner, target.Name, e))
+				}
+				written, err := backfill.Write(store, result)
+				if err != nil {
+					gl.Log("error", err.Error())
+					continue
+				}
+				deploys := 0
+				if result.Dora != nil {
+					deploys = len(result.Dora.Deploys)
+				}
+				gl.Log("success", fmt.Sprintf("⏪ %s/%s: %d weeks from %s (%d commits, %d issues and PRs, %d releases, %d deploys), %d points written, %d metrics kept from real runs in %s",
+					target.Owner, target.Name, len(result.Weeks), result.Since.Format("2006-01-02"), result.Commits,
+					result.Sync.Total, result.Releases, deploys, written.Points, written.Shadowed, time.Since(started).Round(time.Millisecond)))
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode")
+	cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner of the repositories")
+	cmd.Flags().StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to backfill (default: all configured repositories)")
+	cmd.Flags().IntVarP(&opts.Weeks, "weeks", "w", opts.Weeks, "Number of complete past weeks to reconstruct")
+	cmd.Flags().BoolVar(&opts.SkipDora, "skip-dora", false, "Skip the DORA reconstruction (deploys, lead times, failures)")
+	cmd.Flags().StringVar(&opts.Dora.DeployWorkflow, "deploy-workflow", "", "Workflow file whose successful runs count as deploys (default: deployments API, then releases)")
+	cmd.Flags().StringVar(&opts.Dora.Environment, "environment", "", "Deployment environment to consider (e.g. production)")
+	cmd.Flags().IntVar(&opts.Dora.MaxPullRequests, "max-prs", opts.Dora.MaxPullRequests, "Maximum merged pull requests inspected for DORA lead time")
+	cmd.Flags().BoolVar(&fullResync, "full-resync", false, "Discard the sync cursors and list the whole issue history again")
+	cmd.Flags().StringVar(&historyDir, "history-dir", "", "History store directory (default: $GHBEX_HISTORY_DIR or ~/.kubex/ghbex/history)")
+	cmd.Flags().StringVar(&syncDir, "sync-dir", "", "Sync store directory (default: $GHBEX_SYNC_DIR or ~/.kubex/ghbex/sync)")
+
+	return cmd
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/conventional"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/releases"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func ChangelogCmd() *cobra.Command {
+	var repo, from, to, outPath, tag string
+	var allTypes, draftRelease, disableDryRun, debug bool
+
+	short := "Generate a changelog from conventional commits"
+	long := "Generates a CHANGELOG section between two refs grouped by conventional commit type and scope with pull request links, reports the range's Conventional Commits compliance and optionally creates a draft release with it as body."
+
+	cmd := &cobra.Command{
+		Use:   "changelog",
+		Short: short,
+		Long:  long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+
+			owner, name, ok := strings.Cut(repo, "/")
+			if !ok || owner == "" || name == "" {
+				gl.Log("error", fmt.Sprintf("Invalid repository %q, expected owner/name", repo))
+				return
+			}
+
+			cfg, err := config.NewMainConfigType("", owner, []string{name}, debug, disableDryRun, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+
+			opts := conventional.DefaultOptions()
+			opts.AllTypes = allTypes
+			cl, err := conventional.Generate(ctx, ghc, owner, name, from, to, opts)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to generate changelog: %v", err))
+				return
+			}
+			if tag != "" {
+				cl.Version = tag
+			}
+
+			c := cl.Compliance
+			gl.Log("info", fmt.Sprintf("Conventional commits: %d/%d (%.1f%%), %d breaking", c.Compliant, c.Total, c.Percentage, c.Breaking))
+			if offenders := c.TopOffenders(5); len(offenders) > 0 {
+				gl.Log("warning", fmt.Sprintf("Non-compliant commits by: %s", strings.Join(offenders, ", ")))
+			}
+			for _, o := range c.Offenses {
+				gl.Log("debug", fmt.Sprintf("%.7s %s: %s", o.SHA, o.Problem, o.Header))
+			}
+
+			markdown := conventional.ToMarkdown(cl)
+			if outPath == "" {
+				fmt.Print(markdown)
+			} else {
+				if err := os.WriteFile(outPath, []byte(markdown), 0o644); err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to write changelog: %v", err))
+					return
+				}
+				gl.Log("success", fmt.Sprintf("Changelog %s...%s written to %s", from, cl.To, outPath))
+			}
+
+			if !draftRelease {
+				return
+			}
+			if tag == "" {
+				gl.Log("error", "A --tag is required to create a draft release")
+				return
+			}
+			target := ""
+			if !strings.EqualFold(to, "HEAD") {
+				target = to
+			}
+			rel, err := releases.CreateDraft(ctx, ghc, owner, name, tag, target, tag, markdown, dryRun)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to create draft release: %v", err))
+				return
+			}
+			if dryRun {
+				gl.Log("info", fmt.Sprintf("DRY RUN: would create draft release %s", tag))
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Created draft release %s: %s", tag, rel.GetHTMLURL()))
+		},
+	}
+
+	cmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/name)")
+	cmd.Flags().StringVar(&from, "from", "", "Start ref, usually the previous release tag (exclusive)")
+	cmd.Flags().StringVar(&to, "to", "HEAD", "End ref (HEAD means the default branch)")
+	cmd.Flags().StringVarP(&outPath, "out", "o", "", "Output markdown file (default: stdout)")
+	cmd.Flags().StringVarP(&tag, "tag", "t", "", "Version heading and tag of the draft release")
+	cmd.Flags().BoolVar(&allTypes, "all-types", false, "Include docs, refactor, test, build, ci, style and chore sections")
+	cmd.Flags().BoolVar(&draftRelease, "draft-release", false, "Create a draft release with the changelog as body")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "Disable dry run (default: false)")
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+
+	cmd.MarkFlagRequired("repo")
+	cmd.MarkFlagRequired("from")
+
+	return cmd
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/codeowners"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func codeownersCmd() *cobra.Command {
+	var owner, reportDir, ref string
+	var repos []string
+	var skipOwners, debug, quiet bool
+	opts := codeowners.DefaultOptions()
+
+	codeownersCmd := &cobra.Command{
+		Use:   "codeowners",
+		Short: "Validate CODEOWNERS and measure its coverage.",
+		Annotations: GetDescriptions([]string{
+			"This command validates the CODEOWNERS file of the specified repositories.",
+			"This command checks that owners exist and have write access, computes the share of files matched by a rule and reports unmatched, shadowed and departed-only rules.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			if quiet {
+				gl.Logger.SetLogLevel("error")
+			}
+			if len(repos) == 0 {
+				gl.Log("error", "No repositories specified for the CODEOWNERS analysis.")
+				return
+			}
+			if owner == "" {
+				owner = os.Getenv("GITHUB_REPO_OWNER")
+			}
+
+			cfg, err := config.NewMainConfigType(reportDir, owner, repos, debug, false, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+
+			opts.Ref = ref
+			opts.CheckOwners = !skipOwners
+			reports := make([]*codeowners.Report, 0, len(repos))
+			for _, repo := range repos {
+				repoOwner := owner
+				if parts := strings.SplitN(repo, "/", 2); len(parts) == 2 {
+					repoOwner, repo = parts[0], parts[1]
+				}
+				if repoOwner == "" {
+					gl.Log("warning", fmt.Sprintf("No owner for repository %s. Skipping...", repo))
+					continue
+				}
+
+				report, err := codeowners.Analyze(ctx, ghc, repoOwner, repo, opts)
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to analyze CODEOWNERS for %s/%s: %v", repoOwner, repo, err))
+					continue
+				}
+				if !report.Found {
+					gl.Log("warning", fmt.Sprintf("👥 %s/%s: no CODEOWNERS file", repoOwner, repo))
+				} else {
+					gl.Log("info", fmt.Sprintf("👥 %s/%s: %.1f%% of %d files covered, %d invalid owners, %d unmatched and %d shadowed rules",
+						repoOwner, repo, report.CoveragePct, report.FilesTotal, report.InvalidOwners, len(report.Unmatched), len(report.Shadowed)))
+				}
+				reports = append(reports, report)
+			}
+
+			markdown := codeowners.ToMarkdown(reports)
+			if reportDir == "" {
+				fmt.Println(markdown)
+				return
+			}
+			if err := writeCodeownersReports(reportDir, reports, markdown); err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			gl.Log("success", fmt.Sprintf("CODEOWNERS report saved to %s", reportDir))
+		},
+	}
+
+	codeownersCmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	codeownersCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode")
+	codeownersCmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner of the repositories")
+	codeownersCmd.Flags().StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to analyze (name or owner/name)")
+	codeownersCmd.Flags().StringVarP(&reportDir, "report-dir", "R", "", "Directory to write codeowners.md and codeowners.json")
+	codeownersCmd.Flags().StringVar(&ref, "ref", "", "Branch or commit to analyze (default: default branch)")
+	codeownersCmd.Flags().IntVar(&opts.InactiveDays, "inactive-days", opts.InactiveDays, "Days without commits after which a user owner is departed")
+	codeownersCmd.Flags().IntVar(&opts.MaxUnowned, "max-unowned", opts.MaxUnowned, "Maximum unowned files listed per repository")
+	codeownersCmd.Flags().BoolVar(&skipOwners, "skip-owners", false, "Do not verify owners through the API")
+
+	codeownersCmd.MarkFlagRequired("repo")
+
+	return codeownersCmd
+}
+
+func writeCodeownersReports(reportDir string, reports []*codeowners.Report, markdown string) error {
+	if err := os.MkdirAll(reportDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "codeowners.md"), []byte(markdown), 0o644); err != nil {
+		return fmt.Errorf("failed to write markdown report: %w", err)
+	}
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode CODEOWNERS report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "codeowners.json"), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	return nil
+}
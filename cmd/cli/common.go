@@ -2,9 +2,27 @@
 package cli
 
 import (
+	"context"
+	"fmt"
 	"math/rand"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/ghclient"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/alerting"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/analytics"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/artifacts"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/automation"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/monitoring"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/workflows"
+	"github.com/kubex-ecosystem/ghbex/internal/runtime"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
 )
 
 var banners = []string{
@@ -40,3 +58,109 @@ func GetDescriptions(descriptionArg []string, _ bool) map[string]string {
 
 	return map[string]string{"banner": banner, "description": description}
 }
+
+// newGitHubClient builds an authenticated GitHub client from the main configuration.
+// It prefers GitHub App credentials, then a PAT, and falls back to an anonymous client.
+// Every client reports its rate limit budget to the process tracker.
+func newGitHubClient(ctx context.Context, cfg interfaces.IMainConfig) *github.Client {
+	if cfg != nil && cfg.GetGitHub() != nil && cfg.GetGitHub().GetAuth() != nil {
+		auth := cfg.GetGitHub().GetAuth()
+		if auth.GetAppID() != 0 && auth.GetInstallationID() != 0 && auth.GetPrivateKeyPath() != "" {
+			cli, err := ghclient.NewApp(ctx, ghclient.AppConfig{
+				AppID:          auth.GetAppID(),
+				InstallationID: auth.GetInstallationID(),
+				PrivateKeyPath: auth.GetPrivateKeyPath(),
+				BaseURL:        auth.GetBaseURL(),
+				UploadURL:      auth.GetUploadURL(),
+			})
+			if err == nil {
+				return ghclient.Track(cli, ghclient.DefaultRateTracker())
+			}
+			gl.Log("warning", fmt.Sprintf("Failed to create GitHub App client, trying PAT: %v", err))
+		}
+		if auth.GetToken() != "" {
+			cli, err := ghclient.NewPAT(ctx, ghclient.PATConfig{
+				Token:     auth.GetToken(),
+				BaseURL:   auth.GetBaseURL(),
+				UploadURL: auth.GetUploadURL(),
+			})
+			if err == nil {
+				return ghclient.Track(cli, ghclient.DefaultRateTracker())
+			}
+			gl.Log("warning", fmt.Sprintf("Failed to create GitHub PAT client, using anonymous access: %v", err))
+		}
+	}
+	return ghclient.Track(github.NewClient(nil), ghclient.DefaultRateTracker())
+}
+
+// newOperatorManager registers the operators that can be run by name (webhook triggers,
+// schedules, pipelines) and returns a runtime manager dispatching them through mws.
+// Identical concurrent dispatches share one run. Replay of completed results is off unless
+// GHBEX_REPLAY_WINDOW is set, and even then only applies to dispatches with an explicit
+// idempotency key (webhook deliveries), never to schedules, pipelines or reruns.
+func newOperatorManager(cfg interfaces.IMainConfig, mws ...runtime.Middleware) *runtime.Manager {
+	reg := runtime.NewRegistry()
+	alerting.Register(reg, cfg)
+	analytics.Register(reg)
+	artifacts.Register(reg)
+	automation.Register(reg, cfg)
+	monitoring.Register(reg)
+	workflows.Register(reg)
+
+	manager := runtime.NewManager(reg, mws...)
+	if value := config.GetEnvOrDefault("GHBEX_REPLAY_WINDOW", ""); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil {
+			gl.Log("warning", fmt.Sprintf("Ignoring invalid GHBEX_REPLAY_WINDOW %q: %v", value, err))
+		} else {
+			manager.SetReplay(runtime.NewMemoryIdempotencyStore(), window)
+		}
+	}
+	return manager
+}
+
+// findRepoConfig returns the configured repository, nil when it is not configured
+func findRepoConfig(cfg interfaces.IMainConfig, owner, name string) interfaces.IRepoCfg {
+	if cfg == nil || cfg.GetGitHub() == nil {
+		return nil
+	}
+	for _, rc := range cfg.GetGitHub().GetRepos() {
+		if strings.EqualFold(rc.GetOwner(), owner) && strings.EqualFold(rc.GetName(), name) {
+			return rc
+		}
+	}
+	return nil
+}
+
+// operatorParams derives the params of a named operator from the rules of a repository. It
+// reports false when the repository does not configure the rule the operator applies.
+func operatorParams(rc interfaces.IRepoCfg, operator string) (map[string]any, bool) {
+	var rules *gitz.Rules
+	if rc != nil {
+		rules, _ = rc.GetRules().(*gitz.Rules)
+	}
+	if rules == nil {
+		return nil, false
+	}
+	switch operator {
+	case artifacts.OperatorCleanup:
+		if rules.ArtifactsRule == nil {
+			return nil, false
+		}
+		return map[string]any{"max_age_days": rules.ArtifactsRule.GetMaxAgeDays()}, true
+	case workflows.OperatorCleanup:
+		if rules.RunsRule == nil {
+			return nil, false
+		}
+		return map[string]any{
+			"max_age_days":      rules.RunsRule.GetMaxAgeDays(),
+			"keep_success_last": rules.RunsRule.GetKeepSuccessLast(),
+			"only_workflows":    rules.RunsRule.GetOnlyWorkflows(),
+		}, true
+	case monitoring.OperatorInactivity:
+		if m := rules.MonitoringRule; m != nil && m.GetInactiveDaysThreshold() > 0 {
+			return map[string]any{"threshold_days": m.GetInactiveDaysThreshold()}, true
+		}
+	}
+	return map[string]any{}, true
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/render"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func HistoryCmd() *cobra.Command {
+	var repo, dir, sparkDir string
+	var metricNames []string
+	var days, width, height int
+	var asJSON, compact, debug bool
+	retention := history.DefaultRetention()
+
+	short := "Query the recorded metric history of repositories"
+	long := "Queries the history store filled by scorecard and portfolio runs, showing per metric the latest value, the week-over-week delta, the moving average and the trend direction. Without --repo it lists the tracked repositories; --compact applies the retention policy, downsampling old runs into daily and weekly averages."
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: short,
+		Long:  long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			store := history.NewStore(dir)
+
+			var owner, name string
+			if repo != "" {
+				var ok bool
+				owner, name, ok = strings.Cut(repo, "/")
+				if !ok || owner == "" || name == "" {
+					gl.Log("error", fmt.Sprintf("Invalid repository %q, expected owner/name", repo))
+					return
+				}
+			}
+
+			if compact {
+				var before, after int
+				var err error
+				if repo != "" {
+					before, after, err = store.Compact(owner, name, retention, time.Now())
+				} else {
+					before, after, err = store.CompactAll(retention, time.Now())
+				}
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to compact history: %v", err))
+					return
+				}
+				gl.Log("success", fmt.Sprintf("History compacted from %d to %d points", before, after))
+				return
+			}
+
+			if repo == "" {
+				keys, err := store.Repositories()
+				if err != nil {
+					gl.Log("error", err.Error())
+					return
+				}
+				if len(keys) == 0 {
+					gl.Log("info", fmt.Sprintf("No history recorded in %s", store.Dir()))
+					return
+				}
+				for _, key := range keys {
+					fmt.Printf("%s/%s\n", key.Owner, key.Repo)
+				}
+				return
+			}
+
+			points, err := store.Load(owner, name, time.Now().AddDate(0, 0, -days))
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			if len(points) == 0 {
+				gl.Log("info", fmt.Sprintf("No history for %s in the last %d days", repo, days))
+				return
+			}
+			trends := history.Trends(points)
+			if len(metricNames) > 0 {
+				trends = filterTrends(trends, metricNames)
+			}
+
+			if sparkDir != "" {
+				if err := os.MkdirAll(sparkDir, 0o755); err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to create sparkline directory: %v", err))
+					return
+				}
+				for _, t := range trends {
+					path := filepath.Join(sparkDir, fmt.Sprintf("sparkline-%s.svg", t.Metric))
+					if err := render.WriteSparklineSVG(path, t.Values, width, height); err != nil {
+						gl.Log("error", fmt.Sprintf("Failed to write sparkline: %v", err))
+						return
+					}
+				}
+				gl.Log("success", fmt.Sprintf("Sparklines saved to %s", sparkDir))
+			}
+
+			overall := history.OverallDirection(trends)
+			if asJSON {
+				out, _ := json.MarshalIndent(map[string]any{
+					"repository": repo,
+					"points":     len(points),
+					"trend":      overall,
+					"metrics":    trends,
+				}, "", "  ")
+				fmt.Println(string(out))
+				return
+			}
+
+			fmt.Printf("%s: %d points from %s to %s, overall %s\n\n", repo, len(points),
+				points[0].Time.Format("2006-01-02"), points[len(points)-1].Time.Format("2006-01-02"), overall)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "METRIC\tSAMPLES\tLATEST\tWOW\tWOW %\tMOVING AVG\tTREND")
+			for _, t := range trends {
+				fmt.Fprintf(w, "%s\t%d\t%.2f\t%s\t%s\t%.2f\t%s\n",
+					t.Metric, t.Samples, t.Latest, optionalFloat(t.WeekOverWeek, "%+.2f"),
+					optionalFloat(t.WeekOverWeekPct, "%+.1f%%"), t.MovingAverage, t.Direction)
+			}
+			w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVarP(&repo, "repo", "r", "", "repository to query (owner/name); empty lists the tracked repositories")
+	cmd.Flags().StringSliceVarP(&metricNames, "metric", "m", nil, "metrics to show (default: all recorded)")
+	cmd.Flags().IntVarP(&days, "days", "d", 90, "days of history to consider")
+	cmd.Flags().StringVar(&dir, "dir", "", "history store directory (default: $GHBEX_HISTORY_DIR or ~/.kubex/ghbex/history)")
+	cmd.Flags().StringVarP(&sparkDir, "sparklines", "s", "", "write one sparkline SVG per metric into this directory")
+	cmd.Flags().IntVarP(&width, "width", "w", 220, "sparkline width")
+	cmd.Flags().IntVar(&height, "height", 40, "sparkline height")
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "print the trends as JSON")
+	cmd.Flags().BoolVar(&compact, "compact", false, "apply the retention policy instead of querying")
+	cmd.Flags().IntVar(&retention.RawDays, "raw-days", retention.RawDays, "days to keep every run before downsampling to daily averages")
+	cmd.Flags().IntVar(&retention.DailyDays, "daily-days", retention.DailyDays, "days to keep daily averages before downsampling to weekly averages")
+	cmd.Flags().IntVar(&retention.WeeklyDays, "weekly-days", retention.WeeklyDays, "days to keep weekly averages (0 keeps them forever)")
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "enable debug logging")
+
+	return cmd
+}
+
+func filterTrends(trends []history.Trend, names []string) []history.Trend {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	filtered := trends[:0]
+	for _, t := range trends {
+		if wanted[t.Metric] {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+func optionalFloat(v *float64, format string) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf(format, *v)
+}
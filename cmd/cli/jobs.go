@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/jobqueue"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/automation"
+	"github.com/kubex-ecosystem/ghbex/internal/state"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// sanitizeJob is the job kind of a repository sanitization
+const sanitizeJob = "sanitize"
+
+func JobsCmd() *cobra.Command {
+	short := "Inspect and resume the durable job queue"
+	long := "Lists, inspects, resumes and retries the jobs of the durable job queue. Bulk operations such as 'ghbex operations sanitize' enqueue one job per repository and checkpoint every stage (runs, artifacts, releases, persist, notify), so a run interrupted by a crash or Ctrl-C resumes where it stopped."
+
+	cmd := &cobra.Command{
+		Use:     "jobs",
+		Aliases: []string{"job", "queue"},
+		Short:   short,
+		Long:    long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+	}
+	cmd.AddCommand(jobsListCmd())
+	cmd.AddCommand(jobsShowCmd())
+	cmd.AddCommand(jobsResumeCmd())
+	cmd.AddCommand(jobsRetryCmd())
+	cmd.AddCommand(jobsPruneCmd())
+	return cmd
+}
+
+func jobsListCmd() *cobra.Command {
+	var dir, batch string
+	var all, asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the queued jobs",
+		Annotations: GetDescriptions([]string{
+			"This command lists the queued jobs.",
+			"This command lists the unfinished jobs of the queue, or every job with --all, with their status, attempts and checkpointed stages. Running jobs whose process died are flagged as interrupted.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			q, err := jobqueue.Open(dir, jobqueue.Options{})
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			jobs, err := q.List(func(j jobqueue.Job) bool {
+				return (all || jobqueue.Unfinished(j)) && (batch == "" || j.Batch == batch)
+			})
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			if asJSON {
+				data, _ := json.MarshalIndent(jobs, "", "  ")
+				fmt.Println(string(data))
+				return
+			}
+			if len(jobs) == 0 {
+				gl.Log("info", "No jobs")
+				return
+			}
+			now := time.Now()
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "JOB\tREPOSITORY\tSTATUS\tATTEMPTS\tSTAGES\tUPDATED\tERROR")
+			for _, j := range jobs {
+				status := string(j.Status)
+				if q.Stale(j, now) {
+					status += " (interrupted)"
+				}
+				fmt.Fprintf(w, "%s\t%s/%s\t%s\t%d/%d\t%s\t%s\t%s\n", j.ID, j.Owner, j.Repo, status, j.Attempts, j.MaxAttempts,
+					firstNonEmpty(j.Stages.String(), "-"), j.UpdatedAt.Local().Format("2006-01-02 15:04"), j.Error)
+			}
+			w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Job queue directory (default: $GHBEX_JOBS_DIR or ~/.kubex/ghbex/jobs)")
+	cmd.Flags().StringVarP(&batch, "batch", "b", "", "Only list the jobs of this batch")
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Also list the complete, failed and timed out jobs")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the jobs as JSON")
+
+	return cmd
+}
+
+func jobsShowCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "show <job>",
+		Short: "Show a job with its transitions",
+		Args:  cobra.ExactArgs(1),
+		Annotations: GetDescriptions([]string{
+			"This command shows a job.",
+			"This command prints a job, including its checkpoint and result, followed by every state transition it went through.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			q, err := jobqueue.Open(dir, jobqueue.Options{})
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			job, err := q.Get(args[0])
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			data, _ := json.MarshalIndent(job, "", "  ")
+			fmt.Println(string(data))
+
+			history, err := q.History(job.ID)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			fmt.Println()
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TIME\tTRANSITION\tATTEMPT\tDETAIL")
+			for _, t := range history {
+				transition := fmt.Sprintf("%s → %s", firstNonEmpty(string(t.From), "-"), t.To)
+				detail := t.Error
+				if t.Stage != 0 {
+					transition, detail = "checkpoint", t.Stage.String()
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", t.Time.Local().Format("2006-01-02 15:04:05"), transition, t.Attempt, detail)
+			}
+			w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Job queue directory (default: $GHBEX_JOBS_DIR or ~/.kubex/ghbex/jobs)")
+
+	return cmd
+}
+
+func jobsResumeCmd() *cobra.Command {
+	var dir, configPath, batch string
+	var debug bool
+
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Run the pending and interrupted jobs",
+		Annotations: GetDescriptions([]string{
+			"This command resumes the queued jobs.",
+			"This command runs the pending jobs, the retries that are due and the jobs interrupted by a crash, skipping the stages they already checkpointed. Jobs keep the dry run setting they were enqueued with.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			cfg, err := loadWebhookConfig(configPath)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			q, err := jobqueue.Open(dir, jobqueue.Options{})
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			sum, err := q.Run(ctx, jobHandlers(cfg, newGitHubClient(ctx, cfg)), func(j jobqueue.Job) bool {
+				return batch == "" || j.Batch == batch
+			})
+			logJobSummary(sum, err)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	cmd.Flags().StringVar(&dir, "dir", "", "Job queue directory (default: $GHBEX_JOBS_DIR or ~/.kubex/ghbex/jobs)")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Configuration file (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+	cmd.Flags().StringVarP(&batch, "batch", "b", "", "Only resume the jobs of this batch")
+
+	return cmd
+}
+
+func jobsRetryCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "retry <job...>",
+		Short: "Queue failed or timed out jobs again",
+		Args:  cobra.MinimumNArgs(1),
+		Annotations: GetDescriptions([]string{
+			"This command queues failed jobs again.",
+			"This command puts failed or timed out jobs back to pending with a fresh set of attempts; 'ghbex jobs resume' runs them. Their checkpointed stages are kept.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			q, err := jobqueue.Open(dir, jobqueue.Options{})
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			for _, id := range args {
+				if _, err := q.Retry(id); err != nil {
+					gl.Log("error", err.Error())
+					continue
+				}
+				gl.Log("success", fmt.Sprintf("Job %s queued again", id))
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Job queue directory (default: $GHBEX_JOBS_DIR or ~/.kubex/ghbex/jobs)")
+
+	return cmd
+}
+
+func jobsPruneCmd() *cobra.Command {
+	var dir string
+	var days int
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old finished jobs",
+		Annotations: GetDescriptions([]string{
+			"This command removes old finished jobs.",
+			"This command removes the complete, failed and timed out jobs that finished more than --days days ago.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			q, err := jobqueue.Open(dir, jobqueue.Options{})
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			n, err := q.Prune(time.Now().AddDate(0, 0, -days))
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Removed %d finished jobs", n))
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Job queue directory (default: $GHBEX_JOBS_DIR or ~/.kubex/ghbex/jobs)")
+	cmd.Flags().IntVarP(&days, "days", "d", 30, "Keep the jobs finished within this many days")
+
+	return cmd
+}
+
+// jobHandlers maps the job kinds to their handlers. Sanitize jobs report through the
+// configured notifiers.
+func jobHandlers(cfg interfaces.IMainConfig, ghc *github.Client) map[string]jobqueue.Handler {
+	var ntf []interfaces.INotifier
+	if n := cfg.GetNotifiers(); n != nil {
+		ntf = n.GetNotifiers()
+	}
+	svc := automation.New(ghc, cfg, ntf...)
+	return map[string]jobqueue.Handler{
+		sanitizeJob: func(ctx context.Context, task *jobqueue.Task) (any, error) {
+			job := task.Job()
+			var rules *gitz.Rules
+			if rc := findRepoConfig(cfg, job.Owner, job.Repo); rc != nil {
+				rules, _ = rc.GetRules().(*gitz.Rules)
+			}
+			if rules == nil {
+				return nil, fmt.Errorf("%s/%s has no configured rules", job.Owner, job.Repo)
+			}
+			var rpt *gitz.Report
+			resumed, err := task.Restore(&rpt)
+			if err != nil {
+				return nil, err
+			}
+			if resumed {
+				gl.Log("info", fmt.Sprintf("📊 Resuming %s/%s, skipping %s", job.Owner, job.Repo, job.Stages))
+			}
+			return svc.SanitizeRepoResume(ctx, job.Owner, job.Repo, rules, job.DryRun, rpt, sanitizeCheckpoint{task})
+		},
+	}
+}
+
+// sanitizeCheckpoint records the sanitization stages of a job
+type sanitizeCheckpoint struct{ task *jobqueue.Task }
+
+func (c sanitizeCheckpoint) Done(stage state.Stage) bool { return c.task.Done(stage) }
+func (c sanitizeCheckpoint) Complete(stage state.Stage, rpt *gitz.Report) error {
+	return c.task.Complete(stage, rpt)
+}
+
+func logJobSummary(sum jobqueue.Summary, err error) {
+	msg := fmt.Sprintf("Jobs: %d complete, %d to retry, %d failed, %d timed out, %d interrupted",
+		sum.Complete, sum.Retried, sum.Failed, sum.TimedOut, sum.Interrupted)
+	switch {
+	case errors.Is(err, context.Canceled):
+		gl.Log("warning", msg+" - interrupted, run 'ghbex jobs resume' to continue")
+	case err != nil:
+		gl.Log("error", fmt.Sprintf("%s - %v", msg, err))
+	case sum.Failed+sum.TimedOut > 0:
+		gl.Log("warning", msg)
+	default:
+		gl.Log("success", msg)
+	}
+}
+
+// batchMismatch explains how the stored jobs of a batch differ from the jobs a new run would
+// enqueue (dry run setting or repositories), empty when they match
+func batchMismatch(q *jobqueue.Queue, batch string, want []jobqueue.Job) string {
+	stored, err := q.List(func(j jobqueue.Job) bool { return j.Batch == batch })
+	if err != nil {
+		return fmt.Sprintf("could not be read (%v)", err)
+	}
+	repos := make(map[string]bool, len(want))
+	for _, j := range want {
+		repos[j.Owner+"/"+j.Repo] = true
+	}
+	for _, j := range stored {
+		if len(want) > 0 && j.DryRun != want[0].DryRun {
+			return fmt.Sprintf("was started with dry run %t, this run has dry run %t", j.DryRun, want[0].DryRun)
+		}
+		if !repos[j.Owner+"/"+j.Repo] {
+			return fmt.Sprintf("includes %s/%s, which this run does not", j.Owner, j.Repo)
+		}
+		delete(repos, j.Owner+"/"+j.Repo)
+	}
+	for repo := range repos {
+		return fmt.Sprintf("does not include %s", repo)
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/licenses"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func licensesCmd() *cobra.Command {
+	var owner, reportDir, unknownPolicy string
+	var repos, allow, deny, review []string
+	var proprietary, failOnError, debug, quiet bool
+
+	licensesCmd := &cobra.Command{
+		Use:   "licenses",
+		Short: "Check dependency licenses against the compliance policy.",
+		Annotations: GetDescriptions([]string{
+			"This command checks the licenses of the dependencies of the specified repositories.",
+			"This command evaluates the allow, deny and review lists of the licenses rule, flags copyleft licenses in proprietary services and unknown licenses, and writes SARIF and markdown reports.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			if quiet {
+				gl.Logger.SetLogLevel("error")
+			}
+			if len(repos) == 0 {
+				gl.Log("error", "No repositories specified for license checks.")
+				return
+			}
+			if owner == "" {
+				owner = os.Getenv("GITHUB_REPO_OWNER")
+			}
+
+			cfg, err := config.NewMainConfigType(reportDir, owner, repos, debug, false, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+
+			reports := make([]*licenses.Report, 0, len(repos))
+			errorsFound := 0
+			for _, repo := range repos {
+				repoOwner := owner
+				if parts := strings.SplitN(repo, "/", 2); len(parts) == 2 {
+					repoOwner, repo = parts[0], parts[1]
+				}
+				if repoOwner == "" {
+					gl.Log("warning", fmt.Sprintf("No owner for repository %s. Skipping...", repo))
+					continue
+				}
+
+				policy := licenses.PolicyForRepository(cfg, repoOwner, repo)
+				if cmd.Flags().Changed("allow") {
+					policy.Allow = allow
+				}
+				if cmd.Flags().Changed("deny") {
+					policy.Deny = deny
+				}
+				if cmd.Flags().Changed("review") {
+					policy.Review = review
+				}
+				if cmd.Flags().Changed("proprietary") {
+					policy.Proprietary = proprietary
+				}
+				if cmd.Flags().Changed("unknown") {
+					policy.UnknownPolicy = unknownPolicy
+				}
+
+				report, err := licenses.CheckRepository(ctx, ghc, repoOwner, repo, policy)
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to check licenses for %s/%s: %v", repoOwner, repo, err))
+					continue
+				}
+				for _, f := range report.Findings {
+					if f.Level == licenses.LevelError {
+						errorsFound++
+					}
+				}
+				gl.Log("info", fmt.Sprintf("⚖️ %s/%s: %d dependencies checked, %d issues, %d unknown licenses",
+					repoOwner, repo, report.Checked, report.Issues(), report.Unknown))
+				reports = append(reports, report)
+			}
+
+			markdown := licenses.ToMarkdown(reports)
+			if reportDir == "" {
+				fmt.Println(markdown)
+			} else if err := writeLicensesReports(reportDir, reports, markdown); err != nil {
+				gl.Log("error", err.Error())
+				return
+			} else {
+				gl.Log("success", fmt.Sprintf("License compliance report saved to %s", reportDir))
+			}
+
+			if failOnError && errorsFound > 0 {
+				gl.Log("error", fmt.Sprintf("%d license policy violations found", errorsFound))
+				os.Exit(1)
+			}
+		},
+	}
+
+	licensesCmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	licensesCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode")
+	licensesCmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner of the repositories")
+	licensesCmd.Flags().StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to check (name or owner/name)")
+	licensesCmd.Flags().StringVarP(&reportDir, "report-dir", "R", "", "Directory to write licenses.sarif and licenses.md")
+	licensesCmd.Flags().StringSliceVar(&allow, "allow", []string{}, "SPDX identifiers allowed (overrides the licenses rule)")
+	licensesCmd.Flags().StringSliceVar(&deny, "deny", []string{}, "SPDX identifiers denied (overrides the licenses rule)")
+	licensesCmd.Flags().StringSliceVar(&review, "review", []string{}, "SPDX identifiers requiring review (overrides the licenses rule)")
+	licensesCmd.Flags().BoolVar(&proprietary, "proprietary", false, "Treat the repositories as proprietary services")
+	licensesCmd.Flags().StringVar(&unknownPolicy, "unknown", "review", "Policy for unknown licenses: allow, review or deny")
+	licensesCmd.Flags().BoolVar(&failOnError, "fail-on-error", false, "Exit with status 1 when error-level findings exist")
+
+	licensesCmd.MarkFlagRequired("repo")
+
+	return licensesCmd
+}
+
+func writeLicensesReports(reportDir string, reports []*licenses.Report, markdown string) error {
+	if err := os.MkdirAll(reportDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	sarif, err := licenses.ToSARIF(reports)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "licenses.sarif"), sarif, 0o644); err != nil {
+		return fmt.Errorf("failed to write SARIF report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "licenses.md"), []byte(markdown), 0o644); err != nil {
+		return fmt.Errorf("failed to write markdown report: %w", err)
+	}
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode licenses report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "licenses.json"), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	return nil
+}
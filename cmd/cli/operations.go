@@ -5,14 +5,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/google/go-github/v61/github"
 	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
 	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/fanout"
+	"github.com/kubex-ecosystem/ghbex/internal/ghclient"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/jobqueue"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/analytics"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/intelligence"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/licenses"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/productivity"
 	"github.com/spf13/cobra"
 
@@ -40,6 +48,12 @@ func OperationsCmdList() *cobra.Command {
 	cmds = append(cmds, healthCmd())
 	cmds = append(cmds, sanitizeCmd())
 	cmds = append(cmds, productivityCmd())
+	cmds = append(cmds, alertsCmd())
+	cmds = append(cmds, licensesCmd())
+	cmds = append(cmds, worktimeCmd())
+	cmds = append(cmds, codeownersCmd())
+	cmds = append(cmds, portfolioCmd())
+	cmds = append(cmds, activityCmd())
 
 	// Add more commands as needed
 	operationsCmd.AddCommand(cmds...)
@@ -50,8 +64,8 @@ func OperationsCmdList() *cobra.Command {
 func analyzeCmd() *cobra.Command {
 	var owner string
 	var repos []string
-	var analysisDays int
-	var disableOwnerCheck, debug, quiet bool
+	var analysisDays, concurrency, perOwner int
+	var disableOwnerCheck, debug, quiet, noHistory bool
 
 	analyzeCmd := &cobra.Command{
 		Use:   "analyze",
@@ -107,7 +121,7 @@ func analyzeCmd() *cobra.Command {
 			startTime := time.Now()
 
 			// Initialize global context
-			_, err = config.NewMainConfigType(
+			cfg, err := config.NewMainConfigType(
 				"",
 				owner,
 				repos,
@@ -120,11 +134,7 @@ func analyzeCmd() *cobra.Command {
 				return
 			}
 
-			ghc := github.NewClient(nil)
-			if ghc == nil {
-				gl.Log("error", "Failed to create GitHub client.")
-				return
-			}
+			ghc := ghclient.Track(github.NewClient(nil), ghclient.DefaultRateTracker())
 
 			resultstore := make(map[string]any)
 
@@ -133,9 +143,10 @@ func analyzeCmd() *cobra.Command {
 
 			resultstore["repositories"] = repos
 			resultstore["repositoriesReports"] = make(map[string]any)
-			resultstore["repositoriesInsights"] = make([]*analytics.InsightsReport, len(repos))
+			resultstore["repositoriesInsights"] = make([]*analytics.InsightsReport, 0, len(repos))
 
 			resultstore["health_score"] = make(map[string]float64)
+			resultstore["assessments"] = make(map[string]intelligence.OverallAssessment)
 
 			// Perform analysis for each repository
 			gl.Log("info", fmt.Sprintf("🧠 INTELLIGENCE ANALYSIS - Analyzing %d repositories for %d days", len(repos), analysisDays))
@@ -144,58 +155,62 @@ func analyzeCmd() *cobra.Command {
 			if disableOwnerCheck {
 				gl.Log("warning", "🚨 DISABLING OWNER CHECK - Use with caution. This may lead to unintended analysis of repositories not owned by the specified owner.")
 			}
-			for _, repo := range repos {
-				if strings.Contains(repo, "/") {
-					repoParts := strings.SplitN(repo, "/", 2)
-					if len(repoParts) == 2 {
-						if argOwner != repoParts[0] {
-							if disableOwnerCheck {
-								owner = repoParts[0]
-							} else {
-								gl.Log("warning", fmt.Sprintf("Repository %s does not belong to owner %s. Skipping...", repo, argOwner))
-								continue
-							}
-						}
-						owner = argOwner
-						repo = repoParts[1]
-					}
+			var targets []fanout.Repo
+			for _, target := range fanout.ParseRepos(argOwner, repos) {
+				if target.Owner != argOwner && !disableOwnerCheck {
+					gl.Log("warning", fmt.Sprintf("Repository %s does not belong to owner %s. Skipping...", target, argOwner))
+					continue
 				}
-				// Perform intelligence analysis
-				insights, insightsErr := analytics.AnalyzeRepository(context.Background(), ghc, owner, repo, analysisDays)
-				if insightsErr != nil {
-					gl.Log("error", fmt.Sprintf("Intelligence analysis error for %s: %v", repo, insightsErr))
+				targets = append(targets, target)
+			}
+
+			type repoAnalysis struct {
+				insights *analytics.InsightsReport
+				data     *analytics.InsightsReport
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			opts := fanout.DefaultOptions()
+			if concurrency > 0 {
+				opts.Concurrency = concurrency
+			}
+			if perOwner > 0 {
+				opts.PerOwner = perOwner
+			}
+			opts.OnDone = func(repo fanout.Repo, err error, done, total int) {
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("[%d/%d] Analysis of %s failed: %v", done, total, repo, err))
 					return
 				}
-
-				// Store insights in result store
-				if resultstore["repositoriesInsights"] == nil {
-					resultstore["repositoriesInsights"] = make([]*analytics.InsightsReport, 0)
+				gl.Log("info", fmt.Sprintf("[%d/%d] Analyzed %s", done, total, repo))
+			}
+			report := fanout.Run(ctx, targets, opts, func(ctx context.Context, repo fanout.Repo) (repoAnalysis, error) {
+				// Perform intelligence analysis
+				insights, err := analytics.AnalyzeRepositoryWithPolicy(ctx, ghc, repo.Owner, repo.Name, analysisDays, licenses.PolicyForRepository(cfg, repo.Owner, repo.Name))
+				if err != nil {
+					return repoAnalysis{}, fmt.Errorf("intelligence analysis: %w", err)
 				}
-				resultstore["repositoriesInsights"] = append(resultstore["repositoriesInsights"].([]*analytics.InsightsReport), insights)
-
-				// Store health score
-				if resultstore["health_score"] == nil {
-					resultstore["health_score"] = make(map[string]float64)
+				// Create analytics operator
+				data, err := analytics.GetRepositoryInsights(ctx, ghc, repo.Owner, repo.Name, analysisDays)
+				if err != nil {
+					return repoAnalysis{}, fmt.Errorf("analytics data: %w", err)
 				}
+				return repoAnalysis{insights: insights, data: data}, nil
+			})
 
-				resultstore["health_score"].(map[string]float64)[repo] = insights.HealthScore.Overall
-
-				// Create analytics operator
-				analyticData, analyticDataErr := analytics.GetRepositoryInsights(
-					context.Background(),
-					ghc,
-					owner,
-					repo,
-					analysisDays,
-				)
-				if analyticDataErr != nil {
-					gl.Log("error", fmt.Sprintf("Analytics data error for %s: %v", repo, analyticDataErr))
-					return
+			// Store the results in repository order
+			store := history.NewStore("")
+			for _, res := range report.Results {
+				if res.Err != nil {
+					continue
 				}
-				if resultstore["repositoriesReports"] == nil {
-					resultstore["repositoriesReports"] = make(map[string]any)
+				resultstore["assessments"].(map[string]intelligence.OverallAssessment)[res.Repo.String()] =
+					intelligence.NewOverallAssessment(res.Value.insights, analysisTrend(store, res.Repo, res.Value.insights, !noHistory))
+				resultstore["repositoriesInsights"] = append(resultstore["repositoriesInsights"].([]*analytics.InsightsReport), res.Value.insights)
+				if res.Value.insights.HealthScore != nil {
+					resultstore["health_score"].(map[string]float64)[res.Repo.String()] = res.Value.insights.HealthScore.Overall
 				}
-				resultstore["repositoriesReports"].(map[string]any)[repo] = analyticData
+				resultstore["repositoriesReports"].(map[string]any)[res.Repo.String()] = res.Value.data
 			}
 
 			durationStr := ""
@@ -212,6 +227,10 @@ func analyzeCmd() *cobra.Command {
 					gl.Log("info", fmt.Sprintf("%s: [detailed report omitted]", key))
 				case "repositoriesInsights":
 					gl.Log("info", fmt.Sprintf("%s: %d reports", key, len(value.([]*analytics.InsightsReport))))
+				case "assessments":
+					for repo, assessment := range value.(map[string]intelligence.OverallAssessment) {
+						gl.Log("info", fmt.Sprintf("Assessment - %s: %s", repo, assessment.Summary))
+					}
 				case "health_score":
 					healthScores := value.(map[string]float64)
 					for repo, score := range healthScores {
@@ -221,13 +240,23 @@ func analyzeCmd() *cobra.Command {
 					gl.Log("info", fmt.Sprintf("%s: %v", key, value))
 				}
 			}
+
+			if report.Canceled {
+				gl.Log("warning", fmt.Sprintf("Analysis interrupted: %d of %d repositories analyzed", report.Succeeded(), len(targets)))
+			}
+			if err := report.Err(); err != nil {
+				gl.Log("error", fmt.Sprintf("Analysis incomplete: %v", err))
+			}
 		},
 	}
 
 	analyzeCmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
 	analyzeCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode")
 	analyzeCmd.Flags().IntVarP(&analysisDays, "days", "d", 30, "Number of days to analyze (default: 30 days)")
+	analyzeCmd.Flags().IntVarP(&concurrency, "concurrency", "j", 0, "Repositories analyzed at once (default: $GHBEX_CONCURRENCY or 4)")
+	analyzeCmd.Flags().IntVar(&perOwner, "per-owner", 0, "Repositories of one owner analyzed at once (default: $GHBEX_OWNER_CONCURRENCY or unbounded)")
 	analyzeCmd.Flags().BoolVarP(&disableOwnerCheck, "check-owner", "c", false, "Disable owner check (Use with caution. Default: false)")
+	analyzeCmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not record the run in the history store nor read the trend from it")
 	analyzeCmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner of the repositories (required)")
 	analyzeCmd.Flags().StringSliceVarP(&repos, "repo", "r", []string{}, "Name of the repository (required)")
 
@@ -236,6 +265,24 @@ func analyzeCmd() *cobra.Command {
 	return analyzeCmd
 }
 
+// analysisTrend records the scorecard of an analysis in the history store and returns the
+// overall direction of the recorded runs, or "" when history is disabled or unreadable
+func analysisTrend(store *history.Store, repo fanout.Repo, insights *analytics.InsightsReport, useHistory bool) string {
+	if !useHistory {
+		return ""
+	}
+	now := time.Now()
+	if err := store.Record(repo.Owner, repo.Name, "analyze", now, history.FromScorecard(intelligence.ScorecardFromInsights(insights))); err != nil {
+		gl.Log("warning", fmt.Sprintf("Failed to record history of %s: %v", repo, err))
+	}
+	points, err := store.Load(repo.Owner, repo.Name, now.AddDate(0, 0, -history.DefaultRetention().DailyDays))
+	if err != nil {
+		gl.Log("warning", fmt.Sprintf("Failed to load history of %s: %v", repo, err))
+		return ""
+	}
+	return history.OverallDirection(history.Trends(points))
+}
+
 func healthCmd() *cobra.Command {
 	var owner, repo, reportDir string
 	var analysisDays int
@@ -425,9 +472,10 @@ func healthCmd() *cobra.Command {
 }
 
 func sanitizeCmd() *cobra.Command {
-	var owner string
+	var owner, jobsDir string
 	var repos []string
-	var analysisDays int
+	var analysisDays, maxAttempts int
+	var timeout time.Duration
 	var disableDryRun, debug, quiet bool
 
 	cmd := &cobra.Command{
@@ -484,48 +532,92 @@ func sanitizeCmd() *cobra.Command {
 				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
 				return
 			}
-			ghc := github.NewClient(nil)
-			if ghc == nil {
-				gl.Log("error", "Failed to create GitHub client.")
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			ghc := newGitHubClient(ctx, g)
+
+			// Every repository is a durable job, so an interrupted bulk run resumes where it stopped
+			q, err := jobqueue.Open(jobsDir, jobqueue.Options{MaxAttempts: maxAttempts})
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			unfinished, err := q.List(func(j jobqueue.Job) bool { return j.Kind == sanitizeJob && jobqueue.Unfinished(j) })
+			if err != nil {
+				gl.Log("error", err.Error())
 				return
 			}
+			batch := fmt.Sprintf("sanitize-%s", startTime.Format("20060102T150405"))
+			var jobs []jobqueue.Job
+			for _, repoConfig := range g.GetGitHub().GetRepos() {
+				if rules, _ := repoConfig.GetRules().(*gitz.Rules); rules == nil {
+					gl.Log("info", fmt.Sprintf("📊 Skipping %s/%s - No rules defined", repoConfig.GetOwner(), repoConfig.GetName()))
+					continue
+				}
+				jobs = append(jobs, jobqueue.Job{
+					Kind:    sanitizeJob,
+					Batch:   batch,
+					Owner:   repoConfig.GetOwner(),
+					Repo:    repoConfig.GetName(),
+					DryRun:  dryRun,
+					Timeout: timeout,
+				})
+			}
+
+			if len(unfinished) > 0 {
+				// Only a batch started the same way is resumed implicitly: the stored jobs keep
+				// their dry run setting, so resuming a dry run under --no-dry-run would apply nothing
+				batch = unfinished[0].Batch
+				if reason := batchMismatch(q, batch, jobs); reason != "" {
+					gl.Log("error", fmt.Sprintf("📊 The unfinished sanitization %s %s. Finish it with 'ghbex jobs resume --batch %s' before starting a new one.", batch, reason, batch))
+					return
+				}
+				gl.Log("warning", fmt.Sprintf("📊 Resuming the unfinished sanitization %s (%d repositories left)", batch, len(unfinished)))
+			} else {
+				if _, err := q.Enqueue(jobs...); err != nil {
+					gl.Log("error", err.Error())
+					return
+				}
+				gl.Log("info", fmt.Sprintf("📊 Queued %d repositories as %s", len(jobs), batch))
+			}
 
-			// Create automation service for sanitization
-			//automationSvc := ghbexz.NewAutomationService(g.ghc, g.mainConfig)
+			sum, runErr := q.Run(ctx, jobHandlers(g, ghc), func(j jobqueue.Job) bool { return j.Batch == batch })
+			logJobSummary(sum, runErr)
 
+			jobs, err = q.List(func(j jobqueue.Job) bool { return j.Batch == batch })
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
 			var bulkResults []map[string]any
 			totalRuns := 0
 			totalArtifacts := 0
-
-			cfgRepos := g.GetGitHub().GetRepos()
-			for _, repoConfig := range cfgRepos {
-				if repoConfig.GetRules() == nil {
-					gl.Log("info", fmt.Sprintf("📊 Skipping %s/%s - No rules defined", repoConfig.GetOwner(), repoConfig.GetName()))
-					continue
-				}
-				gl.Log("info", fmt.Sprintf("📊 Processing %s/%s...", repoConfig.GetOwner(), repoConfig.GetName()))
-
-				// TODO: Implement sanitization via automation service
-				// For now, creating mock results based on the original implementation
+			for _, job := range jobs {
 				result := map[string]any{
-					"owner":     repoConfig.GetOwner(),
-					"repo":      repoConfig.GetName(),
-					"runs":      10, // Mock data
-					"artifacts": 5,  // Mock data
-					"releases":  2,  // Mock data
-					"success":   true,
+					"owner":   job.Owner,
+					"repo":    job.Repo,
+					"status":  job.Status,
+					"success": job.Status == jobqueue.StatusComplete,
+				}
+				var rpt gitz.Report
+				if job.Status == jobqueue.StatusComplete && json.Unmarshal(job.Result, &rpt) == nil {
+					result["runs"] = rpt.Runs.Deleted
+					result["artifacts"] = rpt.Artifacts.Deleted
+					result["releases"] = rpt.Releases.DeletedDrafts
+					totalRuns += rpt.Runs.Deleted
+					totalArtifacts += rpt.Artifacts.Deleted
+					gl.Log("info", fmt.Sprintf("✅ %s/%s - Runs: %d, Artifacts: %d", job.Owner, job.Repo, rpt.Runs.Deleted, rpt.Artifacts.Deleted))
+				} else if job.Error != "" {
+					result["error"] = job.Error
 				}
 				bulkResults = append(bulkResults, result)
-				totalRuns += 10
-				totalArtifacts += 5
-
-				gl.Log("info", fmt.Sprintf("✅ %s/%s - Runs: %d, Artifacts: %d", repoConfig.GetOwner(), repoConfig.GetName(), 10, 5))
 			}
 
 			duration := time.Since(startTime)
 
 			response := map[string]any{
 				"bulk_operation":          true,
+				"batch":                   batch,
 				"dry_run":                 dryRun,
 				"started_at":              startTime.Format("2006-01-02 15:04:05"),
 				"duration_ms":             duration.Milliseconds(),
@@ -566,6 +658,9 @@ func sanitizeCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&disableDryRun, "no-dry-run", false, "Disable dry run")
 	cmd.Flags().BoolVar(&debug, "debug", false, "Enable debug mode")
 	cmd.Flags().BoolVar(&quiet, "quiet", false, "Enable quiet mode")
+	cmd.Flags().StringVar(&jobsDir, "jobs-dir", "", "Job queue directory (default: $GHBEX_JOBS_DIR or ~/.kubex/ghbex/jobs)")
+	cmd.Flags().IntVar(&maxAttempts, "max-attempts", 3, "Attempts per repository before its job fails")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Timeout of each attempt, e.g. 10m (default: none)")
 
 	return cmd
 }
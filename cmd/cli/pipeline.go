@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/fanout"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/artifacts"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/workflows"
+	"github.com/kubex-ecosystem/ghbex/internal/pipeline"
+	"github.com/kubex-ecosystem/ghbex/internal/runtime"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func PipelineCmd() *cobra.Command {
+	short := "Run operator pipelines declared as DAGs"
+	long := "Runs pipelines declared in YAML, whose nodes are registered operators with params. Edges wait for upstream nodes and can carry conditions on their metrics and insights (e.g. sanitize only when the health score is below 60), params can take values from upstream outputs, and independent nodes run in parallel."
+
+	cmd := &cobra.Command{
+		Use:     "pipeline",
+		Aliases: []string{"pipelines", "pipe"},
+		Short:   short,
+		Long:    long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+	}
+	cmd.AddCommand(pipelineRunCmd())
+	cmd.AddCommand(pipelinePlanCmd())
+	return cmd
+}
+
+func pipelineRunCmd() *cobra.Command {
+	var configPath string
+	var repos []string
+	var retries int
+	var timeout time.Duration
+	var disableDryRun, debug, asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "run <file>",
+		Short: "Run a pipeline",
+		Args:  cobra.ExactArgs(1),
+		Annotations: GetDescriptions([]string{
+			"This command runs a pipeline.",
+			"This command prints the execution plan of a pipeline and runs it on every repository. Without --no-dry-run the operators only report what they would do, so the plan and the branches taken can be checked safely.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			cfg, def, targets, err := loadPipeline(configPath, args[0], repos)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+
+			mws := []runtime.Middleware{
+				runtime.WithMeter(func(fields map[string]any) {
+					gl.Log("debug", fmt.Sprintf("%s on %v took %dms (err %v)", fields["op"], fields["repo"], fields["dur_ms"], fields["err"]))
+				}),
+				runtime.WithRetry(retries+1, time.Second, nil),
+				runtime.WithTimeout(timeout),
+			}
+			manager := newOperatorManager(cfg, mws...)
+			if err := def.Validate(manager.Registry()); err != nil {
+				gl.Log("error", fmt.Sprintf("Invalid pipeline: %v", err))
+				return
+			}
+			plan, err := pipeline.FormatPlan(def, fanoutNames(targets), dryRun)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			if !asJSON {
+				fmt.Println(plan)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			exec := &pipeline.Executor{
+				Manager: manager,
+				Clients: runtime.ClientBundle{GitHub: newGitHubClient(ctx, cfg)},
+				DryRun:  dryRun,
+				Params:  pipelineParams(cfg),
+				OnNode: func(repo runtime.RepoRef, res pipeline.NodeResult) {
+					switch res.Status {
+					case pipeline.StatusFailed:
+						gl.Log("error", fmt.Sprintf("%s/%s %s: %s", repo.Owner, repo.Name, res.Node, res.Error))
+					case pipeline.StatusSkipped:
+						gl.Log("info", fmt.Sprintf("%s/%s %s skipped: %s", repo.Owner, repo.Name, res.Node, res.Reason))
+					default:
+						gl.Log("success", fmt.Sprintf("%s/%s %s done in %s", repo.Owner, repo.Name, res.Node, res.Duration.Round(time.Millisecond)))
+					}
+				},
+			}
+			report := fanout.Run(ctx, targets, fanout.DefaultOptions(), func(ctx context.Context, repo fanout.Repo) (*pipeline.Result, error) {
+				res, err := exec.Run(ctx, def, runtime.RepoRef{Owner: repo.Owner, Name: repo.Name})
+				if err != nil {
+					return res, err
+				}
+				return res, res.Err()
+			})
+
+			results := make([]*pipeline.Result, 0, len(report.Results))
+			for _, r := range report.Results {
+				if r.Value != nil {
+					results = append(results, r.Value)
+				}
+			}
+			if asJSON {
+				data, _ := json.MarshalIndent(results, "", "  ")
+				fmt.Println(string(data))
+			} else {
+				printPipelineResults(results)
+			}
+			if err := report.Err(); err != nil {
+				gl.Log("error", fmt.Sprintf("Pipeline %s incomplete: %v", def.Name, err))
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Pipeline %s done on %d repositories (dry run %t)", def.Name, len(targets), dryRun))
+		},
+	}
+
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "Let the operators apply their changes")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Configuration file (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+	cmd.Flags().StringSliceVarP(&repos, "repo", "r", nil, "Repositories (owner/name) to run on (default: the pipeline repos, or the configured ones)")
+	cmd.Flags().IntVar(&retries, "retries", 1, "Retries of a failed node")
+	cmd.Flags().DurationVar(&timeout, "node-timeout", 10*time.Minute, "Timeout of each node")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the results as JSON")
+
+	return cmd
+}
+
+func pipelinePlanCmd() *cobra.Command {
+	var configPath string
+	var repos []string
+
+	cmd := &cobra.Command{
+		Use:   "plan <file>",
+		Short: "Validate a pipeline and print its execution plan",
+		Args:  cobra.ExactArgs(1),
+		Annotations: GetDescriptions([]string{
+			"This command prints the execution plan of a pipeline.",
+			"This command validates a pipeline against the registered operators and prints its steps, edges, conditions and params without running anything.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, def, targets, err := loadPipeline(configPath, args[0], repos)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			if err := def.Validate(newOperatorManager(cfg).Registry()); err != nil {
+				gl.Log("error", fmt.Sprintf("Invalid pipeline: %v", err))
+				return
+			}
+			plan, err := pipeline.FormatPlan(def, fanoutNames(targets), true)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			fmt.Println(plan)
+		},
+	}
+
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Configuration file (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+	cmd.Flags().StringSliceVarP(&repos, "repo", "r", nil, "Repositories (owner/name) to run on (default: the pipeline repos, or the configured ones)")
+
+	return cmd
+}
+
+// loadPipeline loads the configuration and the pipeline, and picks its repositories: the
+// flags, then the pipeline repos, then the configured repositories
+func loadPipeline(configPath, file string, repos []string) (interfaces.IMainConfig, *pipeline.Definition, []fanout.Repo, error) {
+	cfg, err := loadWebhookConfig(configPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	def, err := pipeline.Load(file)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(repos) == 0 {
+		repos = def.Repos
+	}
+	if len(repos) == 0 && cfg.GetGitHub() != nil {
+		for _, rc := range cfg.GetGitHub().GetRepos() {
+			repos = append(repos, rc.GetOwner()+"/"+rc.GetName())
+		}
+	}
+	targets := fanout.ParseRepos("", repos)
+	for _, t := range targets {
+		if t.Owner == "" {
+			return nil, nil, nil, fmt.Errorf("repository %s should be owner/name", t.Name)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, nil, nil, fmt.Errorf("no repositories to run %s on", def.Name)
+	}
+	return cfg, def, targets, nil
+}
+
+// pipelineParams takes the base params of a node from the configured rules of its operator.
+// Cleanup operators without a configured rule need explicit params, so that they never run
+// with their defaults by accident.
+func pipelineParams(cfg interfaces.IMainConfig) func(runtime.RepoRef, pipeline.Node) (map[string]any, error) {
+	return func(repo runtime.RepoRef, node pipeline.Node) (map[string]any, error) {
+		params, ok := operatorParams(findRepoConfig(cfg, repo.Owner, repo.Name), node.Operator)
+		if ok {
+			return params, nil
+		}
+		if (node.Operator == artifacts.OperatorCleanup || node.Operator == workflows.OperatorCleanup) && len(node.Params) == 0 {
+			return nil, fmt.Errorf("%s has no configured rule for %s/%s and the node sets no params", node.Operator, repo.Owner, repo.Name)
+		}
+		return nil, nil
+	}
+}
+
+func fanoutNames(repos []fanout.Repo) []string {
+	out := make([]string, 0, len(repos))
+	for _, r := range repos {
+		out = append(out, r.String())
+	}
+	return out
+}
+
+func printPipelineResults(results []*pipeline.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPOSITORY\tNODE\tSTATUS\tDURATION\tDETAIL")
+	for _, res := range results {
+		for _, n := range res.Nodes {
+			detail := firstNonEmpty(n.Error, n.Reason)
+			if detail == "" && len(n.Metrics) > 0 {
+				metrics := make([]string, 0, len(n.Metrics))
+				for _, m := range n.Metrics {
+					metrics = append(metrics, fmt.Sprintf("%s=%g", m.Name, m.Value))
+				}
+				sort.Strings(metrics)
+				detail = strings.Join(metrics, ", ")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", res.Repo, n.Node, n.Status, n.Duration.Round(time.Millisecond), detail)
+		}
+	}
+	w.Flush()
+}
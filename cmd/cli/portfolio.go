@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/portfolio"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func portfolioCmd() *cobra.Command {
+	var owner, reportDir string
+	var repos []string
+	var debug, quiet, noHistory bool
+	opts := portfolio.DefaultOptions()
+
+	portfolioCmd := &cobra.Command{
+		Use:   "portfolio",
+		Short: "Roll up all repositories into an org-level scorecard.",
+		Annotations: GetDescriptions([]string{
+			"This command aggregates the analysis of every configured repository into one portfolio scorecard.",
+			"This command collects insights, automation and scorecard reports per repository and writes percentile rankings, grade distributions, worst-N lists, totals and team or topic groups as JSON, markdown and HTML.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			if quiet {
+				gl.Logger.SetLogLevel("error")
+			}
+			if owner == "" {
+				owner = os.Getenv("GITHUB_REPO_OWNER")
+			}
+			switch opts.GroupBy {
+			case portfolio.GroupByNone, portfolio.GroupByTopic, portfolio.GroupByTeam:
+			default:
+				gl.Log("error", fmt.Sprintf("Invalid --group-by %q, expected topic or team", opts.GroupBy))
+				return
+			}
+
+			cfg, err := config.NewMainConfigType(reportDir, owner, repos, debug, false, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+
+			targets := repositoryTargets(owner, repos)
+			if len(targets) == 0 && cfg.GetGitHub() != nil {
+				for _, rc := range cfg.GetGitHub().GetRepos() {
+					targets = append(targets, portfolio.Repository{Owner: rc.GetOwner(), Name: rc.GetName()})
+				}
+			}
+			if len(targets) == 0 {
+				gl.Log("error", "No repositories specified or configured for the portfolio.")
+				return
+			}
+
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+			opts.Config = cfg
+
+			gl.Log("info", fmt.Sprintf("📊 Collecting %d repositories over %d days...", len(targets), opts.Days))
+			store := history.NewStore("")
+			results := make([]*portfolio.Result, 0, len(targets))
+			for _, target := range targets {
+				result, err := portfolio.Collect(ctx, ghc, target, opts)
+				if err != nil {
+					gl.Log("error", err.Error())
+					continue
+				}
+				for _, e := range result.Errors {
+					gl.Log("warning", fmt.Sprintf("%s/%s: %s", target.Owner, target.Name, e))
+				}
+				if !noHistory {
+					if err := store.Record(target.Owner, target.Name, "portfolio", time.Now(), portfolio.Snapshot(result)); err != nil {
+						gl.Log("warning", fmt.Sprintf("Failed to record history for %s/%s: %v", target.Owner, target.Name, err))
+					}
+				}
+				results = append(results, result)
+			}
+
+			rollup := portfolio.Build(results, opts, time.Now())
+			markdown := portfolio.ToMarkdown(rollup)
+
+			dir := reportDir
+			if dir == "" {
+				dir = filepath.Join(cfg.GetRuntime().GetReportDir(), "portfolio")
+			}
+			year, week := rollup.GeneratedAt.ISOWeek()
+			dir = filepath.Join(dir, fmt.Sprintf("%d-W%02d", year, week))
+			if err := writePortfolioReports(dir, rollup, markdown); err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Portfolio of %d repositories saved to %s", rollup.Totals.Repositories, dir))
+		},
+	}
+
+	portfolioCmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	portfolioCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode")
+	portfolioCmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner of the repositories")
+	portfolioCmd.Flags().StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to include (default: all configured repositories)")
+	portfolioCmd.Flags().StringVarP(&reportDir, "report-dir", "R", "", "Directory for the weekly portfolio reports (default: <report dir>/portfolio)")
+	portfolioCmd.Flags().IntVarP(&opts.Days, "days", "d", opts.Days, "Number of days to analyze")
+	portfolioCmd.Flags().StringVarP(&opts.GroupBy, "group-by", "g", "", "Group repositories by topic or team")
+	portfolioCmd.Flags().IntVarP(&opts.WorstN, "worst", "w", opts.WorstN, "Length of the worst-N list per metric")
+	portfolioCmd.Flags().BoolVar(&opts.SkipAutomation, "skip-automation", false, "Skip the automation analysis")
+	portfolioCmd.Flags().BoolVar(&opts.SkipBranches, "skip-branches", false, "Skip the stale branch scan")
+	portfolioCmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not record the run in the history store")
+
+	return portfolioCmd
+}
+
+func repositoryTargets(owner string, repos []string) []portfolio.Repository {
+	targets := make([]portfolio.Repository, 0, len(repos))
+	for _, repo := range repos {
+		repoOwner := owner
+		if parts := strings.SplitN(repo, "/", 2); len(parts) == 2 {
+			repoOwner, repo = parts[0], parts[1]
+		}
+		if repoOwner == "" {
+			gl.Log("warning", fmt.Sprintf("No owner for repository %s. Skipping...", repo))
+			continue
+		}
+		targets = append(targets, portfolio.Repository{Owner: repoOwner, Name: repo})
+	}
+	return targets
+}
+
+func writePortfolioReports(dir string, rollup *portfolio.Rollup, markdown string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	data, err := json.MarshalIndent(rollup, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode portfolio: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "portfolio.json"), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "portfolio.md"), []byte(markdown), 0o644); err != nil {
+		return fmt.Errorf("failed to write markdown report: %w", err)
+	}
+	page, err := portfolio.ToHTML(rollup)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "portfolio.html"), page, 0o644); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
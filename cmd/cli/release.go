@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/releases"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func ReleaseCmd() *cobra.Command {
+	var repo, notesPath string
+	var force, disableDryRun, debug bool
+	opts := releases.DefaultPlanOptions()
+
+	short := "Create the next semantic release"
+	long := "Computes the next semantic version from the conventional commits since the latest tag, then creates the tag and a draft release with generated notes and contributor credits, optionally uploading build artifacts."
+
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: short,
+		Long:  long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+
+			owner, name, ok := strings.Cut(repo, "/")
+			if !ok || owner == "" || name == "" {
+				gl.Log("error", fmt.Sprintf("Invalid repository %q, expected owner/name", repo))
+				return
+			}
+
+			cfg, err := config.NewMainConfigType("", owner, []string{name}, debug, disableDryRun, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+
+			opts.Force = force
+			plan, err := releases.PlanRelease(ctx, ghc, owner, name, opts)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to plan release: %v", err))
+				return
+			}
+			if plan.Tag == "" {
+				gl.Log("info", fmt.Sprintf("No releasable commits since %s (%d commits), nothing to do", plan.PreviousTag, plan.Commits))
+				return
+			}
+
+			previous := plan.PreviousTag
+			if previous == "" {
+				previous = "none"
+			}
+			gl.Log("info", fmt.Sprintf("Planned version %s (%s bump, previous %s, %d commits)", plan.Version.String(), plan.Bump, previous, plan.Commits))
+			gl.Log("info", fmt.Sprintf("Tag %s at %s (%.7s), prerelease: %t", plan.Tag, plan.Target, plan.TargetSHA, plan.Prerelease))
+			for _, asset := range plan.Assets {
+				gl.Log("info", fmt.Sprintf("Asset: %s", asset))
+			}
+			if notesPath == "" {
+				fmt.Print(plan.Notes)
+			} else if err := os.WriteFile(notesPath, []byte(plan.Notes), 0o644); err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to write release notes: %v", err))
+				return
+			}
+
+			rel, err := releases.Publish(ctx, ghc, plan, dryRun)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to publish release: %v", err))
+				return
+			}
+			if dryRun {
+				gl.Log("info", fmt.Sprintf("DRY RUN: would create tag %s and draft release with %d assets", plan.Tag, len(plan.Assets)))
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Created draft release %s: %s", plan.Tag, rel.GetHTMLURL()))
+		},
+	}
+
+	cmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/name)")
+	cmd.Flags().StringVar(&opts.Channel, "channel", "", "Prerelease channel, e.g. rc for vX.Y.Z-rc.N")
+	cmd.Flags().StringVar(&opts.Prefix, "prefix", opts.Prefix, "Tag prefix")
+	cmd.Flags().StringVar(&opts.Target, "target", "", "Branch or commit to release (default: default branch)")
+	cmd.Flags().StringVar(&opts.Initial, "initial", opts.Initial, "Version of the first release when there are no tags")
+	cmd.Flags().StringVarP(&opts.AssetsDir, "assets", "a", "", "Directory of build artifacts to upload")
+	cmd.Flags().StringVarP(&notesPath, "notes", "o", "", "Write the release notes to a file (default: stdout)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Release a patch even when no commit requires one")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "Disable dry run (default: false)")
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+
+	cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
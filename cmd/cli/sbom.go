@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/module/version"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/deps"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/sbom"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func SBOMCmd() *cobra.Command {
+	var repo, format, outPath, release string
+	var attach, disableDryRun, debug bool
+
+	short := "Generate a software bill of materials"
+	long := "Generates an SPDX or CycloneDX JSON SBOM from the repository's dependency manifests and lockfiles, optionally attaching it to a release."
+
+	cmd := &cobra.Command{
+		Use:   "sbom",
+		Short: short,
+		Long:  long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+
+			owner, name, ok := strings.Cut(repo, "/")
+			if !ok || owner == "" || name == "" {
+				gl.Log("error", fmt.Sprintf("Invalid repository %q, expected owner/name", repo))
+				return
+			}
+
+			cfg, err := config.NewMainConfigType("", owner, []string{name}, debug, disableDryRun, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+
+			// An attached SBOM must describe the release it is attached to, not the default branch
+			if attach && release == "" {
+				release, err = sbom.LatestReleaseTag(ctx, ghc, owner, name)
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to resolve the latest release: %v", err))
+					return
+				}
+				gl.Log("info", fmt.Sprintf("Describing latest release %s", release))
+			}
+
+			files, err := deps.FetchManifests(ctx, ghc, owner, name, release)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to fetch manifests: %v", err))
+				return
+			}
+			if len(files) == 0 {
+				gl.Log("warning", fmt.Sprintf("No supported manifests found in %s", repo))
+			}
+			inventory := deps.Analyze(files, nil)
+
+			data, err := sbom.Generate(format, sbom.Meta{
+				Owner:       owner,
+				Repo:        name,
+				Ref:         release,
+				ToolVersion: version.GetVersion(),
+			}, inventory.Dependencies)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to generate SBOM: %v", err))
+				return
+			}
+
+			if outPath == "" {
+				fmt.Println(string(data))
+			} else {
+				if err := os.WriteFile(outPath, data, 0o644); err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to write SBOM: %v", err))
+					return
+				}
+				gl.Log("success", fmt.Sprintf("SBOM with %d packages written to %s", len(inventory.Dependencies), outPath))
+			}
+
+			if !attach {
+				return
+			}
+			assetName := sbom.FileName(name, format)
+			rel, err := sbom.AttachToRelease(ctx, ghc, owner, name, release, assetName, data, dryRun)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to attach SBOM: %v", err))
+				return
+			}
+			if dryRun {
+				gl.Log("info", fmt.Sprintf("DRY RUN: would attach %s to release %s", assetName, rel.GetTagName()))
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Attached %s to release %s", assetName, rel.GetTagName()))
+		},
+	}
+
+	cmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/name)")
+	cmd.Flags().StringVarP(&format, "format", "f", sbom.FormatSPDXJSON, "SBOM format: spdx-json or cyclonedx-json")
+	cmd.Flags().StringVarP(&outPath, "out", "o", "", "Output file (default: stdout)")
+	cmd.Flags().StringVar(&release, "release", "", "Release tag to describe and attach to (default: the default branch, or the latest release with --attach)")
+	cmd.Flags().BoolVarP(&attach, "attach", "a", false, "Attach the SBOM as an asset to the release")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "Disable dry run (default: false)")
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+
+	cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/runtime"
+	"github.com/kubex-ecosystem/ghbex/internal/scheduler"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func ScheduleCmd() *cobra.Command {
+	short := "Run operators on cron schedules"
+	long := "Runs operators on the cron schedules of the configuration (runtime.schedules for every repository, schedules for a single one), e.g. the sanitization nightly, the full analytics weekly and the inactivity check daily. Runs are spread with a per-job jitter, a lock file keeps a single scheduler running and the last and next runs are persisted, so that runs missed while ghbex was down are caught up on start."
+
+	cmd := &cobra.Command{
+		Use:     "schedule",
+		Aliases: []string{"scheduler", "cron"},
+		Short:   short,
+		Long:    long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+	}
+	cmd.AddCommand(scheduleListCmd())
+	cmd.AddCommand(scheduleRunNowCmd())
+	cmd.AddCommand(scheduleStartCmd())
+	return cmd
+}
+
+func scheduleListCmd() *cobra.Command {
+	var configPath string
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the scheduled jobs with their last and next runs",
+		Annotations: GetDescriptions([]string{
+			"This command lists the scheduled jobs.",
+			"This command lists every scheduled job with its last run, its outcome and its next run, flags the runs missed while no scheduler was running and shows which instance holds the scheduler lock.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadWebhookConfig(configPath)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			sched := newScheduler(cfg, nil, true)
+			plan, err := sched.Plan(time.Now())
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			if asJSON {
+				data, _ := json.MarshalIndent(plan, "", "  ")
+				fmt.Println(string(data))
+				return
+			}
+
+			if info, ok := scheduler.ReadLock(sched.LockPath()); ok {
+				fmt.Printf("Scheduler running: pid %d on %s since %s\n\n", info.PID, info.Host, info.Started.Local().Format("2006-01-02 15:04"))
+			} else {
+				fmt.Printf("Scheduler not running\n\n")
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "JOB\tCRON\tLAST RUN\tSTATUS\tNEXT RUN\tRUNS")
+			for _, st := range plan {
+				last, status := "-", "-"
+				if !st.LastRun.IsZero() {
+					last = st.LastRun.Local().Format("2006-01-02 15:04")
+					status = st.LastStatus
+				}
+				next := st.NextRun.Local().Format("2006-01-02 15:04")
+				if st.Missed {
+					next += " (missed)"
+				}
+				if st.DryRun {
+					status += " (dry run)"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n", st.ID, st.Expr, last, status, next, st.Runs)
+			}
+			w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Configuration file (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the schedule as JSON")
+
+	return cmd
+}
+
+func scheduleRunNowCmd() *cobra.Command {
+	var configPath string
+	var disableDryRun, debug bool
+
+	cmd := &cobra.Command{
+		Use:   "run-now <job, schedule name, operator or owner/repo...>",
+		Short: "Run scheduled jobs immediately",
+		Args:  cobra.MinimumNArgs(1),
+		Annotations: GetDescriptions([]string{
+			"This command runs scheduled jobs immediately.",
+			"This command runs the scheduled jobs matching the given job IDs, schedule names, operators or repositories right away. The run is recorded in the scheduler state without moving the next scheduled run. Without --no-dry-run the operators only report what they would do.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			cfg, err := loadWebhookConfig(configPath)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			jobs, err := newScheduler(cfg, nil, dryRun).RunNow(ctx, args...)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			gl.Log("success", fmt.Sprintf("Ran %d scheduled jobs", len(jobs)))
+		},
+	}
+
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "Let the operators apply their changes")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Configuration file (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+
+	return cmd
+}
+
+func scheduleStartCmd() *cobra.Command {
+	var configPath string
+	var disableDryRun, debug bool
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Run the scheduler in the foreground",
+		Annotations: GetDescriptions([]string{
+			"This command runs the scheduler in the foreground.",
+			"This command runs the scheduled jobs until interrupted, catching up the runs missed since the last scheduler stopped. Only one scheduler runs at a time. Without --no-dry-run the operators only report what they would do.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			cfg, err := loadWebhookConfig(configPath)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if err := newScheduler(cfg, nil, dryRun).Start(ctx); err != nil {
+				if errors.Is(err, scheduler.ErrLocked) {
+					gl.Log("error", fmt.Sprintf("Another scheduler is already running (see 'ghbex schedule list'): %v", err))
+					return
+				}
+				gl.Log("error", fmt.Sprintf("Scheduler stopped: %v", err))
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "Let the operators apply their changes")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Configuration file (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+
+	return cmd
+}
+
+// newScheduler builds the scheduler of the configured schedules. Jobs are dispatched through
+// the operator manager with the params of the repository rules, and the artifacts of each run
+// are written under <report_dir>/schedule/<date>. A nil manager gets a new one; pass the
+// manager of a webhook receiver to share runs and results with it.
+func newScheduler(cfg interfaces.IMainConfig, manager *runtime.Manager, dryRun bool) *scheduler.Scheduler {
+	jobs, errs := scheduler.JobsFromConfig(cfg)
+	for _, err := range errs {
+		gl.Log("warning", fmt.Sprintf("Ignoring schedule: %v", err))
+	}
+	ghc := newGitHubClient(context.Background(), cfg)
+	if manager == nil {
+		manager = newOperatorManager(cfg)
+	}
+	reportDir := filepath.Join(cfg.GetRuntime().GetReportDir(), "schedule")
+
+	return scheduler.New(jobs, func(ctx context.Context, job scheduler.Job) error {
+		params, ok := operatorParams(findRepoConfig(cfg, job.Owner, job.Repo), job.Operator)
+		if !ok {
+			return fmt.Errorf("the rule of %s is not configured for %s/%s", job.Operator, job.Owner, job.Repo)
+		}
+		out, err := manager.Dispatch(ctx, job.Operator, runtime.OpInput{
+			Repo:    runtime.RepoRef{Owner: job.Owner, Name: job.Repo},
+			Params:  params,
+			Clients: runtime.ClientBundle{GitHub: ghc},
+			DryRun:  dryRun || job.DryRun,
+		})
+		if err != nil {
+			return err
+		}
+
+		summary := make([]string, 0, len(out.Metrics))
+		for _, m := range out.Metrics {
+			summary = append(summary, fmt.Sprintf("%s=%g", m.Name, m.Value))
+		}
+		gl.Log("success", fmt.Sprintf("⏰ %s: %s (dry run %t)", job.ID, strings.Join(summary, ", "), dryRun || job.DryRun))
+		for _, insight := range out.Insights {
+			gl.Log("warning", fmt.Sprintf("⏰ %s: %s", job.ID, insight.Summary))
+		}
+
+		if len(out.Artifacts) > 0 {
+			dir := filepath.Join(reportDir, time.Now().Format("2006-01-02"))
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("failed to create report directory: %w", err)
+			}
+			for name, data := range out.Artifacts {
+				file := filepath.Join(dir, fmt.Sprintf("%s_%s_%s", job.Owner, job.Repo, name))
+				if err := os.WriteFile(file, data, 0o644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", file, err)
+				}
+			}
+		}
+		return nil
+	}, scheduler.Options{})
+}
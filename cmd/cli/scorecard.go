@@ -1,14 +1,24 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
 	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/analytics"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/dora"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/integrity"
 	"github.com/kubex-ecosystem/ghbex/internal/render"
 	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
 )
 
 type Dora struct {
@@ -28,9 +38,13 @@ type Code struct {
 }
 
 type Community struct {
-	FirstReviewP50 float64   `json:"first_review_p50" yaml:"first_review_p50"`
-	BusFactor      int       `json:"bus_factor" yaml:"bus_factor"`
-	TrendLeadTime  []float64 `json:"trend_lead_time" yaml:"trend_lead_time"`
+	FirstReviewP50    float64   `json:"first_review_p50" yaml:"first_review_p50"`
+	FirstReviewP90    float64   `json:"first_review_p90,omitempty" yaml:"first_review_p90,omitempty"`
+	ReviewCoveragePct float64   `json:"review_coverage_pct,omitempty" yaml:"review_coverage_pct,omitempty"`
+	BusFactor         int       `json:"bus_factor" yaml:"bus_factor"`
+	TrendLeadTime     []float64 `json:"trend_lead_time" yaml:"trend_lead_time"`
+	TrendFirstReview  []float64 `json:"trend_first_review,omitempty" yaml:"trend_first_review,omitempty"`
+	TrendCycleTime    []float64 `json:"trend_cycle_time,omitempty" yaml:"trend_cycle_time,omitempty"` // PR open to merge, hours
 }
 
 type Input struct {
@@ -41,28 +55,178 @@ type Input struct {
 	Dora          Dora      `json:"dora" yaml:"dora"`
 	Code          Code      `json:"code" yaml:"code"`
 	Community     Community `json:"community" yaml:"community"`
+
+	Provenance *metrics.Provenance `json:"provenance,omitempty" yaml:"provenance,omitempty"`
 }
 
 type Files struct {
-	SparkCHI  string `json:"spark_chi" yaml:"spark_chi"`
-	SparkLead string `json:"spark_lead" yaml:"spark_lead"`
-	BadgesMD  string `json:"badges_md_path" yaml:"badges_md_path"`
+	SparkCHI    string `json:"spark_chi" yaml:"spark_chi"`
+	SparkLead   string `json:"spark_lead" yaml:"spark_lead"`
+	SparkReview string `json:"spark_review,omitempty" yaml:"spark_review,omitempty"`
+	SparkCycle  string `json:"spark_cycle,omitempty" yaml:"spark_cycle,omitempty"`
+	BadgesMD    string `json:"badges_md_path" yaml:"badges_md_path"`
 }
 
 type Output struct {
-	CHI    float64  `json:"chi" yaml:"chi"`
-	Grade  string   `json:"grade" yaml:"grade"`
-	Badges []string `json:"badges_md" yaml:"badges_md"`
-	Files  Files    `json:"files" yaml:"files"`
+	CHI       float64  `json:"chi" yaml:"chi"`
+	Grade     string   `json:"grade" yaml:"grade"`
+	DoraGrade string   `json:"dora_grade,omitempty" yaml:"dora_grade,omitempty"`
+	Badges    []string `json:"badges_md" yaml:"badges_md"`
+	Files     Files    `json:"files" yaml:"files"`
+
+	Provenance *metrics.Provenance `json:"provenance,omitempty" yaml:"provenance,omitempty"`
 }
 
-func renderScorecard(inPath, outDir *string, width, height *int) {
+// renderScorecard renders a scorecard file. Its empty trends are filled from the history
+// store, but the file itself is only recorded there when record is set: a hand-written or
+// stale file is not a measurement of the repository.
+func renderScorecard(inPath, outDir *string, width, height *int, useHistory, record bool) {
 
 	b, err := os.ReadFile(*inPath)
 	must(err)
 	var sc Input
 	must(json.Unmarshal(b, &sc))
 
+	if useHistory {
+		applyScorecardHistory(&sc, record)
+	}
+	renderScorecardInput(sc, outDir, width, height)
+}
+
+// renderLiveScorecard computes the DORA and review sections from the repository itself and renders it.
+// When the input file exists, its code section and bus factor are kept.
+func renderLiveScorecard(repo string, opts dora.Options, inPath, outDir *string, width, height *int, useHistory bool) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" {
+		must(fmt.Errorf("invalid repository %q, expected owner/name", repo))
+	}
+
+	var sc Input
+	if b, err := os.ReadFile(*inPath); err == nil {
+		must(json.Unmarshal(b, &sc))
+	}
+
+	ctx := context.Background()
+	cfg, err := config.NewMainConfigType("", owner, []string{name}, false, false, false)
+	must(err)
+
+	ghc := newGitHubClient(ctx, cfg)
+	report, err := dora.ComputeMetrics(ctx, ghc, owner, name, opts)
+	must(err)
+	for _, note := range report.Notes {
+		gl.Log("warning", fmt.Sprintf("DORA: %s", note))
+	}
+	gl.Log("info", fmt.Sprintf("DORA for %s/%s from %s: %d deploys, %d lead times, %d failures",
+		owner, name, report.Source, len(report.Deploys), len(report.LeadTimes), len(report.Failures)))
+
+	sc.Owner = owner
+	sc.Repo = name
+	sc.PeriodDays = opts.PeriodDays
+	sc.Dora = Dora{
+		DeploymentFrequency: report.Metrics.DeploymentFrequency,
+		PeriodUnit:          report.Metrics.PeriodUnit,
+		LeadTimeP95:         report.Metrics.LeadTimeP95,
+		LeadTimeP50:         report.Metrics.LeadTimeP50,
+		ChangeFailRate:      report.Metrics.ChangeFailRate,
+		MTTR:                report.Metrics.MTTR,
+	}
+
+	reviews, err := analytics.ComputeReviewMetrics(ctx, ghc, owner, name, report.Since)
+	if err != nil {
+		gl.Log("warning", fmt.Sprintf("Review metrics: %v", err))
+	} else {
+		gl.Log("info", fmt.Sprintf("Reviews for %s/%s: %d merged PRs, first review p50 %.1fh, coverage %.0f%%",
+			owner, name, reviews.PRsAnalyzed, reviews.FirstReviewP50, reviews.PRReviewRate))
+		sc.Community.FirstReviewP50 = reviews.FirstReviewP50
+		sc.Community.FirstReviewP90 = reviews.FirstReviewP90
+		sc.Community.ReviewCoveragePct = reviews.PRReviewRate
+		sc.Community.TrendCycleTime = reviews.TrendCycleTime
+		sc.Community.TrendFirstReview = reviews.TrendFirstReview
+	}
+
+	sc.Provenance = &metrics.Provenance{
+		Sources: []metrics.DataSource{{Type: "github", Provider: "github.com", Timestamp: time.Now()}},
+	}
+	audit, err := integrity.Audit(ctx, ghc, owner, name, integrity.DefaultOptions())
+	if err != nil {
+		gl.Log("warning", fmt.Sprintf("Release integrity: %v", err))
+	} else {
+		gl.Log("info", fmt.Sprintf("Release integrity for %s/%s: %d tags, %d releases, %d findings",
+			owner, name, audit.TagsChecked, audit.ReleasesChecked, len(audit.Findings)))
+		sc.Provenance.Releases = &metrics.ReleaseIntegrity{
+			TagsChecked:              audit.TagsChecked,
+			ReleasesChecked:          audit.ReleasesChecked,
+			NonSemverTags:            audit.NonSemverTags,
+			LightweightTags:          audit.LightweightTags,
+			UnsignedTags:             audit.UnsignedTags,
+			UnsignedCommits:          audit.UnsignedCommits,
+			UnreachableTags:          audit.UnreachableTags,
+			ReleasesWithoutAssets:    audit.ReleasesWithoutAssets,
+			ReleasesWithoutChecksums: audit.ReleasesWithoutChecksums,
+			ChecksumMismatches:       audit.ChecksumMismatches,
+		}
+	}
+
+	if useHistory {
+		applyScorecardHistory(&sc, true)
+	}
+	renderScorecardInput(sc, outDir, width, height)
+}
+
+// applyScorecardHistory fills the empty trends of the scorecard (CHI, lead time, first review)
+// from the recorded runs of the repository, recording the scorecard first when record is set.
+func applyScorecardHistory(sc *Input, record bool) {
+	if sc.Owner == "" || sc.Repo == "" {
+		return
+	}
+	es := &metrics.EnhancedScorecard{
+		Code: metrics.CodeMetrics{
+			MI:             sc.Code.MI,
+			DuplicationPct: sc.Code.DuplicationPct,
+			CyclomaticAvg:  sc.Code.CyclomaticAvg,
+			Trend:          sc.Code.Trend,
+		},
+		Community: metrics.CommunityMetrics{
+			BusFactor:         sc.Community.BusFactor,
+			FirstReviewP50:    sc.Community.FirstReviewP50,
+			ReviewCoveragePct: sc.Community.ReviewCoveragePct,
+			TrendLeadTime:     sc.Community.TrendLeadTime,
+			TrendFirstReview:  sc.Community.TrendFirstReview,
+		},
+	}
+	if sc.Dora.PeriodUnit != "" {
+		es.Dora = metrics.DoraMetrics{
+			DeploymentFrequency: sc.Dora.DeploymentFrequency,
+			PeriodUnit:          sc.Dora.PeriodUnit,
+			LeadTimeP50:         sc.Dora.LeadTimeP50,
+			LeadTimeP95:         sc.Dora.LeadTimeP95,
+			ChangeFailRate:      sc.Dora.ChangeFailRate,
+			MTTR:                sc.Dora.MTTR,
+		}
+	}
+	if sc.Code.MI > 0 {
+		es.Health.CHI = metrics.ComputeCHI(sc.Code.MI, sc.Code.DuplicationPct, sc.Code.CyclomaticAvg, metrics.DefaultCHI)
+	}
+
+	store := history.NewStore("")
+	now := time.Now()
+	if record {
+		if err := store.Record(sc.Owner, sc.Repo, "scorecard", now, history.FromScorecard(es)); err != nil {
+			gl.Log("warning", fmt.Sprintf("Failed to record history: %v", err))
+		}
+	}
+	points, err := store.Load(sc.Owner, sc.Repo, now.AddDate(0, 0, -history.DefaultRetention().DailyDays))
+	if err != nil {
+		gl.Log("warning", fmt.Sprintf("Failed to load history: %v", err))
+		return
+	}
+	history.ApplyTrends(es, points)
+	sc.Code.Trend = es.Code.Trend
+	sc.Community.TrendLeadTime = es.Community.TrendLeadTime
+	sc.Community.TrendFirstReview = es.Community.TrendFirstReview
+}
+
+func renderScorecardInput(sc Input, outDir *string, width, height *int) {
 	chi := metrics.ComputeCHI(sc.Code.MI, sc.Code.DuplicationPct, sc.Code.CyclomaticAvg, metrics.DefaultCHI)
 	grade := metrics.GradeFromCHI(chi)
 
@@ -84,12 +248,22 @@ func renderScorecard(inPath, outDir *string, width, height *int) {
 		"sparkline-chi.svg")
 	sparkLead := filepath.Join(*outDir,
 		"sparkline-leadtime.svg")
+	sparkReview := filepath.Join(*outDir,
+		"sparkline-review.svg")
+	sparkCycle := filepath.Join(*outDir,
+		"sparkline-cycletime.svg")
 	if len(sc.Code.Trend) > 0 {
 		must(render.WriteSparklineSVG(sparkCHI, sc.Code.Trend, *width, *height))
 	}
 	if len(sc.Community.TrendLeadTime) > 0 {
 		must(render.WriteSparklineSVG(sparkLead, sc.Community.TrendLeadTime, *width, *height))
 	}
+	if len(sc.Community.TrendFirstReview) > 0 {
+		must(render.WriteSparklineSVG(sparkReview, sc.Community.TrendFirstReview, *width, *height))
+	}
+	if len(sc.Community.TrendCycleTime) > 0 {
+		must(render.WriteSparklineSVG(sparkCycle, sc.Community.TrendCycleTime, *width, *height))
+	}
 	// badges.md
 	badgesMD := filepath.Join(*outDir,
 		"badges.md")
@@ -100,9 +274,25 @@ func renderScorecard(inPath, outDir *string, width, height *int) {
 		fmt.Fprintln(f, b)
 	}
 
-	out := Output{CHI: chi, Grade: grade, Badges: badges}
+	out := Output{CHI: chi, Grade: grade, Badges: badges, Provenance: sc.Provenance}
+	if sc.Dora.PeriodUnit != "" {
+		out.DoraGrade = metrics.DoraGrade(metrics.DoraMetrics{
+			DeploymentFrequency: sc.Dora.DeploymentFrequency,
+			PeriodUnit:          sc.Dora.PeriodUnit,
+			LeadTimeP50:         sc.Dora.LeadTimeP50,
+			LeadTimeP95:         sc.Dora.LeadTimeP95,
+			ChangeFailRate:      sc.Dora.ChangeFailRate,
+			MTTR:                sc.Dora.MTTR,
+		})
+	}
 	out.Files.SparkCHI = sparkCHI
 	out.Files.SparkLead = sparkLead
+	if len(sc.Community.TrendFirstReview) > 0 {
+		out.Files.SparkReview = sparkReview
+	}
+	if len(sc.Community.TrendCycleTime) > 0 {
+		out.Files.SparkCycle = sparkCycle
+	}
 	out.Files.BadgesMD = badgesMD
 
 	ob, _ := json.MarshalIndent(out,
@@ -118,11 +308,13 @@ func must(err error) {
 }
 
 func ScoreCardRootCmd() *cobra.Command {
-	var inPath, outDir string
+	var inPath, outDir, repo string
 	var width, height int
+	var noHistory, record bool
+	doraOpts := dora.DefaultOptions()
 
 	short := "Generate a scorecard report"
-	long := "Generates a scorecard report based on the provided input JSON file, including sparklines and badges."
+	long := "Generates a scorecard report based on the provided input JSON file, including sparklines and badges. With --repo, the DORA and review sections are computed live from the repository's deployments, releases, pull requests and issues."
 
 	cmd := &cobra.Command{
 		Use:     "scorecard",
@@ -134,7 +326,11 @@ func ScoreCardRootCmd() *cobra.Command {
 			long,
 		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
 		Run: func(cmd *cobra.Command, args []string) {
-			renderScorecard(&inPath, &outDir, &width, &height)
+			if repo != "" {
+				renderLiveScorecard(repo, doraOpts, &inPath, &outDir, &width, &height, !noHistory)
+				return
+			}
+			renderScorecard(&inPath, &outDir, &width, &height, !noHistory, record)
 		},
 	}
 
@@ -142,6 +338,14 @@ func ScoreCardRootCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&outDir, "out", "o", "dist", "output directory")
 	cmd.Flags().IntVarP(&width, "width", "w", 220, "sparkline width")
 	cmd.Flags().IntVarP(&height, "height", "", 40, "sparkline height")
+	cmd.Flags().StringVarP(&repo, "repo", "r", "", "compute DORA and review metrics live from this repository (owner/name)")
+	cmd.Flags().BoolVar(&noHistory, "no-history", false, "do not record the run in the history store nor fill empty trends from it")
+	cmd.Flags().BoolVar(&record, "record", false, "record the input file in the history store (live --repo runs are recorded unless --no-history)")
+	cmd.Flags().StringVar(&doraOpts.DeployWorkflow, "deploy-workflow", "", "workflow file whose successful runs on the default branch are deploys (e.g. deploy.yml)")
+	cmd.Flags().StringVar(&doraOpts.Environment, "environment", "", "deployment environment to consider (deployments API)")
+	cmd.Flags().IntVar(&doraOpts.PeriodDays, "period-days", doraOpts.PeriodDays, "analysis window in days")
+	cmd.Flags().StringVar(&doraOpts.PeriodUnit, "period-unit", doraOpts.PeriodUnit, "deployment frequency unit (day, week, month)")
+	cmd.Flags().DurationVar(&doraOpts.FailureWindow, "failure-window", doraOpts.FailureWindow, "time after a deploy in which failures, reverts and hotfixes count against it")
 
 	return cmd
 }
@@ -0,0 +1,376 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/runtime"
+	"github.com/kubex-ecosystem/ghbex/internal/webhook"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func WebhooksCmd() *cobra.Command {
+	short := "Receive GitHub webhooks for poll-free metrics"
+	long := "Receives GitHub webhooks (push, pull_request, pull_request_review, issues, workflow_run, release and deployment_status), verifies their X-Hub-Signature-256, normalizes them into an append-only event log, keeps weekly metric aggregates up to date and runs the operators configured as triggers, e.g. the artifact cleanup on every completed workflow run."
+
+	cmd := &cobra.Command{
+		Use:     "webhooks",
+		Aliases: []string{"webhook", "hooks"},
+		Short:   short,
+		Long:    long,
+		Annotations: GetDescriptions([]string{
+			short,
+			long,
+		}, os.Getenv("GHBEX_HIDE_BANNER") == "true"),
+	}
+	cmd.AddCommand(webhooksServeCmd())
+	cmd.AddCommand(webhooksReplayCmd())
+	cmd.AddCommand(webhooksStatsCmd())
+	return cmd
+}
+
+func webhooksServeCmd() *cobra.Command {
+	var configPath, addr, path, secret, eventLog string
+	var disableDryRun, debug bool
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the webhook receiver",
+		Annotations: GetDescriptions([]string{
+			"This command runs the webhook receiver.",
+			"This command listens for GitHub webhook deliveries, logs and aggregates them, and runs the configured triggers. With runtime.background set it also runs the scheduler. Without --no-dry-run the triggered operators only report what they would do.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			cfg, err := loadWebhookConfig(configPath)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			hooks := cfg.GetServer().GetWebhooks()
+			if addr == "" {
+				addr = cfg.GetServer().GetAddr()
+			}
+			if path == "" {
+				path = firstNonEmpty(hooks.GetPath(), webhook.DefaultPath)
+			}
+			if eventLog == "" {
+				eventLog = hooks.GetEventLog()
+			}
+
+			// The receiver and the background scheduler share one manager, so that the same
+			// work triggered by both runs once
+			manager := newOperatorManager(cfg)
+			receiver, err := newWebhookReceiver(cfg, manager, firstNonEmpty(secret, hooks.GetSecret()), webhook.NewLog(eventLog), dryRun)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to start the webhook receiver: %v", err))
+				return
+			}
+
+			mux := http.NewServeMux()
+			mux.Handle(path, receiver)
+			server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				_ = server.Shutdown(shutdown)
+			}()
+			if cfg.GetRuntime().GetBackground() {
+				go func() {
+					if err := newScheduler(cfg, manager, dryRun).Start(ctx); err != nil {
+						gl.Log("warning", fmt.Sprintf("Background scheduler not started: %v", err))
+					}
+				}()
+			}
+
+			gl.Log("info", fmt.Sprintf("📡 Receiving GitHub webhooks on %s%s (event log %s, dry run %t)", addr, path, webhook.NewLog(eventLog).Path(), dryRun))
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				gl.Log("error", fmt.Sprintf("Webhook receiver stopped: %v", err))
+				return
+			}
+			receiver.Wait()
+			gl.Log("info", "Webhook receiver stopped")
+		},
+	}
+
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "Let triggered operators apply their changes")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Configuration file (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+	cmd.Flags().StringVarP(&addr, "addr", "a", "", "Listen address (default: server.addr, $GHBEX_SERVER_ADDR or :8088)")
+	cmd.Flags().StringVarP(&path, "path", "p", "", "Endpoint path (default: server.webhooks.path or "+webhook.DefaultPath+")")
+	cmd.Flags().StringVarP(&secret, "secret", "s", "", "Webhook secret (default: server.webhooks.secret or $GHBEX_WEBHOOK_SECRET)")
+	cmd.Flags().StringVar(&eventLog, "event-log", "", "Event log file (default: server.webhooks.event_log, $GHBEX_EVENT_LOG or ~/.kubex/ghbex/events/events.jsonl)")
+
+	return cmd
+}
+
+func webhooksReplayCmd() *cobra.Command {
+	var configPath, url, secret, eventLog string
+	var disableDryRun, debug bool
+
+	cmd := &cobra.Command{
+		Use:   "replay [fixture files or directories...]",
+		Short: "Replay recorded webhook payloads",
+		Args:  cobra.MinimumNArgs(1),
+		Annotations: GetDescriptions([]string{
+			"This command replays recorded webhook payloads.",
+			"This command signs recorded payloads, named <event>[.<action>].json, and delivers them to an in-process receiver, or with --url to a running one, to exercise the signature check, normalization, aggregates and triggers without GitHub. In-process replays do not write the event log unless --event-log is given.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun := !disableDryRun
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			fixtures, err := webhook.LoadFixtures(args...)
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+
+			var handler http.Handler
+			var receiver *webhook.Receiver
+			if url == "" {
+				cfg, err := loadWebhookConfig(configPath)
+				if err != nil {
+					gl.Log("error", err.Error())
+					return
+				}
+				secret = firstNonEmpty(secret, cfg.GetServer().GetWebhooks().GetSecret())
+				if secret == "" {
+					secret = randomSecret()
+				}
+				var log *webhook.Log
+				if eventLog != "" {
+					log = webhook.NewLog(eventLog)
+				}
+				if receiver, err = newWebhookReceiver(cfg, nil, secret, log, dryRun); err != nil {
+					gl.Log("error", err.Error())
+					return
+				}
+				handler = receiver
+			} else if secret == "" {
+				secret = os.Getenv("GHBEX_WEBHOOK_SECRET")
+			}
+
+			for _, fx := range fixtures {
+				status, body, err := deliverFixture(handler, url, secret, fx)
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("%s: %v", filepath.Base(fx.Path), err))
+					continue
+				}
+				level := "success"
+				if status >= 300 {
+					level = "error"
+				}
+				gl.Log(level, fmt.Sprintf("%s → %d %s", filepath.Base(fx.Path), status, strings.TrimSpace(body)))
+			}
+
+			if receiver != nil {
+				receiver.Wait()
+				printAggregates(receiver.Aggregator().Aggregates())
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	cmd.Flags().BoolVarP(&disableDryRun, "no-dry-run", "n", false, "Let triggered operators apply their changes")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Configuration file with the triggers (default: ~/.kubex/ghbex/config/sanitize.yaml)")
+	cmd.Flags().StringVarP(&url, "url", "u", "", "Deliver to a running receiver at this URL instead of in-process")
+	cmd.Flags().StringVarP(&secret, "secret", "s", "", "Secret used to sign the payloads (default: the configured one, or a random one in-process)")
+	cmd.Flags().StringVar(&eventLog, "event-log", "", "Append the replayed events to this event log")
+
+	return cmd
+}
+
+func webhooksStatsCmd() *cobra.Command {
+	var eventLog, repo string
+	var weeks int
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show the weekly aggregates of the event log",
+		Annotations: GetDescriptions([]string{
+			"This command shows the weekly aggregates of the event log.",
+			"This command replays the webhook event log into weekly per-repository aggregates: pushes, commits, pull request and issue flow, reviews, workflow failures, releases and deploys.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			log := webhook.NewLog(eventLog)
+			agg := webhook.NewAggregator()
+			since := time.Now().AddDate(0, 0, -7*weeks)
+			err := log.Replay(since, func(ev *webhook.Event) error {
+				if repo == "" || strings.EqualFold(ev.Owner+"/"+ev.Repo, repo) {
+					agg.Apply(ev)
+				}
+				return nil
+			})
+			if err != nil {
+				gl.Log("error", err.Error())
+				return
+			}
+			aggregates := agg.Aggregates()
+			if len(aggregates) == 0 {
+				gl.Log("info", fmt.Sprintf("No events in %s for the last %d weeks", log.Path(), weeks))
+				return
+			}
+			if asJSON {
+				out, _ := json.MarshalIndent(aggregates, "", "  ")
+				fmt.Println(string(out))
+				return
+			}
+			printAggregates(aggregates)
+		},
+	}
+
+	cmd.Flags().StringVar(&eventLog, "event-log", "", "Event log file (default: $GHBEX_EVENT_LOG or ~/.kubex/ghbex/events/events.jsonl)")
+	cmd.Flags().StringVarP(&repo, "repo", "r", "", "Only this repository (owner/name)")
+	cmd.Flags().IntVarP(&weeks, "weeks", "w", 12, "Weeks of events to aggregate")
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "Print the aggregates as JSON")
+
+	return cmd
+}
+
+// loadWebhookConfig loads the configuration file holding the server and trigger settings
+func loadWebhookConfig(path string) (interfaces.IMainConfig, error) {
+	if path == "" {
+		path = filepath.Join(config.GetBaseFilesPath(), "config", "sanitize.yaml")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("configuration file %s not found: %w", path, err)
+	}
+	cfg, err := config.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// newWebhookReceiver wires the configured triggers to the operator manager. Triggers only run
+// for configured repositories, with the params of their rules. A nil manager gets a new one.
+func newWebhookReceiver(cfg interfaces.IMainConfig, manager *runtime.Manager, secret string, log *webhook.Log, dryRun bool) (*webhook.Receiver, error) {
+	ghc := newGitHubClient(context.Background(), cfg)
+	if manager == nil {
+		manager = newOperatorManager(cfg)
+	}
+	triggers := webhook.TriggersFromConfig(cfg.GetServer().GetWebhooks())
+	for _, t := range triggers {
+		gl.Log("info", fmt.Sprintf("Trigger: %s on %s %s", t.Operator, t.Event, t.Action))
+	}
+
+	return webhook.NewReceiver(webhook.Options{
+		Secret:   secret,
+		Log:      log,
+		Triggers: triggers,
+		OnTrigger: func(ctx context.Context, t webhook.Trigger, ev *webhook.Event) error {
+			rc := findRepoConfig(cfg, ev.Owner, ev.Repo)
+			params, ok := operatorParams(rc, t.Operator)
+			if !ok {
+				gl.Log("warning", fmt.Sprintf("Skipping %s on %s/%s: repository or rule not configured", t.Operator, ev.Owner, ev.Repo))
+				return nil
+			}
+			out, err := manager.Dispatch(ctx, t.Operator, runtime.OpInput{
+				Repo:           runtime.RepoRef{Owner: ev.Owner, Name: ev.Repo, Head: ev.SHA},
+				Params:         params,
+				Clients:        runtime.ClientBundle{GitHub: ghc},
+				DryRun:         dryRun || t.DryRun,
+				IdempotencyKey: t.Operator + ":" + ev.Delivery,
+			})
+			if err != nil {
+				return err
+			}
+			summary := make([]string, 0, len(out.Metrics))
+			for _, m := range out.Metrics {
+				summary = append(summary, fmt.Sprintf("%s=%g", m.Name, m.Value))
+			}
+			gl.Log("success", fmt.Sprintf("⚡ %s on %s/%s after %s %s: %s (dry run %t)",
+				t.Operator, ev.Owner, ev.Repo, ev.Type, ev.Action, strings.Join(summary, ", "), dryRun || t.DryRun))
+			return nil
+		},
+	})
+}
+
+// deliverFixture signs a fixture and posts it to the handler, or to url when handler is nil
+func deliverFixture(handler http.Handler, url, secret string, fx webhook.Fixture) (int, string, error) {
+	target := url
+	if handler != nil {
+		target = webhook.DefaultPath
+	}
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(fx.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(github.EventTypeHeader, fx.Event)
+	req.Header.Set(github.DeliveryIDHeader, fx.Delivery)
+	if secret != "" {
+		req.Header.Set(github.SHA256SignatureHeader, webhook.Sign(secret, fx.Payload))
+	}
+
+	if handler != nil {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.String(), nil
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return resp.StatusCode, string(body), nil
+}
+
+func printAggregates(aggregates []webhook.Aggregate) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPOSITORY\tWEEK\tEVENTS\tCOMMITS\tPRS OPENED\tMERGED\tREVIEWS\tISSUES +/-\tRUNS (FAILED)\tRELEASES\tDEPLOYS")
+	for _, a := range aggregates {
+		fmt.Fprintf(w, "%s/%s\t%s\t%d\t%d\t%d\t%d\t%d\t+%d/-%d\t%d (%d)\t%d\t%d\n",
+			a.Owner, a.Repo, a.Week.Format("2006-01-02"), a.Events, a.Commits, a.PROpened, a.PRMerged,
+			a.Reviews, a.IssuesOpened, a.IssuesClosed, a.WorkflowRuns, a.WorkflowFailures, a.Releases, a.Deploys)
+	}
+	w.Flush()
+}
+
+func randomSecret() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
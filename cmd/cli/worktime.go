@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/worktime"
+	"github.com/kubex-ecosystem/ghbex/internal/render"
+	"github.com/spf13/cobra"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+func worktimeCmd() *cobra.Command {
+	var owner, reportDir string
+	var repos []string
+	var noResolve, debug, quiet bool
+	opts := worktime.DefaultOptions()
+
+	worktimeCmd := &cobra.Command{
+		Use:   "worktime",
+		Short: "Analyze timezones and working hours from commit timestamps.",
+		Annotations: GetDescriptions([]string{
+			"This command analyzes when contributors of the specified repositories work.",
+			"This command uses the UTC offsets of commit author dates to compute the timezone spread, weekend and after-hours ratios, follow-the-sun coverage and a 7x24 activity heatmap.",
+		}, false),
+		Run: func(cmd *cobra.Command, args []string) {
+			if debug {
+				os.Setenv("DEBUG", "true")
+				gl.SetDebug(true)
+			}
+			if quiet {
+				gl.Logger.SetLogLevel("error")
+			}
+			if len(repos) == 0 {
+				gl.Log("error", "No repositories specified for working-hours analysis.")
+				return
+			}
+			if owner == "" {
+				owner = os.Getenv("GITHUB_REPO_OWNER")
+			}
+			opts.ResolveOffsets = !noResolve
+
+			cfg, err := config.NewMainConfigType(reportDir, owner, repos, debug, false, false)
+			if err != nil {
+				gl.Log("error", fmt.Sprintf("Failed to initialize global context: %v", err))
+				return
+			}
+
+			ctx := context.Background()
+			ghc := newGitHubClient(ctx, cfg)
+
+			if reportDir != "" {
+				if err := os.MkdirAll(reportDir, 0o755); err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to create report directory: %v", err))
+					return
+				}
+			}
+
+			for _, repo := range repos {
+				repoOwner := owner
+				if parts := strings.SplitN(repo, "/", 2); len(parts) == 2 {
+					repoOwner, repo = parts[0], parts[1]
+				}
+				if repoOwner == "" {
+					gl.Log("warning", fmt.Sprintf("No owner for repository %s. Skipping...", repo))
+					continue
+				}
+
+				report, err := worktime.Analyze(ctx, ghc, repoOwner, repo, opts)
+				if err != nil {
+					gl.Log("error", fmt.Sprintf("Failed to analyze working hours for %s/%s: %v", repoOwner, repo, err))
+					continue
+				}
+				gl.Log("info", fmt.Sprintf("🕒 %s/%s: %d commits, %d timezones, weekend %.1f%%, after hours %.1f%%, follow-the-sun %.0f%%",
+					repoOwner, repo, report.CommitsAnalyzed, report.TimezoneSpread, report.WeekendPct, report.AfterHoursPct, report.FollowTheSun))
+
+				markdown := worktime.ToMarkdown(report)
+				if reportDir == "" {
+					fmt.Println(markdown)
+					continue
+				}
+				if err := writeWorktimeReport(reportDir, report, markdown); err != nil {
+					gl.Log("error", err.Error())
+					continue
+				}
+			}
+			if reportDir != "" {
+				gl.Log("success", fmt.Sprintf("Working-hours reports saved to %s", reportDir))
+			}
+		},
+	}
+
+	worktimeCmd.Flags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	worktimeCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Enable quiet mode")
+	worktimeCmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner of the repositories")
+	worktimeCmd.Flags().StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to analyze (name or owner/name)")
+	worktimeCmd.Flags().StringVarP(&reportDir, "report-dir", "R", "", "Directory to write the markdown, JSON and heatmap SVG reports")
+	worktimeCmd.Flags().IntVarP(&opts.WindowDays, "days", "d", opts.WindowDays, "Number of days of history to analyze")
+	worktimeCmd.Flags().IntVar(&opts.MaxCommits, "max-commits", opts.MaxCommits, "Maximum number of commits inspected")
+	worktimeCmd.Flags().IntVar(&opts.WorkStart, "work-start", opts.WorkStart, "Local hour the working day starts")
+	worktimeCmd.Flags().IntVar(&opts.WorkEnd, "work-end", opts.WorkEnd, "Local hour the working day ends")
+	worktimeCmd.Flags().BoolVar(&noResolve, "no-resolve", false, "Do not fetch patch headers to recover author UTC offsets")
+
+	worktimeCmd.MarkFlagRequired("repo")
+
+	return worktimeCmd
+}
+
+func writeWorktimeReport(reportDir string, report *worktime.Report, markdown string) error {
+	base := filepath.Join(reportDir, fmt.Sprintf("%s_%s_worktime", report.Owner, report.Repo))
+	if err := os.WriteFile(base+".md", []byte(markdown), 0o644); err != nil {
+		return fmt.Errorf("failed to write markdown report: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode working-hours report: %w", err)
+	}
+	if err := os.WriteFile(base+".json", data, 0o644); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	if err := render.WriteHeatmapSVG(base+"_heatmap.svg", report.Heatmap, 14); err != nil {
+		return fmt.Errorf("failed to write heatmap: %w", err)
+	}
+	return nil
+}
@@ -3,21 +3,44 @@ package ghbex
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-github/v61/github"
 	"github.com/kubex-ecosystem/ghbex/internal/config"
 	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
 	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
-	"github.com/kubex-ecosystem/ghbex/internal/defs/notifiers"
+	"github.com/kubex-ecosystem/ghbex/internal/fanout"
+	"github.com/kubex-ecosystem/ghbex/internal/ghclient"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/identity"
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
+	"github.com/kubex-ecosystem/ghbex/internal/jobqueue"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/alerting"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/analytics"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/automation"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/backfill"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/codeowners"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/codestats"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/conventional"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/deps"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/dora"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/integrity"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/intelligence"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/licenses"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/monitoring"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/ownership"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/portfolio"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/productivity"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/releases"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/sanitize"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/sbom"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/security"
 	"github.com/kubex-ecosystem/ghbex/internal/operators/workflows"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/worktime"
+	"github.com/kubex-ecosystem/ghbex/internal/pipeline"
+	"github.com/kubex-ecosystem/ghbex/internal/scheduler"
+	"github.com/kubex-ecosystem/ghbex/internal/state"
+	"github.com/kubex-ecosystem/ghbex/internal/webhook"
 )
 
 type MainConfig = interfaces.IMainConfig
@@ -39,6 +62,129 @@ func NewMainConfigObj() (MainConfig, error) {
 type Rules = interfaces.IRules
 type RepoCfg = interfaces.IRepoCfg
 
+type Identity = identity.Identity
+type IdentityResolver = identity.Resolver
+type IdentityOptions = identity.Options
+
+func NewIdentityResolver(opts IdentityOptions) *IdentityResolver {
+	return identity.NewResolver(opts)
+}
+
+func RepositoryIdentities(ctx context.Context, cli *github.Client, owner, repo string) *IdentityResolver {
+	return identity.ForRepository(ctx, cli, owner, repo)
+}
+
+type HistoryStore = history.Store
+type HistoryPoint = history.Point
+type HistoryRetention = history.Retention
+type MetricTrend = history.Trend
+
+// NewHistoryStore opens the metric history store at dir, the ghbex data dir when empty
+func NewHistoryStore(dir string) *HistoryStore {
+	return history.NewStore(dir)
+}
+
+func ComputeMetricTrends(points []HistoryPoint) []MetricTrend {
+	return history.Trends(points)
+}
+
+// OverallTrend is "improving", "stable" or "declining", as used by OverallAssessment.Trend
+func OverallTrend(trends []MetricTrend) string {
+	return history.OverallDirection(trends)
+}
+
+type WebhookReceiver = webhook.Receiver
+type WebhookOptions = webhook.Options
+type WebhookEvent = webhook.Event
+type WebhookTrigger = webhook.Trigger
+type WebhookAggregate = webhook.Aggregate
+type WebhookEventLog = webhook.Log
+
+// NewWebhookReceiver builds the http.Handler of the GitHub webhook endpoint
+func NewWebhookReceiver(opts WebhookOptions) (*WebhookReceiver, error) {
+	return webhook.NewReceiver(opts)
+}
+
+// NewWebhookEventLog opens the webhook event log at path, the ghbex data dir when empty
+func NewWebhookEventLog(path string) *WebhookEventLog {
+	return webhook.NewLog(path)
+}
+
+func VerifyWebhookSignature(secret string, payload []byte, signature string) error {
+	return webhook.Verify(secret, payload, signature)
+}
+
+type FanoutRepo = fanout.Repo
+type FanoutOptions = fanout.Options
+type FanoutErrors = fanout.Errors
+type RateTracker = ghclient.RateTracker
+type RateState = ghclient.RateState
+
+// DefaultFanoutOptions bounds concurrency from GHBEX_CONCURRENCY and GHBEX_OWNER_CONCURRENCY
+// and waits on the shared rate limit tracker
+func DefaultFanoutOptions() FanoutOptions {
+	return fanout.DefaultOptions()
+}
+
+// TrackRateLimits returns a copy of cli reporting its rate limit budget to the shared tracker
+func TrackRateLimits(cli *github.Client) *github.Client {
+	return ghclient.Track(cli, ghclient.DefaultRateTracker())
+}
+
+func DefaultRateTracker() *RateTracker {
+	return ghclient.DefaultRateTracker()
+}
+
+type JobQueue = jobqueue.Queue
+type Job = jobqueue.Job
+type JobStatus = jobqueue.Status
+type JobTask = jobqueue.Task
+type JobHandler = jobqueue.Handler
+type JobQueueOptions = jobqueue.Options
+type Stage = state.Stage
+
+// OpenJobQueue opens the durable job queue in dir, the ghbex data dir when empty
+func OpenJobQueue(dir string, opts JobQueueOptions) (*JobQueue, error) {
+	return jobqueue.Open(dir, opts)
+}
+
+type Scheduler = scheduler.Scheduler
+type SchedulerJob = scheduler.Job
+type SchedulerStatus = scheduler.Status
+type SchedulerOptions = scheduler.Options
+type CronSchedule = scheduler.Cron
+
+// NewScheduler builds a scheduler running jobs with run; see ScheduledJobs for the configured ones
+func NewScheduler(jobs []SchedulerJob, run scheduler.RunFunc, opts SchedulerOptions) *Scheduler {
+	return scheduler.New(jobs, run, opts)
+}
+
+func ScheduledJobs(cfg MainConfig) ([]SchedulerJob, []error) {
+	return scheduler.JobsFromConfig(cfg)
+}
+
+// ParseCron parses a 5-field cron expression, a macro such as "@daily" or "@every 6h"
+func ParseCron(expr string) (*CronSchedule, error) {
+	return scheduler.ParseCron(expr)
+}
+
+type PipelineDefinition = pipeline.Definition
+type PipelineNode = pipeline.Node
+type PipelineEdge = pipeline.Edge
+type PipelineExecutor = pipeline.Executor
+type PipelineResult = pipeline.Result
+type PipelineCondition = pipeline.Condition
+
+// LoadPipeline loads a pipeline file; validate it against a registry before running it
+func LoadPipeline(path string) (*PipelineDefinition, error) {
+	return pipeline.Load(path)
+}
+
+// ParsePipelineCondition parses an edge condition such as "metric.health < 60 && !insight.inactive"
+func ParsePipelineCondition(expr string) (*PipelineCondition, error) {
+	return pipeline.ParseCondition(expr)
+}
+
 /* OPERATORS - API EXPOSE (ABSTRACT) */
 
 type OperatorStatus struct {
@@ -78,6 +224,12 @@ func GetRepositoryInsights(ctx context.Context, owner, repo string, days int) (*
 	return analytics.GetRepositoryInsights(ctx, ghc, owner, repo, days)
 }
 
+type CollaborationMetrics = analytics.CollaborationMetrics
+
+func ComputeReviewMetrics(ctx context.Context, client *github.Client, owner, repo string, since time.Time) (*CollaborationMetrics, error) {
+	return analytics.ComputeReviewMetrics(ctx, client, owner, repo, since)
+}
+
 // AUTOMATION
 
 type AutomationReport = automation.AutomationReport
@@ -92,11 +244,151 @@ func AnalyzeAutomation(ctx context.Context, client *github.Client, owner, repo s
 }
 
 type Service = automation.Service
+type SanitizeCheckpoint = automation.Checkpoint
 
-func NewService(cli *github.Client, cfg interfaces.IMainConfig, ntf ...*notifiers.Discord) *Service {
+func NewService(cli *github.Client, cfg interfaces.IMainConfig, ntf ...interfaces.INotifier) *Service {
 	return automation.New(cli, cfg, ntf...)
 }
 
+/* OPERATORS - API EXPOSE (ALERTING) */
+
+type AlertRule = alerting.Rule
+type AlertEvaluation = alerting.Evaluation
+type AlertEvent = alerting.Event
+type AlertingOptions = alerting.Options
+type AlertingEngine = alerting.Engine
+
+func DefaultAlertingOptions() AlertingOptions {
+	return alerting.DefaultOptions()
+}
+
+func NewAlertingEngine(statePath string) (*AlertingEngine, error) {
+	return alerting.NewEngine(statePath)
+}
+
+func EvaluateAlert(repository string, rule AlertRule, points []HistoryPoint, opts AlertingOptions) AlertEvaluation {
+	return alerting.Evaluate(repository, rule, points, opts)
+}
+
+func SendAlerts(ctx context.Context, notifiers []interfaces.INotifier, events []AlertEvent) error {
+	return alerting.Notify(ctx, notifiers, events)
+}
+
+// DeliverAlerts sends events and returns the delivered ones, to be applied with
+// AlertingEngine.Delivered
+func DeliverAlerts(ctx context.Context, notifiers []interfaces.INotifier, events []AlertEvent) ([]AlertEvent, error) {
+	return alerting.Deliver(ctx, notifiers, events)
+}
+
+/* OPERATORS - API EXPOSE (BACKFILL) */
+
+type BackfillOptions = backfill.Options
+type BackfillResult = backfill.Result
+type BackfillWeek = backfill.Week
+
+func DefaultBackfillOptions() BackfillOptions {
+	return backfill.DefaultOptions()
+}
+
+func CollectBackfill(ctx context.Context, cli *github.Client, store *SyncStore, owner, repo string, opts BackfillOptions) (*BackfillResult, error) {
+	return backfill.Collect(ctx, cli, store, owner, repo, opts, time.Now())
+}
+
+func WriteBackfill(store *HistoryStore, result *BackfillResult) (int, error) {
+	written, err := backfill.Write(store, result)
+	return written.Points, err
+}
+
+/* OPERATORS - API EXPOSE (CODEOWNERS) */
+
+type CodeownersFile = codeowners.File
+type CodeownersOptions = codeowners.Options
+type CodeownersReport = codeowners.Report
+
+func DefaultCodeownersOptions() CodeownersOptions {
+	return codeowners.DefaultOptions()
+}
+
+func LoadCodeowners(ctx context.Context, cli *github.Client, owner, repo, ref string) (*CodeownersFile, error) {
+	return codeowners.Load(ctx, cli, owner, repo, ref)
+}
+
+func AnalyzeCodeowners(ctx context.Context, cli *github.Client, owner, repo string, opts CodeownersOptions) (*CodeownersReport, error) {
+	return codeowners.Analyze(ctx, cli, owner, repo, opts)
+}
+
+/* OPERATORS - API EXPOSE (CODESTATS) */
+
+type CodeStatsOptions = codestats.Options
+type CodeStatsReport = codestats.Report
+
+func AnalyzeCodeSnapshot(ctx context.Context, cli *github.Client, owner, repo string, opts CodeStatsOptions) (*CodeStatsReport, error) {
+	return codestats.AnalyzeSnapshot(ctx, cli, owner, repo, opts)
+}
+
+func AnalyzeCodeDir(dir string, opts CodeStatsOptions) (*CodeStatsReport, error) {
+	return codestats.AnalyzeDir(dir, opts)
+}
+
+/* OPERATORS - API EXPOSE (CONVENTIONAL) */
+
+type ConventionalCommit = conventional.Commit
+type ConventionalCompliance = conventional.Compliance
+type Changelog = conventional.Changelog
+type ChangelogOptions = conventional.Options
+
+func ParseConventionalCommit(message string) ConventionalCommit {
+	return conventional.Parse(message)
+}
+
+func GenerateChangelog(ctx context.Context, cli *github.Client, owner, repo, from, to string, opts ChangelogOptions) (*Changelog, error) {
+	return conventional.Generate(ctx, cli, owner, repo, from, to, opts)
+}
+
+func ChangelogToMarkdown(cl *Changelog) string {
+	return conventional.ToMarkdown(cl)
+}
+
+/* OPERATORS - API EXPOSE (DEPENDENCIES) */
+
+type Dependency = deps.Dependency
+type DependencyReport = deps.Report
+type VulnerabilityDatabase = deps.Database
+
+func LoadVulnerabilityDatabase(path string) (*VulnerabilityDatabase, error) {
+	return deps.LoadDatabase(path)
+}
+
+func AnalyzeDependencies(ctx context.Context, cli *github.Client, owner, repo string, db *VulnerabilityDatabase) (*DependencyReport, error) {
+	return deps.AnalyzeRepository(ctx, cli, owner, repo, db)
+}
+
+/* OPERATORS - API EXPOSE (DORA) */
+
+type DoraOptions = dora.Options
+type DoraReport = dora.Report
+
+func DefaultDoraOptions() DoraOptions {
+	return dora.DefaultOptions()
+}
+
+func ComputeDoraMetrics(ctx context.Context, cli *github.Client, owner, repo string, opts DoraOptions) (*DoraReport, error) {
+	return dora.ComputeMetrics(ctx, cli, owner, repo, opts)
+}
+
+/* OPERATORS - API EXPOSE (INTEGRITY) */
+
+type ReleaseIntegrityOptions = integrity.Options
+type ReleaseIntegrityReport = integrity.Report
+
+func DefaultReleaseIntegrityOptions() ReleaseIntegrityOptions {
+	return integrity.DefaultOptions()
+}
+
+func AuditReleaseIntegrity(ctx context.Context, cli *github.Client, owner, repo string, opts ReleaseIntegrityOptions) (*ReleaseIntegrityReport, error) {
+	return integrity.Audit(ctx, cli, owner, repo, opts)
+}
+
 /* OPERATORS - API EXPOSE (INTELLIGENCE) */
 
 type LLMMetaResponse = intelligence.LLMMetaResponse
@@ -125,10 +417,62 @@ func AnalyzeRepositoryActivity(ctx context.Context, cli *github.Client, owner, r
 	return monitoring.AnalyzeRepositoryActivity(ctx, cli, owner, repo, inactiveDaysThreshold)
 }
 
+type SyncStore = incremental.Store
+type SyncOptions = incremental.Options
+type SyncSnapshot = incremental.Snapshot
+
+// NewSyncStore opens the issue and pull request sync store at dir, the ghbex data dir when empty
+func NewSyncStore(dir string) *SyncStore {
+	return incremental.NewStore(dir)
+}
+
+func AnalyzeRepositoryActivityIncremental(ctx context.Context, cli *github.Client, store *SyncStore, owner, repo string, inactiveDaysThreshold int, opts SyncOptions) (*ActivityReport, error) {
+	return monitoring.AnalyzeRepositoryActivityIncremental(ctx, cli, store, owner, repo, inactiveDaysThreshold, opts)
+}
+
+// CheckInactiveRepositories checks repositories for inactivity concurrently. Results may be
+// partial: the reports that succeeded are returned together with a non-nil *FanoutErrors.
 func CheckInactiveRepositories(ctx context.Context, cli *github.Client, repos []struct{ Owner, Name string }, inactiveDaysThreshold int) ([]*ActivityReport, error) {
 	return monitoring.CheckInactiveRepositories(ctx, cli, repos, inactiveDaysThreshold)
 }
 
+func CheckRepositoriesActivity(ctx context.Context, cli *github.Client, repos []FanoutRepo, inactiveDaysThreshold int, opts FanoutOptions) *fanout.Report[*ActivityReport] {
+	return monitoring.CheckRepositoriesActivity(ctx, cli, repos, inactiveDaysThreshold, opts)
+}
+
+/* OPERATORS - API EXPOSE (OWNERSHIP) */
+
+type OwnershipOptions = ownership.Options
+type OwnershipReport = ownership.Report
+type PathOwnership = ownership.PathOwnership
+
+func DefaultOwnershipOptions() OwnershipOptions {
+	return ownership.DefaultOptions()
+}
+
+func AnalyzeOwnership(ctx context.Context, cli *github.Client, owner, repo string, opts OwnershipOptions) (*OwnershipReport, error) {
+	return ownership.Analyze(ctx, cli, owner, repo, opts)
+}
+
+/* OPERATORS - API EXPOSE (PORTFOLIO) */
+
+type PortfolioOptions = portfolio.Options
+type PortfolioRepository = portfolio.Repository
+type PortfolioResult = portfolio.Result
+type PortfolioRollup = portfolio.Rollup
+
+func DefaultPortfolioOptions() PortfolioOptions {
+	return portfolio.DefaultOptions()
+}
+
+func CollectPortfolioRepository(ctx context.Context, cli *github.Client, repo PortfolioRepository, opts PortfolioOptions) (*PortfolioResult, error) {
+	return portfolio.Collect(ctx, cli, repo, opts)
+}
+
+func BuildPortfolio(results []*PortfolioResult, opts PortfolioOptions) *PortfolioRollup {
+	return portfolio.Build(results, opts, time.Now())
+}
+
 /* OPERATORS - API EXPOSE (PRODUCTIVITY) */
 
 type ProductivityReport = productivity.ProductivityReport
@@ -166,6 +510,25 @@ func CleanReleases(ctx context.Context, cli *github.Client, owner, repo string,
 	return releases.CleanReleases(ctx, cli, owner, repo, r, dry)
 }
 
+func CreateDraftRelease(ctx context.Context, cli *github.Client, owner, repo, tag, target, name, body string, dry bool) (*github.RepositoryRelease, error) {
+	return releases.CreateDraft(ctx, cli, owner, repo, tag, target, name, body, dry)
+}
+
+type ReleasePlan = releases.Plan
+type ReleasePlanOptions = releases.PlanOptions
+
+func DefaultReleasePlanOptions() ReleasePlanOptions {
+	return releases.DefaultPlanOptions()
+}
+
+func PlanRelease(ctx context.Context, cli *github.Client, owner, repo string, opts ReleasePlanOptions) (*ReleasePlan, error) {
+	return releases.PlanRelease(ctx, cli, owner, repo, opts)
+}
+
+func PublishRelease(ctx context.Context, cli *github.Client, plan *ReleasePlan, dry bool) (*github.RepositoryRelease, error) {
+	return releases.Publish(ctx, cli, plan, dry)
+}
+
 /* OPERATORS - API EXPOSE (SANITIZE) */
 
 type IntelligentSanitizer = sanitize.IntelligentSanitizer
@@ -179,6 +542,40 @@ func NewIntelligentSanitizer(client *github.Client) *IntelligentSanitizer {
 	return sanitize.NewIntelligentSanitizer(client)
 }
 
+/* OPERATORS - API EXPOSE (LICENSES) */
+
+type LicensePolicy = licenses.Policy
+type LicenseFinding = licenses.Finding
+type LicensesReport = licenses.Report
+
+func LicensePolicyFromRule(rule interfaces.ILicensesRule) LicensePolicy {
+	return licenses.PolicyFromRule(rule)
+}
+
+func CheckLicenses(ctx context.Context, cli *github.Client, owner, repo string, policy LicensePolicy) (*LicensesReport, error) {
+	return licenses.CheckRepository(ctx, cli, owner, repo, policy)
+}
+
+func EvaluateLicenses(dependencies []Dependency, policy LicensePolicy) *LicensesReport {
+	return licenses.Evaluate(dependencies, policy)
+}
+
+func LicensesToSARIF(reports []*LicensesReport) ([]byte, error) {
+	return licenses.ToSARIF(reports)
+}
+
+/* OPERATORS - API EXPOSE (SBOM) */
+
+type SBOMMeta = sbom.Meta
+
+func GenerateSBOM(format string, meta SBOMMeta, dependencies []Dependency) ([]byte, error) {
+	return sbom.Generate(format, meta, dependencies)
+}
+
+func AttachSBOMToRelease(ctx context.Context, cli *github.Client, owner, repo, tag, name string, data []byte, dry bool) (*github.RepositoryRelease, error) {
+	return sbom.AttachToRelease(ctx, cli, owner, repo, tag, name, data, dry)
+}
+
 /* OPERATORS - API EXPOSE (SECURITY) */
 
 type SSHKeyPair = security.SSHKeyPair
@@ -191,8 +588,34 @@ func ListDeployKeys(ctx context.Context, cli *github.Client, owner, repo string)
 	return security.ListDeployKeys(ctx, cli, owner, repo)
 }
 
+type AlertsReport = security.AlertsReport
+type AlertSummary = security.AlertSummary
+type SecurityAlert = security.SecurityAlert
+
+func CollectSecurityAlerts(ctx context.Context, cli *github.Client, owner, repo string) (*AlertsReport, error) {
+	return security.CollectSecurityAlerts(ctx, cli, owner, repo)
+}
+
+func SecurityAlertsToMarkdown(reports []*AlertsReport, limit int) string {
+	return security.AlertsToMarkdown(reports, limit)
+}
+
 /* OPERATORS - API EXPOSE (WORKFLOWS) */
 
 func CleanWorkflowRuns(ctx context.Context, cli *github.Client, owner, repo string, r interfaces.IRunsRule, dry bool) (deleted, kept int, ids []int64, err error) {
 	return workflows.CleanRuns(ctx, cli, owner, repo, r, dry)
 }
+
+/* OPERATORS - API EXPOSE (WORKTIME) */
+
+type WorktimeOptions = worktime.Options
+type WorktimeReport = worktime.Report
+type ContributorHours = worktime.ContributorHours
+
+func DefaultWorktimeOptions() WorktimeOptions {
+	return worktime.DefaultOptions()
+}
+
+func AnalyzeWorktime(ctx context.Context, cli *github.Client, owner, repo string, opts WorktimeOptions) (*WorktimeReport, error) {
+	return worktime.Analyze(ctx, cli, owner, repo, opts)
+}
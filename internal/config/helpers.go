@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -31,6 +32,17 @@ func GetEnvOrDefault[T any](key string, defaultValue T) T {
 	return defaultValue
 }
 
+// splitList splits a comma-separated value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func GetBaseFilesPath() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -140,6 +152,8 @@ func LoadFromFile(filePath string) (interfaces.IMainConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Placeholders such as ${GITHUB_TOKEN} and ${DISCORD_WEBHOOK_URL} come from the environment
+	data = []byte(os.ExpandEnv(string(data)))
 	var cfg MainConfig
 	switch filepath.Ext(filePath) {
 	case ".yaml", ".yml":
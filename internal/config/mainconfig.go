@@ -24,6 +24,7 @@ type MainConfig struct {
 	*core.Runtime     `yaml:"runtime" json:"runtime"`
 	*gitz.GitHub      `yaml:"github" json:"github"`
 	*common.Notifiers `mapstructure:",squash"`
+	Server            *core.Server   `yaml:"server,omitempty" json:"server,omitempty"`
 	Grompt            gromptz.Grompt `yaml:"-" json:"-"`
 }
 
@@ -201,6 +202,7 @@ func NewMainConfigType(
 					),
 					gitz.NewReleasesRuleType(
 						GetEnvOrDefault("GITHUB_REPO_RELEASES_DELETE_DRAFTS", false),
+						GetEnvOrDefault("GITHUB_REPO_RELEASES_AUDIT", false),
 					),
 					gitz.NewSecurityRuleType(
 						GetEnvOrDefault("GITHUB_REPO_ROTATE_SSH_KEYS", false),
@@ -212,6 +214,13 @@ func NewMainConfigType(
 						GetEnvOrDefault("GITHUB_REPO_INACTIVE_DAYS_THRESHOLD", 30),
 						GetEnvOrDefault("GITHUB_REPO_MONITOR_PRS", true),
 					),
+					gitz.NewLicensesRuleType(
+						splitList(GetEnvOrDefault("GITHUB_REPO_LICENSES_ALLOW", "")),
+						splitList(GetEnvOrDefault("GITHUB_REPO_LICENSES_DENY", "")),
+						splitList(GetEnvOrDefault("GITHUB_REPO_LICENSES_REVIEW", "")),
+						GetEnvOrDefault("GITHUB_REPO_PROPRIETARY", false),
+						GetEnvOrDefault("GITHUB_REPO_LICENSES_UNKNOWN", "review"),
+					),
 				),
 			))
 		}
@@ -221,6 +230,34 @@ func NewMainConfigType(
 	return cfg, nil
 }
 
+// UnmarshalYAML accepts the notifiers as a plain list, as written in the config file,
+// besides the mapping written by SaveToFile
+func (c *MainConfig) UnmarshalYAML(value *yaml.Node) error {
+	type plain MainConfig
+	node := *value
+	var notifiers []*common.Notifier
+	if node.Kind == yaml.MappingNode {
+		node.Content = make([]*yaml.Node, 0, len(value.Content))
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			key, val := value.Content[i], value.Content[i+1]
+			if key.Value == "notifiers" && val.Kind == yaml.SequenceNode {
+				if err := val.Decode(&notifiers); err != nil {
+					return err
+				}
+				continue
+			}
+			node.Content = append(node.Content, key, val)
+		}
+	}
+	if err := node.Decode((*plain)(c)); err != nil {
+		return err
+	}
+	if notifiers != nil {
+		c.Notifiers = common.NewNotifiersType(notifiers...)
+	}
+	return nil
+}
+
 func (c *MainConfig) GetRuntime() interfaces.IRuntime {
 	if c == nil {
 		return nil
@@ -273,6 +310,19 @@ func (c *MainConfig) GetNotifiers() interfaces.INotifiers {
 	return c.Notifiers
 }
 
+func (c *MainConfig) GetServer() interfaces.IServer {
+	if c == nil {
+		return nil
+	}
+	if c.Server == nil {
+		c.Server = core.NewServerType(
+			GetEnvOrDefault("GHBEX_SERVER_ADDR", ":8088"),
+			&core.Webhooks{Secret: GetEnvOrDefault("GHBEX_WEBHOOK_SECRET", "")},
+		)
+	}
+	return c.Server
+}
+
 func (c *MainConfig) GetConfigFilePath() string {
 	if c == nil {
 		return ""
@@ -320,6 +370,7 @@ func (c *MainConfig) GetConfigObject() any {
 		Runtime:   c.Runtime,
 		GitHub:    c.GitHub,
 		Notifiers: c.Notifiers,
+		Server:    c.Server,
 		Grompt:    c.Grompt,
 	}
 	return obj
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/notifiers"
 )
 
 type Notifiers struct {
@@ -86,7 +87,16 @@ func NewNotifier(notifierType, webhook string) interfaces.INotifier {
 func (n *Notifier) GetType() string    { return n.Type }
 func (n *Notifier) GetWebhook() string { return n.Webhook }
 
+// Send delivers through the channel of the notifier type. Types without a channel
+// implementation and notifiers without a webhook are no-ops.
 func (n *Notifier) Send(ctx context.Context, title, text string, files ...interfaces.IAttachment) error {
-	// Implementation for sending the notification
+	switch n.Type {
+	case "discord":
+		return notifiers.NewDiscordNotifier(n.Webhook).Send(ctx, title, text, files...)
+	case "slack":
+		return notifiers.NewSlackNotifier(n.Webhook).Send(ctx, title, text, files...)
+	case "stdout":
+		return notifiers.NewStdoutNotifier().Send(ctx, title, text, files...)
+	}
 	return nil
 }
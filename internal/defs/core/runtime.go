@@ -9,7 +9,9 @@ type Runtime struct {
 	Debug      bool   `yaml:"debug" json:"debug"`
 	DryRun     bool   `yaml:"dry_run" json:"dry_run"`
 	ReportDir  string `yaml:"report_dir" json:"report_dir"`
-	Background bool   `yaml:"background" json:"background"`
+	Background bool   `yaml:"background" json:"background"` // Run the scheduler alongside long-running commands
+	// Schedules apply to every configured repository, or to the repositories they list
+	Schedules []*Schedule `yaml:"schedules,omitempty" json:"schedules,omitempty"`
 }
 
 func NewRuntimeType(debug, dryRun bool, reportDir string, background bool) *Runtime {
@@ -28,6 +30,11 @@ func NewRuntime(debug, dryRun bool, reportDir string, background bool) interface
 func (r *Runtime) GetDebug() bool                { return r.Debug }
 func (r *Runtime) GetDryRun() bool               { return r.DryRun }
 func (r *Runtime) GetReportDir() string          { return r.ReportDir }
+func (r *Runtime) GetBackground() bool           { return r.Background }
 func (r *Runtime) SetDebug(debug bool)           { r.Debug = debug }
 func (r *Runtime) SetDryRun(dryRun bool)         { r.DryRun = dryRun }
 func (r *Runtime) SetReportDir(reportDir string) { r.ReportDir = reportDir }
+func (r *Runtime) SetBackground(background bool) { r.Background = background }
+func (r *Runtime) GetSchedules() []interfaces.ISchedule {
+	return Schedules(r.Schedules)
+}
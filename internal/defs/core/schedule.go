@@ -0,0 +1,44 @@
+package core
+
+import "github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+
+// Schedule runs an operator (e.g. "automation.sanitize") on a cron expression. Under runtime
+// it applies to every configured repository, or to Repos; under a repository, to that one.
+// Jitter ("15m") spreads the repositories over a window so they do not hit GitHub at once, and
+// CatchUp runs a schedule missed while ghbex was down once on start.
+type Schedule struct {
+	Name     string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Cron     string   `yaml:"cron" json:"cron"` // "0 3 * * *", "@daily", "@every 6h"
+	Operator string   `yaml:"operator" json:"operator"`
+	Repos    []string `yaml:"repos,omitempty" json:"repos,omitempty"` // owner/name, global schedules only
+	Jitter   string   `yaml:"jitter,omitempty" json:"jitter,omitempty"`
+	CatchUp  *bool    `yaml:"catch_up,omitempty" json:"catch_up,omitempty"` // default true
+	DryRun   bool     `yaml:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+func NewScheduleType(name, cron, operator string) *Schedule {
+	return &Schedule{Name: name, Cron: cron, Operator: operator}
+}
+
+func NewSchedule(name, cron, operator string) interfaces.ISchedule {
+	return NewScheduleType(name, cron, operator)
+}
+
+func (s *Schedule) GetName() string     { return s.Name }
+func (s *Schedule) GetCron() string     { return s.Cron }
+func (s *Schedule) GetOperator() string { return s.Operator }
+func (s *Schedule) GetRepos() []string  { return s.Repos }
+func (s *Schedule) GetJitter() string   { return s.Jitter }
+func (s *Schedule) GetCatchUp() bool    { return s.CatchUp == nil || *s.CatchUp }
+func (s *Schedule) GetDryRun() bool     { return s.DryRun }
+
+// Schedules converts a config list, skipping empty entries
+func Schedules(list []*Schedule) []interfaces.ISchedule {
+	out := make([]interfaces.ISchedule, 0, len(list))
+	for _, s := range list {
+		if s != nil {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"net"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+)
+
+type Server struct {
+	Addr     string    `yaml:"addr" json:"addr"`
+	Webhooks *Webhooks `yaml:"webhooks,omitempty" json:"webhooks,omitempty"`
+}
+
+// Webhooks configures the GitHub webhook receiver. Secret verifies X-Hub-Signature-256,
+// EventLog is the append-only log of normalized events and Triggers run operators on events.
+type Webhooks struct {
+	Path     string            `yaml:"path,omitempty" json:"path,omitempty"`
+	Secret   string            `yaml:"secret" json:"-"`
+	EventLog string            `yaml:"event_log,omitempty" json:"event_log,omitempty"`
+	Triggers []*WebhookTrigger `yaml:"triggers,omitempty" json:"triggers,omitempty"`
+}
+
+// WebhookTrigger runs an operator (e.g. "artifacts.cleanup") on every event of a type and,
+// optionally, action (e.g. workflow_run completed), for all repositories or the listed ones.
+type WebhookTrigger struct {
+	Event    string   `yaml:"event" json:"event"`
+	Action   string   `yaml:"action,omitempty" json:"action,omitempty"`
+	Operator string   `yaml:"operator" json:"operator"`
+	Repos    []string `yaml:"repos,omitempty" json:"repos,omitempty"` // owner/name
+	DryRun   bool     `yaml:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+func NewServerType(addr string, webhooks *Webhooks) *Server {
+	return &Server{Addr: addr, Webhooks: webhooks}
+}
+
+func NewServer(addr string, webhooks *Webhooks) interfaces.IServer {
+	return NewServerType(addr, webhooks)
+}
+
+func (s *Server) GetAddr() string { return s.Addr }
+func (s *Server) GetPort() string {
+	if _, port, err := net.SplitHostPort(s.Addr); err == nil {
+		return port
+	}
+	return strings.TrimPrefix(s.Addr, ":")
+}
+func (s *Server) GetWebhooks() interfaces.IWebhooks {
+	if s.Webhooks == nil {
+		s.Webhooks = &Webhooks{}
+	}
+	return s.Webhooks
+}
+
+func (w *Webhooks) GetPath() string     { return w.Path }
+func (w *Webhooks) GetSecret() string   { return w.Secret }
+func (w *Webhooks) GetEventLog() string { return w.EventLog }
+func (w *Webhooks) GetTriggers() []interfaces.IWebhookTrigger {
+	triggers := make([]interfaces.IWebhookTrigger, 0, len(w.Triggers))
+	for _, t := range w.Triggers {
+		if t != nil {
+			triggers = append(triggers, t)
+		}
+	}
+	return triggers
+}
+
+func (t *WebhookTrigger) GetEvent() string    { return t.Event }
+func (t *WebhookTrigger) GetAction() string   { return t.Action }
+func (t *WebhookTrigger) GetOperator() string { return t.Operator }
+func (t *WebhookTrigger) GetRepos() []string  { return t.Repos }
+func (t *WebhookTrigger) GetDryRun() bool     { return t.DryRun }
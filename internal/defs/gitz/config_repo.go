@@ -1,11 +1,15 @@
 package gitz
 
-import "github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+import (
+	"github.com/kubex-ecosystem/ghbex/internal/defs/core"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+)
 
 type RepoCfg struct {
-	Owner  string `yaml:"owner" json:"owner"`
-	Name   string `yaml:"name" json:"name"`
-	*Rules `yaml:"rules" json:"rules"`
+	Owner     string           `yaml:"owner" json:"owner"`
+	Name      string           `yaml:"name" json:"name"`
+	Schedules []*core.Schedule `yaml:"schedules,omitempty" json:"schedules,omitempty"`
+	*Rules    `yaml:"rules" json:"rules"`
 }
 
 func NewRepoCfgType(owner, name string, rules interfaces.IRules) *RepoCfg {
@@ -51,3 +55,6 @@ func (r *RepoCfg) SetOwner(owner string)       { r.Owner = owner }
 func (r *RepoCfg) GetName() string             { return r.Name }
 func (r *RepoCfg) SetName(name string)         { r.Name = name }
 func (r *RepoCfg) GetRules() interfaces.IRules { return r.Rules }
+func (r *RepoCfg) GetSchedules() []interfaces.ISchedule {
+	return core.Schedules(r.Schedules)
+}
@@ -15,9 +15,34 @@ type Artifacts struct {
 	IDs     []int64 `yaml:"ids" json:"ids"`
 }
 
+type ReleaseFinding struct {
+	Kind     string `yaml:"kind" json:"kind"`
+	Severity string `yaml:"severity" json:"severity"`
+	Tag      string `yaml:"tag,omitempty" json:"tag,omitempty"`
+	Asset    string `yaml:"asset,omitempty" json:"asset,omitempty"`
+	Message  string `yaml:"message" json:"message"`
+	Status   string `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+type ReleaseAudit struct {
+	Audited                  bool             `yaml:"audited" json:"audited"`
+	TagsChecked              int              `yaml:"tags_checked" json:"tags_checked"`
+	ReleasesChecked          int              `yaml:"releases_checked" json:"releases_checked"`
+	NonSemverTags            int              `yaml:"non_semver_tags" json:"non_semver_tags"`
+	LightweightTags          int              `yaml:"lightweight_tags" json:"lightweight_tags"`
+	UnsignedTags             int              `yaml:"unsigned_tags" json:"unsigned_tags"`
+	UnsignedCommits          int              `yaml:"unsigned_commits" json:"unsigned_commits"`
+	UnreachableTags          int              `yaml:"unreachable_tags" json:"unreachable_tags"`
+	ReleasesWithoutAssets    int              `yaml:"releases_without_assets" json:"releases_without_assets"`
+	ReleasesWithoutChecksums int              `yaml:"releases_without_checksums" json:"releases_without_checksums"`
+	ChecksumMismatches       int              `yaml:"checksum_mismatches" json:"checksum_mismatches"`
+	Findings                 []ReleaseFinding `yaml:"findings" json:"findings"`
+}
+
 type Releases struct {
-	DeletedDrafts int      `yaml:"deleted_drafts" json:"deleted_drafts"`
-	Tags          []string `yaml:"tags" json:"tags"`
+	DeletedDrafts int          `yaml:"deleted_drafts" json:"deleted_drafts"`
+	Tags          []string     `yaml:"tags" json:"tags"`
+	Audit         ReleaseAudit `yaml:"audit" json:"audit"`
 }
 
 type Security struct {
@@ -34,6 +59,26 @@ type Monitoring struct {
 	CommitsLast30 int  `yaml:"commits_last_30" json:"commits_last_30"`
 }
 
+type RiskPath struct {
+	Path           string   `yaml:"path" json:"path"`
+	Risk           string   `yaml:"risk" json:"risk"`
+	BusFactor      int      `yaml:"bus_factor" json:"bus_factor"`
+	Owners         []string `yaml:"owners" json:"owners"`
+	Changes        int      `yaml:"changes" json:"changes"`
+	Silo           bool     `yaml:"silo" json:"silo"`
+	DepartedOwners []string `yaml:"departed_owners,omitempty" json:"departed_owners,omitempty"`
+}
+
+type Risk struct {
+	Analyzed        bool       `yaml:"analyzed" json:"analyzed"`
+	CommitsAnalyzed int        `yaml:"commits_analyzed" json:"commits_analyzed"`
+	BusFactor       int        `yaml:"bus_factor" json:"bus_factor"`
+	Owners          []string   `yaml:"owners" json:"owners"`
+	Silos           []string   `yaml:"silos" json:"silos"`
+	DepartedOwners  []string   `yaml:"departed_owners" json:"departed_owners"`
+	Paths           []RiskPath `yaml:"paths" json:"paths"`
+}
+
 type Report struct {
 	Owner      string     `yaml:"owner" json:"owner"`
 	Repo       string     `yaml:"repo" json:"repo"`
@@ -44,5 +89,6 @@ type Report struct {
 	Releases   Releases   `yaml:"releases" json:"releases"`
 	Security   Security   `yaml:"security" json:"security"`
 	Monitoring Monitoring `yaml:"monitoring" json:"monitoring"`
+	Risk       Risk       `yaml:"risk" json:"risk"`
 	Notes      []string   `yaml:"notes" json:"notes"`
 }
@@ -0,0 +1,41 @@
+package gitz
+
+import "github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+
+// AlertRule fires when a recorded metric crosses a threshold or deviates from its history.
+// Condition is "threshold" (latest value), "week_over_week" (delta against the value a week
+// earlier), "zscore" or "ewma" (deviations from the mean or the exponentially weighted mean,
+// in standard deviations). Operator is one of >, >=, <, <=.
+type AlertRule struct {
+	Name      string  `yaml:"name" json:"name"`
+	Metric    string  `yaml:"metric" json:"metric"`
+	Condition string  `yaml:"condition" json:"condition"`
+	Operator  string  `yaml:"operator" json:"operator"`
+	Value     float64 `yaml:"value" json:"value"`
+	Window    int     `yaml:"window,omitempty" json:"window,omitempty"`     // samples used by zscore/ewma (default 12)
+	Cooldown  string  `yaml:"cooldown,omitempty" json:"cooldown,omitempty"` // minimum time between repeated notifications (default 24h)
+	Severity  string  `yaml:"severity,omitempty" json:"severity,omitempty"` // "critical" | "warning" | "info" (default "warning")
+}
+
+func NewAlertRuleType(name, metric, condition, operator string, value float64) *AlertRule {
+	return &AlertRule{
+		Name:      name,
+		Metric:    metric,
+		Condition: condition,
+		Operator:  operator,
+		Value:     value,
+	}
+}
+
+func NewAlertRule(name, metric, condition, operator string, value float64) interfaces.IAlertRule {
+	return NewAlertRuleType(name, metric, condition, operator, value)
+}
+
+func (r *AlertRule) GetName() string      { return r.Name }
+func (r *AlertRule) GetMetric() string    { return r.Metric }
+func (r *AlertRule) GetCondition() string { return r.Condition }
+func (r *AlertRule) GetOperator() string  { return r.Operator }
+func (r *AlertRule) GetValue() float64    { return r.Value }
+func (r *AlertRule) GetWindow() int       { return r.Window }
+func (r *AlertRule) GetCooldown() string  { return r.Cooldown }
+func (r *AlertRule) GetSeverity() string  { return r.Severity }
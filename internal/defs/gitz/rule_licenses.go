@@ -0,0 +1,38 @@
+package gitz
+
+import "github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+
+type LicensesRule struct {
+	Allow         []string `yaml:"allow" json:"allow"`                   // SPDX ids allowed without review (empty = any not denied)
+	Deny          []string `yaml:"deny" json:"deny"`                     // SPDX ids that are violations
+	Review        []string `yaml:"review" json:"review"`                 // SPDX ids that need legal review
+	Proprietary   bool     `yaml:"proprietary" json:"proprietary"`       // closed-source service: copyleft is a violation
+	UnknownPolicy string   `yaml:"unknown_policy" json:"unknown_policy"` // "allow" | "review" | "deny" (default "review")
+}
+
+func NewLicensesRuleType(allow, deny, review []string, proprietary bool, unknownPolicy string) *LicensesRule {
+	return &LicensesRule{
+		Allow:         allow,
+		Deny:          deny,
+		Review:        review,
+		Proprietary:   proprietary,
+		UnknownPolicy: unknownPolicy,
+	}
+}
+
+func NewLicensesRule(allow, deny, review []string, proprietary bool, unknownPolicy string) interfaces.ILicensesRule {
+	return NewLicensesRuleType(allow, deny, review, proprietary, unknownPolicy)
+}
+
+func (r *LicensesRule) GetAllow() []string              { return r.Allow }
+func (r *LicensesRule) SetAllow(allow []string)         { r.Allow = allow }
+func (r *LicensesRule) GetDeny() []string               { return r.Deny }
+func (r *LicensesRule) SetDeny(deny []string)           { r.Deny = deny }
+func (r *LicensesRule) GetReview() []string             { return r.Review }
+func (r *LicensesRule) SetReview(review []string)       { r.Review = review }
+func (r *LicensesRule) GetProprietary() bool            { return r.Proprietary }
+func (r *LicensesRule) SetProprietary(proprietary bool) { r.Proprietary = proprietary }
+func (r *LicensesRule) GetUnknownPolicy() string        { return r.UnknownPolicy }
+func (r *LicensesRule) SetUnknownPolicy(policy string)  { r.UnknownPolicy = policy }
+func (r *LicensesRule) GetRuleName() string             { return "licenses" }
+func (r *LicensesRule) SetRuleName(name string)         { /* // No-op for licenses rule */ }
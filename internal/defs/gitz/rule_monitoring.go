@@ -7,6 +7,8 @@ type MonitoringRule struct {
 	InactiveDaysThreshold int  `yaml:"inactive_days_threshold" json:"inactive_days_threshold"`
 	MonitorPRs            bool `yaml:"monitor_prs" json:"monitor_prs"`
 	MonitorIssues         bool `yaml:"monitor_issues" json:"monitor_issues"`
+
+	Alerts []*AlertRule `yaml:"alerts,omitempty" json:"alerts,omitempty"`
 }
 
 func NewMonitoringRuleType(checkInactivity bool, inactiveDaysThreshold int, monitorPRs bool) *MonitoringRule {
@@ -30,6 +32,19 @@ func (r *MonitoringRule) SetMonitorPRs(monitor bool)        { r.MonitorPRs = mon
 func (r *MonitoringRule) GetRuleName() string               { return "monitoring" }
 func (r *MonitoringRule) SetRuleName(name string)           { /* // No-op for monitoring rule */ }
 
+func (r *MonitoringRule) GetAlerts() []interfaces.IAlertRule {
+	if r == nil {
+		return nil
+	}
+	alerts := make([]interfaces.IAlertRule, 0, len(r.Alerts))
+	for _, a := range r.Alerts {
+		if a != nil {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
 func (r *MonitoringRule) GetArtifacts() interfaces.IArtifactsRule {
 	if r == nil {
 		return nil
@@ -4,19 +4,23 @@ import "github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
 
 type ReleasesRule struct {
 	DeleteDrafts bool `yaml:"delete_drafts" json:"delete_drafts"`
+	Audit        bool `yaml:"audit" json:"audit"`
 }
 
-func NewReleasesRuleType(deleteDrafts bool) *ReleasesRule {
+func NewReleasesRuleType(deleteDrafts, audit bool) *ReleasesRule {
 	return &ReleasesRule{
 		DeleteDrafts: deleteDrafts,
+		Audit:        audit,
 	}
 }
 
-func NewReleasesRule(deleteDrafts bool) interfaces.IReleasesRule {
-	return NewReleasesRuleType(deleteDrafts)
+func NewReleasesRule(deleteDrafts, audit bool) interfaces.IReleasesRule {
+	return NewReleasesRuleType(deleteDrafts, audit)
 }
 
 func (r *ReleasesRule) GetDeleteDrafts() bool             { return r.DeleteDrafts }
 func (r *ReleasesRule) SetDeleteDrafts(deleteDrafts bool) { r.DeleteDrafts = deleteDrafts }
+func (r *ReleasesRule) GetAudit() bool                    { return r.Audit }
+func (r *ReleasesRule) SetAudit(audit bool)               { r.Audit = audit }
 func (r *ReleasesRule) GetRuleName() string               { return "releases" }
 func (r *ReleasesRule) SetRuleName(name string)           { /* // No-op for releases rule */ }
@@ -8,6 +8,7 @@ type Rules struct {
 	*ReleasesRule   `yaml:"releases" json:"releases"`
 	*SecurityRule   `yaml:"security" json:"security"`
 	*MonitoringRule `yaml:"monitoring" json:"monitoring"`
+	*LicensesRule   `yaml:"licenses" json:"licenses"`
 }
 
 func NewRulesType(
@@ -16,6 +17,7 @@ func NewRulesType(
 	releases interfaces.IReleasesRule,
 	security interfaces.ISecurityRule,
 	monitoring interfaces.IMonitoringRule,
+	licenses interfaces.ILicensesRule,
 ) *Rules {
 	if runs == nil {
 		runs = &RunsRule{}
@@ -32,6 +34,9 @@ func NewRulesType(
 	if monitoring == nil {
 		monitoring = &MonitoringRule{}
 	}
+	if licenses == nil {
+		licenses = &LicensesRule{}
+	}
 
 	return &Rules{
 		RunsRule:       runs.(*RunsRule),
@@ -39,6 +44,7 @@ func NewRulesType(
 		ReleasesRule:   releases.(*ReleasesRule),
 		SecurityRule:   security.(*SecurityRule),
 		MonitoringRule: monitoring.(*MonitoringRule),
+		LicensesRule:   licenses.(*LicensesRule),
 	}
 }
 
@@ -48,6 +54,7 @@ func NewRules(
 	releases interfaces.IReleasesRule,
 	security interfaces.ISecurityRule,
 	monitoring interfaces.IMonitoringRule,
+	licenses interfaces.ILicensesRule,
 ) interfaces.IRules {
 	return NewRulesType(
 		runs,
@@ -55,6 +62,7 @@ func NewRules(
 		releases,
 		security,
 		monitoring,
+		licenses,
 	)
 }
 
@@ -65,11 +73,18 @@ func (r *Rules) GetArtifacts() interfaces.IRule                { return r.Artifa
 func (r *Rules) GetReleases() interfaces.IRule                 { return r.ReleasesRule }
 func (r *Rules) GetSecurity() interfaces.IRule                 { return r.SecurityRule }
 func (r *Rules) GetMonitoring() interfaces.IRule               { return r.MonitoringRule }
+func (r *Rules) GetLicenses() interfaces.IRule                 { return r.LicensesRule }
 func (r *Rules) GetSecurityRule() interfaces.ISecurityRule     { return r.SecurityRule }
 func (r *Rules) GetMonitoringRule() interfaces.IMonitoringRule { return r.MonitoringRule }
 func (r *Rules) GetReleasesRule() interfaces.IReleasesRule     { return r.ReleasesRule }
 func (r *Rules) GetArtifactsRule() interfaces.IArtifactsRule   { return r.ArtifactsRule }
 func (r *Rules) GetRunsRule() interfaces.IRunsRule             { return r.RunsRule }
+func (r *Rules) GetLicensesRule() interfaces.ILicensesRule {
+	if r.LicensesRule == nil {
+		return &LicensesRule{}
+	}
+	return r.LicensesRule
+}
 
 func (r *Rules) SetRuns(rule interfaces.IRunsRule) {
 	if rule == nil {
@@ -161,3 +176,10 @@ func (r *Rules) SetRunsRule(rule interfaces.IRunsRule) {
 		}
 	}
 }
+func (r *Rules) SetLicensesRule(rule interfaces.ILicensesRule) {
+	if rule == nil {
+		r.LicensesRule = nil
+	} else if licenses, ok := rule.(*LicensesRule); ok {
+		r.LicensesRule = licenses
+	}
+}
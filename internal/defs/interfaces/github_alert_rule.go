@@ -0,0 +1,12 @@
+package interfaces
+
+type IAlertRule interface {
+	GetName() string
+	GetMetric() string
+	GetCondition() string
+	GetOperator() string
+	GetValue() float64
+	GetWindow() int
+	GetCooldown() string
+	GetSeverity() string
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+type ILicensesRule interface {
+	IRule
+	GetAllow() []string
+	SetAllow(allow []string)
+	GetDeny() []string
+	SetDeny(deny []string)
+	GetReview() []string
+	SetReview(review []string)
+	GetProprietary() bool
+	SetProprietary(proprietary bool)
+	GetUnknownPolicy() string
+	SetUnknownPolicy(policy string)
+}
@@ -8,4 +8,5 @@ type IMonitoringRule interface {
 	SetInactiveDaysThreshold(days int)
 	GetMonitorPRs() bool
 	SetMonitorPRs(monitor bool)
+	GetAlerts() []IAlertRule
 }
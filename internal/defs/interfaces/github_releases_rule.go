@@ -4,4 +4,6 @@ type IReleasesRule interface {
 	IRule
 	GetDeleteDrafts() bool
 	SetDeleteDrafts(deleteDrafts bool)
+	GetAudit() bool
+	SetAudit(audit bool)
 }
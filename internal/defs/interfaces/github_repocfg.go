@@ -5,6 +5,7 @@ type IRepoCfg interface {
 	GetName() string
 	GetRules() IRules
 	GetMonitoring() IMonitoringRule
+	GetSchedules() []ISchedule
 	SetOwner(owner string)
 	SetName(name string)
 	SetRules(rules IRules)
@@ -6,16 +6,19 @@ type IRules interface {
 	GetReleases() IRule
 	GetSecurity() IRule
 	GetMonitoring() IRule
+	GetLicenses() IRule
 
 	GetRunsRule() IRunsRule
 	GetArtifactsRule() IArtifactsRule
 	GetReleasesRule() IReleasesRule
 	GetSecurityRule() ISecurityRule
 	GetMonitoringRule() IMonitoringRule
+	GetLicensesRule() ILicensesRule
 
 	SetRunsRule(runs IRunsRule)
 	SetArtifactsRule(artifacts IArtifactsRule)
 	SetReleasesRule(releases IReleasesRule)
 	SetSecurityRule(security ISecurityRule)
 	SetMonitoringRule(monitoring IMonitoringRule)
+	SetLicensesRule(licenses ILicensesRule)
 }
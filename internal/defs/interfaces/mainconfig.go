@@ -10,6 +10,7 @@ type IMainConfig interface {
 	GetRuntime() IRuntime
 	GetGitHub() IGitHub
 	GetNotifiers() INotifiers
+	GetServer() IServer
 	GetGrompt() grompt.PromptEngine
 	GetConfigObject() any
 	String() string
@@ -4,7 +4,10 @@ type IRuntime interface {
 	GetDebug() bool
 	GetDryRun() bool
 	GetReportDir() string
+	GetBackground() bool
+	GetSchedules() []ISchedule
 	SetDebug(debug bool)
 	SetDryRun(dryRun bool)
 	SetReportDir(reportDir string)
+	SetBackground(background bool)
 }
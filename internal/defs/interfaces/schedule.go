@@ -0,0 +1,11 @@
+package interfaces
+
+type ISchedule interface {
+	GetName() string
+	GetCron() string
+	GetOperator() string
+	GetRepos() []string
+	GetJitter() string
+	GetCatchUp() bool
+	GetDryRun() bool
+}
@@ -5,4 +5,6 @@ type IServer interface {
 	GetAddr() string
 	// GetPort returns the server port.
 	GetPort() string
+	// GetWebhooks returns the webhook receiver configuration.
+	GetWebhooks() IWebhooks
 }
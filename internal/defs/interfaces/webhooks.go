@@ -0,0 +1,16 @@
+package interfaces
+
+type IWebhooks interface {
+	GetPath() string
+	GetSecret() string
+	GetEventLog() string
+	GetTriggers() []IWebhookTrigger
+}
+
+type IWebhookTrigger interface {
+	GetEvent() string
+	GetAction() string
+	GetOperator() string
+	GetRepos() []string
+	GetDryRun() bool
+}
@@ -0,0 +1,57 @@
+package notifiers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+)
+
+type Slack struct {
+	Webhook string
+}
+
+func NewSlackNotifier(webhook string) interfaces.INotifier {
+	return &Slack{
+		Webhook: webhook,
+	}
+}
+
+func (s *Slack) GetType() string {
+	return "slack"
+}
+
+func (s *Slack) SetWebhook(webhook string) {
+	s.Webhook = webhook
+}
+
+func (s *Slack) GetWebhook() string {
+	return s.Webhook
+}
+
+func (s *Slack) Send(ctx context.Context, title, text string, files ...interfaces.IAttachment) error {
+	if s.Webhook == "" {
+		return nil
+	}
+	payload := map[string]any{
+		"text": "*" + title + "*\n" + text,
+	}
+	b, _ := json.Marshal(payload)
+	req, err := http.NewRequestWithContext(ctx, "POST", s.Webhook, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package fanout runs a task over many repositories with bounded global and per-owner
+// concurrency, waiting out exhausted GitHub rate limits, stopping on cancellation and
+// reporting partial results with an aggregated error of the repositories that failed.
+package fanout
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/ghclient"
+)
+
+const defaultConcurrency = 4
+
+// DefaultOptions reads GHBEX_CONCURRENCY (default 4) and GHBEX_OWNER_CONCURRENCY (default
+// unbounded) and waits on the process rate limit tracker
+func DefaultOptions() Options {
+	opts := Options{Concurrency: defaultConcurrency, Limiter: ghclient.DefaultRateTracker()}
+	if n, err := strconv.Atoi(config.GetEnvOrDefault("GHBEX_CONCURRENCY", "")); err == nil && n > 0 {
+		opts.Concurrency = n
+	}
+	if n, err := strconv.Atoi(config.GetEnvOrDefault("GHBEX_OWNER_CONCURRENCY", "")); err == nil && n > 0 {
+		opts.PerOwner = n
+	}
+	return opts
+}
+
+// ParseRepos turns "name" and "owner/name" specs into repositories of owner by default
+func ParseRepos(owner string, specs []string) []Repo {
+	out := make([]Repo, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		if o, name, ok := strings.Cut(spec, "/"); ok {
+			out = append(out, Repo{Owner: o, Name: name})
+		} else {
+			out = append(out, Repo{Owner: owner, Name: spec})
+		}
+	}
+	return out
+}
+
+// Run calls fn for every repository. Tasks not started when ctx is canceled are reported as
+// skipped; a task that panics fails with the panic instead of crashing the fan-out.
+func Run[T any](ctx context.Context, repos []Repo, opts Options, fn func(ctx context.Context, repo Repo) (T, error)) *Report[T] {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	start := time.Now()
+	report := &Report[T]{Results: make([]Result[T], len(repos))}
+
+	global := make(chan struct{}, opts.Concurrency)
+	owners := map[string]chan struct{}{}
+	if opts.PerOwner > 0 {
+		for _, repo := range repos {
+			if owners[repo.Owner] == nil {
+				owners[repo.Owner] = make(chan struct{}, opts.PerOwner)
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	done := 0
+	finish := func(i int, res Result[T]) {
+		mu.Lock()
+		report.Results[i] = res
+		done++
+		n := done
+		mu.Unlock()
+		if opts.OnDone != nil {
+			opts.OnDone(res.Repo, res.Err, n, len(repos))
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// The owner slot comes first so that tasks queued behind a busy owner do not hold
+			// global slots other owners could use
+			release, err := acquire(ctx, owners[repo.Owner], global)
+			if err != nil {
+				finish(i, Result[T]{Repo: repo, Err: err, Skipped: true})
+				return
+			}
+			defer release()
+			if opts.Limiter != nil {
+				if err := opts.Limiter.Wait(ctx); err != nil {
+					finish(i, Result[T]{Repo: repo, Err: err, Skipped: ctx.Err() != nil})
+					return
+				}
+			}
+			began := time.Now()
+			value, err := call(ctx, repo, fn)
+			finish(i, Result[T]{Repo: repo, Value: value, Err: err, Duration: time.Since(began)})
+		}()
+	}
+	wg.Wait()
+
+	report.Canceled = ctx.Err() != nil
+	report.Elapsed = time.Since(start)
+	return report
+}
+
+// acquire takes a slot of each non-nil semaphore in order
+func acquire(ctx context.Context, sems ...chan struct{}) (func(), error) {
+	var held []chan struct{}
+	release := func() {
+		for _, sem := range held {
+			<-sem
+		}
+	}
+	for _, sem := range sems {
+		if sem == nil {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+			held = append(held, sem)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	// A slot may have been won in a race with the cancellation
+	if err := ctx.Err(); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+func call[T any](ctx context.Context, repo Repo, fn func(ctx context.Context, repo Repo) (T, error)) (value T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx, repo)
+}
@@ -0,0 +1,126 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Repo is a repository processed by a fan-out
+type Repo struct {
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+}
+
+func (r Repo) String() string { return r.Owner + "/" + r.Name }
+
+// Limiter is consulted before every task, e.g. a *ghclient.RateTracker
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Options configures a fan-out
+type Options struct {
+	// Concurrency bounds the tasks running at once (default 4)
+	Concurrency int
+	// PerOwner bounds the tasks running at once for one owner, 0 for no bound
+	PerOwner int
+	// Limiter delays tasks while the GitHub budget is exhausted
+	Limiter Limiter
+	// OnDone is called after each task with the number of finished tasks
+	OnDone func(repo Repo, err error, done, total int)
+}
+
+// Result is the outcome of one repository
+type Result[T any] struct {
+	Repo     Repo          `json:"repo"`
+	Value    T             `json:"value,omitempty"`
+	Err      error         `json:"-"`
+	Skipped  bool          `json:"skipped,omitempty"` // Not started before the fan-out was canceled
+	Duration time.Duration `json:"duration"`
+}
+
+// Report holds the results of a fan-out in the order of its repositories, including the
+// partial results of a canceled one
+type Report[T any] struct {
+	Results  []Result[T]   `json:"results"`
+	Canceled bool          `json:"canceled,omitempty"`
+	Elapsed  time.Duration `json:"elapsed"`
+}
+
+// Values returns the values of the successful repositories
+func (r *Report[T]) Values() []T {
+	out := make([]T, 0, len(r.Results))
+	for _, res := range r.Results {
+		if res.Err == nil {
+			out = append(out, res.Value)
+		}
+	}
+	return out
+}
+
+// Succeeded counts the successful repositories
+func (r *Report[T]) Succeeded() int { return len(r.Values()) }
+
+// Err returns an *Errors listing the failed and skipped repositories, nil when all succeeded
+func (r *Report[T]) Err() error {
+	errs := &Errors{Total: len(r.Results)}
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs.Failures = append(errs.Failures, Failure{Repo: res.Repo, Err: res.Err, Skipped: res.Skipped})
+		}
+	}
+	if len(errs.Failures) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Failure is a repository that failed or was skipped
+type Failure struct {
+	Repo    Repo
+	Err     error
+	Skipped bool
+}
+
+// Errors is the aggregated error of a fan-out
+type Errors struct {
+	Failures []Failure
+	Total    int
+}
+
+func (e *Errors) Error() string {
+	var failed, skipped []string
+	for _, f := range e.Failures {
+		if f.Skipped {
+			skipped = append(skipped, f.Repo.String())
+		} else {
+			failed = append(failed, fmt.Sprintf("%s: %v", f.Repo, f.Err))
+		}
+	}
+	var parts []string
+	if len(failed) > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d repositories failed (%s)", len(failed), e.Total, strings.Join(failed, "; ")))
+	}
+	if len(skipped) > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped after cancellation (%s)", len(skipped), strings.Join(skipped, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (e *Errors) Unwrap() []error {
+	out := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		out = append(out, f.Err)
+	}
+	return out
+}
+
+// AsErrors extracts the *Errors of err
+func AsErrors(err error) (*Errors, bool) {
+	var errs *Errors
+	ok := errors.As(err, &errs)
+	return errs, ok
+}
@@ -0,0 +1,179 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// ErrRateLimited is returned by RateTracker.Wait when the budget resets later than MaxWait
+var ErrRateLimited = errors.New("github rate limit exhausted")
+
+// RateState is the last known budget of a rate limit resource ("core", "search", "graphql"...)
+type RateState struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"reset"`
+	Updated   time.Time `json:"updated"`
+}
+
+// RateOptions configures a RateTracker
+type RateOptions struct {
+	// Reserve is the core budget left for other clients of the same token (default 50, at
+	// most 5% of the limit so anonymous clients are not blocked)
+	Reserve int
+	// MaxWait bounds how long Wait blocks for a reset (default 15m)
+	MaxWait time.Duration
+}
+
+// RateTracker records the rate limit headers of every GitHub response and lets workers wait
+// for a reset instead of failing on an exhausted budget. It also honors the Retry-After of
+// secondary rate limits.
+type RateTracker struct {
+	opts RateOptions
+
+	mu         sync.Mutex
+	states     map[string]RateState
+	pauseUntil time.Time
+}
+
+var defaultTracker = NewRateTracker(RateOptions{})
+
+// DefaultRateTracker is the tracker shared by the clients of the process
+func DefaultRateTracker() *RateTracker { return defaultTracker }
+
+func NewRateTracker(opts RateOptions) *RateTracker {
+	if opts.Reserve <= 0 {
+		opts.Reserve = 50
+	}
+	if opts.MaxWait <= 0 {
+		opts.MaxWait = 15 * time.Minute
+	}
+	return &RateTracker{opts: opts, states: map[string]RateState{}}
+}
+
+// Track returns a copy of cli that reports its responses to t
+func Track(cli *github.Client, t *RateTracker) *github.Client {
+	hc := cli.Client() // A copy, the transport of cli itself cannot be replaced
+	if rt, ok := hc.Transport.(*rateTransport); ok && rt.tracker == t {
+		return cli
+	}
+	hc.Transport = &rateTransport{base: hc.Transport, tracker: t}
+	tracked := github.NewClient(hc)
+	tracked.BaseURL, tracked.UploadURL, tracked.UserAgent = cli.BaseURL, cli.UploadURL, cli.UserAgent
+	return tracked
+}
+
+// Observe records the rate limit headers of resp
+func (t *RateTracker) Observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	h := resp.Header
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		st := RateState{Resource: strings.ToLower(h.Get("X-RateLimit-Resource")), Limit: limit, Updated: now}
+		if st.Resource == "" {
+			st.Resource = "core"
+		}
+		st.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+		st.Used, _ = strconv.Atoi(h.Get("X-RateLimit-Used"))
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			st.Reset = time.Unix(reset, 0)
+		}
+		t.states[st.Resource] = st
+	}
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil && secs > 0 {
+			if until := now.Add(time.Duration(secs) * time.Second); until.After(t.pauseUntil) {
+				t.pauseUntil = until
+			}
+		}
+	}
+}
+
+// State returns the last known budget of resource
+func (t *RateTracker) State(resource string) (RateState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	st, ok := t.states[resource]
+	return st, ok
+}
+
+// Snapshot returns the last known budget of every resource seen
+func (t *RateTracker) Snapshot() []RateState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]RateState, 0, len(t.states))
+	for _, st := range t.states {
+		out = append(out, st)
+	}
+	slices.SortFunc(out, func(a, b RateState) int { return strings.Compare(a.Resource, b.Resource) })
+	return out
+}
+
+// Wait blocks while the core budget is down to the reserve or a secondary limit asked to back
+// off. It returns ctx's error when canceled and ErrRateLimited when the wait exceeds MaxWait.
+func (t *RateTracker) Wait(ctx context.Context) error {
+	until := t.blockedUntil(time.Now())
+	if until.IsZero() {
+		return nil
+	}
+	wait := time.Until(until)
+	if wait > t.opts.MaxWait {
+		return fmt.Errorf("%w: resets at %s", ErrRateLimited, until.Format(time.RFC3339))
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// blockedUntil is the time work may resume, zero when it need not wait
+func (t *RateTracker) blockedUntil(now time.Time) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var until time.Time
+	if t.pauseUntil.After(now) {
+		until = t.pauseUntil
+	}
+	if st, ok := t.states["core"]; ok && st.Remaining <= min(t.opts.Reserve, st.Limit/20) && st.Reset.After(now) && st.Reset.After(until) {
+		until = st.Reset
+	}
+	return until
+}
+
+// rateTransport reports every response to its tracker
+type rateTransport struct {
+	base    http.RoundTripper
+	tracker *RateTracker
+}
+
+func (r *rateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := r.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		r.tracker.Observe(resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,292 @@
+// Package history persists the key metrics of every analysis run per repository in an
+// append-only JSON lines store under the ghbex data dir, and derives trends, week-over-week
+// deltas and moving averages from it.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+)
+
+const fileExt = ".jsonl"
+
+// Store is a directory with one JSON lines file per repository. It is safe for concurrent use
+// within a process.
+type Store struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// DefaultDir is GHBEX_HISTORY_DIR, defaulting to ~/.kubex/ghbex/history
+func DefaultDir() string {
+	return config.GetEnvOrDefault("GHBEX_HISTORY_DIR", filepath.Join(config.GetBaseFilesPath(), "history"))
+}
+
+// DefaultRetention keeps 30 days of raw runs, 6 months of daily and 2 years of weekly averages
+func DefaultRetention() Retention {
+	return Retention{RawDays: 30, DailyDays: 180, WeeklyDays: 730}
+}
+
+// NewStore opens the store at dir, DefaultDir when empty
+func NewStore(dir string) *Store {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	return &Store{dir: dir}
+}
+
+// Dir is the store directory
+func (s *Store) Dir() string { return s.dir }
+
+// Record appends a raw point with the given metrics. Empty metric sets are ignored.
+func (s *Store) Record(owner, repo, source string, at time.Time, values map[string]float64) error {
+	if len(values) == 0 {
+		return nil
+	}
+	path, err := s.path(owner, repo)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(Point{Time: at.UTC(), Resolution: ResolutionRaw, Count: 1, Source: source, Metrics: values})
+	if err != nil {
+		return fmt.Errorf("failed to encode history point: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history point: %w", err)
+	}
+	return nil
+}
+
+// Load returns every point of a repository recorded at or after since, oldest first.
+// A repository without history has no points.
+func (s *Store) Load(owner, repo string, since time.Time) ([]Point, error) {
+	path, err := s.path(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	points, err := readPoints(path)
+	if err != nil {
+		return nil, err
+	}
+	filtered := points[:0]
+	for _, p := range points {
+		if !p.Time.Before(since) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
+
+// Query returns the samples of one metric recorded at or after since, oldest first
+func (s *Store) Query(owner, repo, metric string, since time.Time) ([]Sample, error) {
+	points, err := s.Load(owner, repo, since)
+	if err != nil {
+		return nil, err
+	}
+	return Samples(points, metric), nil
+}
+
+// Repositories lists the repositories with history
+func (s *Store) Repositories() ([]RepoKey, error) {
+	owners, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+	var keys []RepoKey
+	for _, owner := range owners {
+		if !owner.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(s.dir, owner.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history directory: %w", err)
+		}
+		for _, f := range files {
+			if name, ok := strings.CutSuffix(f.Name(), fileExt); ok && !f.IsDir() {
+				keys = append(keys, RepoKey{Owner: owner.Name(), Repo: name})
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Owner != keys[j].Owner {
+			return keys[i].Owner < keys[j].Owner
+		}
+		return keys[i].Repo < keys[j].Repo
+	})
+	return keys, nil
+}
+
+// Replace swaps every point of one source for points, keeping the points of other sources, so
+// that writing the same source twice (e.g. a backfill) is idempotent. It returns the number of
+// points written.
+func (s *Store) Replace(owner, repo, source string, points []Point) (int, error) {
+	path, err := s.path(owner, repo)
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, err := readPoints(path)
+	if err != nil {
+		return 0, err
+	}
+	merged := make([]Point, 0, len(existing)+len(points))
+	for _, p := range existing {
+		if p.Source != source {
+			merged = append(merged, p)
+		}
+	}
+	for _, p := range points {
+		if len(p.Metrics) == 0 {
+			continue
+		}
+		p.Time = p.Time.UTC()
+		p.Source = source
+		if p.Resolution == "" {
+			p.Resolution = ResolutionRaw
+		}
+		if p.Count == 0 {
+			p.Count = 1
+		}
+		merged = append(merged, p)
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Time.Before(merged[j].Time) })
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	if err := writePoints(path, merged); err != nil {
+		return 0, err
+	}
+	return len(merged) - (len(existing) - countSource(existing, source)), nil
+}
+
+// Compact applies the retention policy to a repository: raw points older than RawDays become
+// daily averages, daily points older than DailyDays become weekly averages and points older than
+// WeeklyDays are dropped. It returns the number of points before and after.
+func (s *Store) Compact(owner, repo string, retention Retention, now time.Time) (before, after int, err error) {
+	path, err := s.path(owner, repo)
+	if err != nil {
+		return 0, 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	points, err := readPoints(path)
+	if err != nil || len(points) == 0 {
+		return 0, 0, err
+	}
+	compacted := Downsample(points, retention, now)
+	if err := writePoints(path, compacted); err != nil {
+		return 0, 0, err
+	}
+	return len(points), len(compacted), nil
+}
+
+// CompactAll compacts every repository in the store
+func (s *Store) CompactAll(retention Retention, now time.Time) (before, after int, err error) {
+	keys, err := s.Repositories()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, key := range keys {
+		b, a, err := s.Compact(key.Owner, key.Repo, retention, now)
+		if err != nil {
+			return before, after, fmt.Errorf("failed to compact %s/%s: %w", key.Owner, key.Repo, err)
+		}
+		before += b
+		after += a
+	}
+	return before, after, nil
+}
+
+// Downsample applies the retention policy to points, returning a new slice oldest first.
+// Merged points carry the count-weighted mean of each metric. Points of different sources
+// never share a bucket, so Replace still finds a source's points after compaction.
+func Downsample(points []Point, retention Retention, now time.Time) []Point {
+	rawCutoff := now.AddDate(0, 0, -retention.RawDays)
+	dailyCutoff := now.AddDate(0, 0, -retention.DailyDays)
+	var dropCutoff time.Time
+	if retention.WeeklyDays > 0 {
+		dropCutoff = now.AddDate(0, 0, -retention.WeeklyDays)
+	}
+
+	var out []Point
+	buckets := map[string]*bucket{}
+	// Buckets are chosen by their start so that compacting twice is a no-op
+	for _, p := range points {
+		key := p.Source + "|"
+		week, day := weekStart(p.Time), dayStart(p.Time)
+		if week.Before(dropCutoff) {
+			continue
+		}
+		switch {
+		case day.Before(dailyCutoff):
+			addToBucket(buckets, key+"w"+week.Format("2006-01-02"), ResolutionWeekly, week, p)
+		case day.Before(rawCutoff) && p.Resolution != ResolutionWeekly:
+			addToBucket(buckets, key+"d"+day.Format("2006-01-02"), ResolutionDaily, day, p)
+		default:
+			out = append(out, p)
+		}
+	}
+	for _, b := range buckets {
+		out = append(out, b.point())
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Time.Equal(out[j].Time) {
+			return out[i].Time.Before(out[j].Time)
+		}
+		return out[i].Source < out[j].Source
+	})
+	return out
+}
+
+// Samples extracts one metric from points, skipping points that did not measure it
+func Samples(points []Point, metric string) []Sample {
+	var samples []Sample
+	for _, p := range points {
+		if v, ok := p.Metrics[metric]; ok {
+			samples = append(samples, Sample{Time: p.Time, Value: v})
+		}
+	}
+	return samples
+}
+
+// MetricNames lists every metric present in points, sorted
+func MetricNames(points []Point) []string {
+	seen := map[string]bool{}
+	for _, p := range points {
+		for name := range p.Metrics {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
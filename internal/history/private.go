@@ -0,0 +1,136 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type bucket struct {
+	at         time.Time
+	resolution string
+	count      int
+	source     string
+	sums       map[string]float64
+	weights    map[string]int
+}
+
+func addToBucket(buckets map[string]*bucket, key, resolution string, at time.Time, p Point) {
+	b, ok := buckets[key]
+	if !ok {
+		b = &bucket{at: at, resolution: resolution, source: p.Source, sums: map[string]float64{}, weights: map[string]int{}}
+		buckets[key] = b
+	}
+	count := p.Count
+	if count <= 0 {
+		count = 1
+	}
+	b.count += count
+	for name, v := range p.Metrics {
+		b.sums[name] += v * float64(count)
+		b.weights[name] += count
+	}
+}
+
+func (b *bucket) point() Point {
+	values := make(map[string]float64, len(b.sums))
+	for name, sum := range b.sums {
+		values[name] = sum / float64(b.weights[name])
+	}
+	return Point{Time: b.at, Resolution: b.resolution, Count: b.count, Source: b.source, Metrics: values}
+}
+
+func dayStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart is the Monday of the ISO week of t
+func weekStart(t time.Time) time.Time {
+	day := dayStart(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func (s *Store) path(owner, repo string) (string, error) {
+	for _, part := range []string{owner, repo} {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `/\`) {
+			return "", fmt.Errorf("invalid repository %s/%s", owner, repo)
+		}
+	}
+	return filepath.Join(s.dir, owner, repo+fileExt), nil
+}
+
+func readPoints(path string) ([]Point, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+
+	var points []Point
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var p Point
+		// A torn last line from an interrupted write is skipped rather than failing the whole history
+		if err := json.Unmarshal(line, &p); err != nil {
+			continue
+		}
+		points = append(points, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return points, nil
+}
+
+func countSource(points []Point, source string) int {
+	n := 0
+	for _, p := range points {
+		if p.Source == source {
+			n++
+		}
+	}
+	return n
+}
+
+// writePoints replaces the file atomically
+func writePoints(path string, points []Point) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create history file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, p := range points {
+		if err := enc.Encode(p); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to encode history point: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace history file: %w", err)
+	}
+	return nil
+}
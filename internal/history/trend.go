@@ -0,0 +1,217 @@
+package history
+
+import (
+	"math"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+)
+
+// MovingWindow is the number of samples averaged by the moving average
+const MovingWindow = 4
+
+// stableThreshold is the relative change under which a metric is considered stable
+const stableThreshold = 0.02
+
+// lowerIsBetter lists the metrics that improve when they go down
+var lowerIsBetter = map[string]bool{
+	MetricDuplication:    true,
+	MetricCyclomatic:     true,
+	MetricLeadTimeP50:    true,
+	MetricLeadTimeP95:    true,
+	MetricChangeFailRate: true,
+	MetricMTTR:           true,
+	MetricDepsVulnerable: true,
+	MetricDepsOutdated:   true,
+	MetricFirstReviewP50: true,
+	MetricKnowledgeSilos: true,
+	MetricOpenPRs:        true,
+	MetricStalePRs:       true,
+	MetricStaleBranches:  true,
+
+	MetricWorkflowFailureRate: true,
+	MetricPRCycleTimeP50:      true,
+	MetricOpenIssues:          true,
+}
+
+// LowerIsBetter reports whether a decrease of the metric is an improvement
+func LowerIsBetter(metric string) bool { return lowerIsBetter[metric] }
+
+// ComputeTrend derives the trend of one metric from its samples, oldest first.
+// With fewer than two samples the direction is stable.
+func ComputeTrend(metric string, samples []Sample) Trend {
+	trend := Trend{Metric: metric, Samples: len(samples), Direction: DirectionStable}
+	if len(samples) == 0 {
+		return trend
+	}
+	last := samples[len(samples)-1]
+	trend.First = samples[0].Time
+	trend.Last = last.Time
+	trend.Latest = last.Value
+	trend.Values = make([]float64, len(samples))
+	for i, s := range samples {
+		trend.Values[i] = s.Value
+	}
+
+	// Week over week: the latest sample at least 7 days older than the last one
+	weekAgo := last.Time.Add(-7 * 24 * time.Hour)
+	for i := len(samples) - 2; i >= 0; i-- {
+		if !samples[i].Time.After(weekAgo) {
+			prev := samples[i].Value
+			delta := last.Value - prev
+			trend.WeekAgo = &prev
+			trend.WeekOverWeek = &delta
+			if prev != 0 {
+				pct := delta / math.Abs(prev) * 100
+				trend.WeekOverWeekPct = &pct
+			}
+			break
+		}
+	}
+
+	window := MovingWindow
+	if window > len(samples) {
+		window = len(samples)
+	}
+	trend.MovingAverage = mean(trend.Values[len(samples)-window:])
+
+	if len(samples) < 2 {
+		return trend
+	}
+	// Compare the recent half of the period with the older half, which smooths single noisy runs
+	half := len(samples) / 2
+	current := mean(trend.Values[len(samples)-half:])
+	previous := mean(trend.Values[:half])
+	trend.Direction = direction(metric, previous, current)
+	return trend
+}
+
+// Trends computes the trend of every metric present in points, sorted by metric name
+func Trends(points []Point) []Trend {
+	names := MetricNames(points)
+	trends := make([]Trend, 0, len(names))
+	for _, name := range names {
+		trends = append(trends, ComputeTrend(name, Samples(points, name)))
+	}
+	return trends
+}
+
+// OverallDirection summarizes several trends into "improving", "stable" or "declining".
+// The CHI decides when it moved; otherwise the majority of the moving metrics does.
+func OverallDirection(trends []Trend) string {
+	improving, declining := 0, 0
+	for _, t := range trends {
+		if t.Metric == MetricCHI && t.Direction != DirectionStable {
+			return t.Direction
+		}
+		switch t.Direction {
+		case DirectionImproving:
+			improving++
+		case DirectionDeclining:
+			declining++
+		}
+	}
+	switch {
+	case improving > declining:
+		return DirectionImproving
+	case declining > improving:
+		return DirectionDeclining
+	default:
+		return DirectionStable
+	}
+}
+
+// FromScorecard extracts the metrics of a scorecard worth recording. Groups that were not
+// measured are left out so they do not record as zeros.
+func FromScorecard(sc *metrics.EnhancedScorecard) map[string]float64 {
+	if sc == nil {
+		return nil
+	}
+	values := map[string]float64{}
+	if sc.Health.CHI > 0 {
+		values[MetricCHI] = sc.Health.CHI
+	}
+	if sc.Code.MI > 0 {
+		values[MetricMI] = sc.Code.MI
+		values[MetricDuplication] = sc.Code.DuplicationPct
+		values[MetricCyclomatic] = sc.Code.CyclomaticAvg
+	}
+	if sc.Dora.PeriodUnit != "" {
+		values[MetricDeployFreq] = sc.Dora.DeploymentFrequency
+		values[MetricLeadTimeP50] = sc.Dora.LeadTimeP50
+		values[MetricLeadTimeP95] = sc.Dora.LeadTimeP95
+		values[MetricChangeFailRate] = sc.Dora.ChangeFailRate
+		values[MetricMTTR] = sc.Dora.MTTR
+	}
+	if sc.Deps.Count > 0 || sc.Deps.Health > 0 {
+		values[MetricDepsVulnerable] = float64(sc.Deps.Vulnerable)
+		values[MetricDepsOutdated] = float64(sc.Deps.Outdated)
+		values[MetricDepsHealth] = sc.Deps.Health
+	}
+	if sc.Community.Contributors > 0 {
+		values[MetricContributors] = float64(sc.Community.Contributors)
+	}
+	if sc.Community.BusFactor > 0 {
+		values[MetricBusFactor] = float64(sc.Community.BusFactor)
+	}
+	if sc.Community.FirstReviewP50 > 0 {
+		values[MetricFirstReviewP50] = sc.Community.FirstReviewP50
+		values[MetricReviewCoverage] = sc.Community.ReviewCoveragePct
+	}
+	if len(sc.Community.Paths) > 0 {
+		values[MetricKnowledgeSilos] = float64(sc.Community.KnowledgeSilos)
+	}
+	return values
+}
+
+// ApplyTrends fills the trend series of a scorecard that are still empty from points
+func ApplyTrends(sc *metrics.EnhancedScorecard, points []Point) {
+	if sc == nil {
+		return
+	}
+	if len(sc.Code.Trend) == 0 {
+		sc.Code.Trend = values(Samples(points, MetricCHI))
+	}
+	if len(sc.Community.TrendLeadTime) == 0 {
+		sc.Community.TrendLeadTime = values(Samples(points, MetricLeadTimeP50))
+	}
+	if len(sc.Community.TrendFirstReview) == 0 {
+		sc.Community.TrendFirstReview = values(Samples(points, MetricFirstReviewP50))
+	}
+}
+
+func direction(metric string, previous, current float64) string {
+	change := current - previous
+	if previous != 0 {
+		change /= math.Abs(previous)
+	}
+	if math.Abs(change) < stableThreshold {
+		return DirectionStable
+	}
+	if (change > 0) != LowerIsBetter(metric) {
+		return DirectionImproving
+	}
+	return DirectionDeclining
+}
+
+func values(samples []Sample) []float64 {
+	if len(samples) == 0 {
+		return nil
+	}
+	out := make([]float64, len(samples))
+	for i, s := range samples {
+		out[i] = s.Value
+	}
+	return out
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,98 @@
+package history
+
+import "time"
+
+// Resolutions of a stored point
+const (
+	ResolutionRaw    = "raw"
+	ResolutionDaily  = "daily"
+	ResolutionWeekly = "weekly"
+)
+
+// Trend directions
+const (
+	DirectionImproving = "improving"
+	DirectionStable    = "stable"
+	DirectionDeclining = "declining"
+)
+
+// Metric names recorded from scorecards and portfolio runs
+const (
+	MetricCHI            = "chi"
+	MetricMI             = "mi"
+	MetricDuplication    = "duplication_pct"
+	MetricCyclomatic     = "cyclomatic_avg"
+	MetricDeployFreq     = "deploy_frequency"
+	MetricLeadTimeP50    = "lead_time_p50"
+	MetricLeadTimeP95    = "lead_time_p95"
+	MetricChangeFailRate = "change_fail_rate"
+	MetricMTTR           = "mttr"
+	MetricDepsVulnerable = "deps_vulnerable"
+	MetricDepsOutdated   = "deps_outdated"
+	MetricDepsHealth     = "deps_health"
+	MetricContributors   = "contributors"
+	MetricBusFactor      = "bus_factor"
+	MetricFirstReviewP50 = "first_review_p50"
+	MetricReviewCoverage = "review_coverage_pct"
+	MetricKnowledgeSilos = "knowledge_silos"
+	MetricHealth         = "health"
+	MetricAutomation     = "automation"
+	MetricOpenPRs        = "open_prs"
+	MetricStalePRs       = "stale_prs"
+	MetricStaleBranches  = "stale_branches"
+
+	MetricWorkflowFailureRate = "workflow_failure_rate"
+
+	// Weekly flow, reconstructed by backfill
+	MetricCommits        = "commits"
+	MetricMergedPRs      = "merged_prs"
+	MetricPRCycleTimeP50 = "pr_cycle_time_p50"
+	MetricIssuesOpened   = "issues_opened"
+	MetricIssuesClosed   = "issues_closed"
+	MetricOpenIssues     = "open_issues"
+	MetricReleases       = "releases"
+)
+
+// Point is one run of one repository
+type Point struct {
+	Time       time.Time          `json:"time"`
+	Resolution string             `json:"resolution"`
+	Count      int                `json:"count"` // Runs merged into this point by downsampling
+	Source     string             `json:"source,omitempty"`
+	Metrics    map[string]float64 `json:"metrics"`
+}
+
+// Sample is the value of one metric at one point
+type Sample struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// Retention keeps raw points for RawDays, daily averages up to DailyDays and weekly averages
+// up to WeeklyDays; older points are dropped. Zero WeeklyDays keeps weekly points forever.
+type Retention struct {
+	RawDays    int `json:"raw_days"`
+	DailyDays  int `json:"daily_days"`
+	WeeklyDays int `json:"weekly_days"`
+}
+
+// Trend summarizes the history of one metric
+type Trend struct {
+	Metric          string    `json:"metric"`
+	Samples         int       `json:"samples"`
+	First           time.Time `json:"first"`
+	Last            time.Time `json:"last"`
+	Latest          float64   `json:"latest"`
+	WeekAgo         *float64  `json:"week_ago,omitempty"` // Latest value at least 7 days before the last sample
+	WeekOverWeek    *float64  `json:"week_over_week,omitempty"`
+	WeekOverWeekPct *float64  `json:"week_over_week_pct,omitempty"`
+	MovingAverage   float64   `json:"moving_average"` // Mean of the last MovingWindow samples
+	Direction       string    `json:"direction"`
+	Values          []float64 `json:"values"` // Oldest first, for sparklines
+}
+
+// RepoKey identifies a tracked repository
+type RepoKey struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+}
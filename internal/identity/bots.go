@@ -0,0 +1,57 @@
+package identity
+
+import (
+	"regexp"
+	"strings"
+)
+
+// defaultBotPatterns match the usual automation accounts of GitHub repositories
+var defaultBotPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\[bot\]$`),
+	regexp.MustCompile(`^(dependabot|renovate|github-actions|greenkeeper|snyk-bot|codecov|mergify|imgbot|allcontributors|pre-commit-ci|semantic-release-bot)\b`),
+	regexp.MustCompile(`(^|[-_.])bot$`),
+	regexp.MustCompile(`^actions@github\.com$`),
+	regexp.MustCompile(`^noreply@github\.com$`),
+	regexp.MustCompile(`\+[a-z0-9-]+\[bot\]@users\.noreply\.github\.com$`),
+}
+
+// IsBot classifies a login, commit name or email with the default bot patterns
+func IsBot(values ...string) bool {
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		for _, re := range defaultBotPatterns {
+			if re.MatchString(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Resolver) addBotPatterns(patterns []string) {
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			continue
+		}
+		r.botPatterns = append(r.botPatterns, re)
+	}
+}
+
+func (r *Resolver) matchesBot(values ...string) bool {
+	for _, v := range values {
+		v = strings.ToLower(v)
+		if v == "" {
+			continue
+		}
+		for _, re := range r.botPatterns {
+			if re.MatchString(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,300 @@
+// Package identity resolves the several logins and commit emails of a contributor to one
+// identity, honoring the repository .mailmap and the global alias file, and classifies bots.
+package identity
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Resolver merges logins and emails into identities. It is safe for concurrent use.
+type Resolver struct {
+	mu          sync.Mutex
+	excludeBots bool
+	botPatterns []*regexp.Regexp
+	byLogin     map[string]*Identity
+	byEmail     map[string]*Identity
+	byName      map[string]*Identity
+	mailmap     map[string]MailmapEntry
+}
+
+// NewResolver creates a resolver with the default bot patterns plus opts.BotPatterns.
+// Invalid patterns are ignored.
+func NewResolver(opts Options) *Resolver {
+	r := &Resolver{
+		excludeBots: opts.ExcludeBots,
+		botPatterns: append([]*regexp.Regexp(nil), defaultBotPatterns...),
+		byLogin:     make(map[string]*Identity),
+		byEmail:     make(map[string]*Identity),
+		byName:      make(map[string]*Identity),
+		mailmap:     make(map[string]MailmapEntry),
+	}
+	r.addBotPatterns(opts.BotPatterns)
+	return r
+}
+
+// ExcludesBots reports whether bots are left out of people metrics
+func (r *Resolver) ExcludesBots() bool {
+	return r.excludeBots
+}
+
+// AddAliases registers the people of an alias file; its exclude_bots and bot_patterns
+// settings override the resolver options
+func (r *Resolver) AddAliases(file *AliasFile) {
+	if file == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if file.ExcludeBots != nil {
+		r.excludeBots = *file.ExcludeBots
+	}
+	r.addBotPatterns(file.BotPatterns)
+
+	for _, p := range file.People {
+		logins := p.Logins
+		if p.Login != "" {
+			logins = append([]string{p.Login}, logins...)
+		}
+		var id *Identity
+		for _, login := range logins {
+			id = r.link(id, normalizeLogin(login), "", p.Name)
+		}
+		for _, email := range p.Emails {
+			id = r.link(id, "", normalizeEmail(email), p.Name)
+		}
+		if id == nil && p.Name != "" {
+			id = r.link(nil, "", "", p.Name)
+		}
+		if id == nil {
+			continue
+		}
+		if p.Name != "" {
+			id.Name = p.Name
+			r.byName[strings.ToLower(p.Name)] = id
+		}
+		id.Bot = id.Bot || p.Bot
+	}
+}
+
+// AddMailmap registers .mailmap entries: commits matching an entry are attributed to its proper name and email
+func (r *Resolver) AddMailmap(entries []MailmapEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range entries {
+		commitEmail := normalizeEmail(e.CommitEmail)
+		r.mailmap[mailmapKey(e.CommitName, commitEmail)] = e
+
+		properEmail := normalizeEmail(e.ProperEmail)
+		if properEmail == "" {
+			properEmail = commitEmail
+		}
+		id := r.link(nil, "", properEmail, e.ProperName)
+		if commitEmail != properEmail {
+			id = r.link(id, "", commitEmail, e.ProperName)
+		}
+		if e.ProperName != "" {
+			id.Name = e.ProperName
+			r.byName[strings.ToLower(e.ProperName)] = id
+		}
+	}
+}
+
+// Resolve returns the identity behind a login, commit name and commit email (any may be empty).
+// Unknown contributors get a new identity; a login seen together with an email links both.
+func (r *Resolver) Resolve(login, name, email string) *Identity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	login = normalizeLogin(login)
+	email = normalizeEmail(email)
+	name = strings.TrimSpace(name)
+
+	if e, ok := r.lookupMailmap(name, email); ok {
+		if e.ProperName != "" {
+			name = e.ProperName
+		}
+		if pe := normalizeEmail(e.ProperEmail); pe != "" {
+			email = pe
+		}
+	}
+	if login == "" {
+		login = loginFromNoreply(email)
+	}
+
+	id := r.link(nil, login, email, name)
+	if id == nil {
+		id = r.link(nil, "", "", "unknown")
+	}
+	if !id.Bot && r.matchesBot(login, name, email) {
+		id.Bot = true
+	}
+	return id
+}
+
+// Key returns the canonical key of a contributor and whether it counts for people
+// metrics (false for bots when bots are excluded)
+func (r *Resolver) Key(login, name, email string) (string, bool) {
+	id := r.Resolve(login, name, email)
+	return id.Key, !(id.Bot && r.ExcludesBots())
+}
+
+// Canonical maps a key returned earlier by Key to the current canonical key. Keys can change
+// when a later commit links an email to a login, so callers remap after collecting.
+func (r *Resolver) Canonical(key string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, index := range []map[string]*Identity{r.byLogin, r.byEmail, r.byName} {
+		if id, ok := index[key]; ok {
+			return id.Key
+		}
+	}
+	return key
+}
+
+// Identities returns a snapshot of every known identity
+func (r *Resolver) Identities() []Identity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[*Identity]bool)
+	var out []Identity
+	for _, index := range []map[string]*Identity{r.byLogin, r.byEmail, r.byName} {
+		for _, id := range index {
+			if !seen[id] {
+				seen[id] = true
+				out = append(out, *id)
+			}
+		}
+	}
+	return out
+}
+
+// link finds or creates the identity for the given keys and attaches every key to it,
+// merging identities that turn out to be the same person. Callers hold r.mu.
+func (r *Resolver) link(id *Identity, login, email, name string) *Identity {
+	candidates := []*Identity{id}
+	if login != "" {
+		candidates = append(candidates, r.byLogin[login])
+	}
+	if email != "" {
+		candidates = append(candidates, r.byEmail[email])
+	}
+	if login == "" && email == "" && name != "" {
+		candidates = append(candidates, r.byName[strings.ToLower(name)])
+	}
+
+	var target *Identity
+	for _, c := range candidates {
+		if c == nil {
+			continue
+		}
+		if target == nil {
+			target = c
+		} else if c != target {
+			r.merge(target, c)
+		}
+	}
+
+	if target == nil {
+		if login == "" && email == "" && name == "" {
+			return nil
+		}
+		target = &Identity{Name: name}
+		if login == "" && email == "" {
+			r.byName[strings.ToLower(name)] = target
+		}
+	}
+
+	if login != "" {
+		r.byLogin[login] = target
+		target.Logins = appendUnique(target.Logins, login)
+	}
+	if email != "" {
+		r.byEmail[email] = target
+		target.Emails = appendUnique(target.Emails, email)
+	}
+	if target.Name == "" {
+		target.Name = name
+	}
+	target.Key = canonicalKey(target)
+	return target
+}
+
+// merge moves every key of src to dst. Callers hold r.mu.
+func (r *Resolver) merge(dst, src *Identity) {
+	for _, login := range src.Logins {
+		r.byLogin[login] = dst
+		dst.Logins = appendUnique(dst.Logins, login)
+	}
+	for _, email := range src.Emails {
+		r.byEmail[email] = dst
+		dst.Emails = appendUnique(dst.Emails, email)
+	}
+	for key, id := range r.byName {
+		if id == src {
+			r.byName[key] = dst
+		}
+	}
+	if dst.Name == "" {
+		dst.Name = src.Name
+	}
+	dst.Key = canonicalKey(dst)
+	dst.Bot = dst.Bot || src.Bot
+}
+
+// canonicalKey is the first login, else the first email, else the lowercase name
+func canonicalKey(id *Identity) string {
+	switch {
+	case len(id.Logins) > 0:
+		return id.Logins[0]
+	case len(id.Emails) > 0:
+		return id.Emails[0]
+	}
+	return strings.ToLower(id.Name)
+}
+
+func (r *Resolver) lookupMailmap(name, email string) (MailmapEntry, bool) {
+	if e, ok := r.mailmap[mailmapKey(name, email)]; ok {
+		return e, true
+	}
+	e, ok := r.mailmap[mailmapKey("", email)]
+	return e, ok
+}
+
+func mailmapKey(name, email string) string {
+	return strings.ToLower(strings.TrimSpace(name)) + "\x00" + email
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(email), "<>"))
+}
+
+// loginFromNoreply extracts the login of "12345+login@users.noreply.github.com" addresses
+func loginFromNoreply(email string) string {
+	local, ok := strings.CutSuffix(email, "@users.noreply.github.com")
+	if !ok {
+		return ""
+	}
+	if _, after, found := strings.Cut(local, "+"); found {
+		return after
+	}
+	return local
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
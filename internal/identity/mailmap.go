@@ -0,0 +1,56 @@
+package identity
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// ParseMailmap parses the git .mailmap formats:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+func ParseMailmap(data []byte) []MailmapEntry {
+	var entries []MailmapEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		names, emails := splitMailmapLine(line)
+		switch len(emails) {
+		case 1:
+			entries = append(entries, MailmapEntry{ProperName: names[0], CommitEmail: emails[0]})
+		case 2:
+			entries = append(entries, MailmapEntry{
+				ProperName:  names[0],
+				ProperEmail: emails[0],
+				CommitName:  names[1],
+				CommitEmail: emails[1],
+			})
+		}
+	}
+	return entries
+}
+
+// splitMailmapLine returns the name before each <email> and the emails themselves
+func splitMailmapLine(line string) ([]string, []string) {
+	var names, emails []string
+	for {
+		open := strings.Index(line, "<")
+		if open < 0 {
+			break
+		}
+		end := strings.Index(line[open:], ">")
+		if end < 0 {
+			break
+		}
+		names = append(names, strings.TrimSpace(line[:open]))
+		emails = append(emails, strings.TrimSpace(line[open+1:open+end]))
+		line = line[open+end+1:]
+	}
+	return names, emails
+}
@@ -0,0 +1,121 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"gopkg.in/yaml.v3"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// mailmapTTL bounds how long a fetched .mailmap is reused before it is fetched again
+const mailmapTTL = 10 * time.Minute
+
+var (
+	mailmapMu    sync.Mutex
+	mailmapCache = make(map[string]cachedMailmap) // "owner/repo" -> .mailmap entries
+
+	aliasOnce sync.Once
+	aliases   *AliasFile
+)
+
+type cachedMailmap struct {
+	entries   []MailmapEntry
+	fetchedAt time.Time
+}
+
+// DefaultOptions excludes bots unless GHBEX_INCLUDE_BOTS=true and reads the alias file
+// from GHBEX_ALIASES_FILE, defaulting to ~/.kubex/ghbex/config/aliases.yaml
+func DefaultOptions() Options {
+	return Options{
+		ExcludeBots: !strings.EqualFold(os.Getenv("GHBEX_INCLUDE_BOTS"), "true"),
+		AliasFile:   config.GetEnvOrDefault("GHBEX_ALIASES_FILE", filepath.Join(config.GetBaseFilesPath(), "config", "aliases.yaml")),
+	}
+}
+
+// LoadAliasFile reads a YAML (or JSON) alias file
+func LoadAliasFile(path string) (*AliasFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alias file: %w", err)
+	}
+	var file AliasFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse alias file: %w", err)
+	}
+	return &file, nil
+}
+
+// ForRepository returns a new resolver for a repository: default options, the global alias
+// file and the repository .mailmap. Each call gets its own resolver, so the identities one
+// analysis resolves do not leak into another. The .mailmap is cached for mailmapTTL; failed
+// fetches are not cached.
+func ForRepository(ctx context.Context, cli *github.Client, owner, repo string) *Resolver {
+	opts := DefaultOptions()
+	r := NewResolver(opts)
+	r.AddAliases(globalAliases(opts.AliasFile))
+	if cli != nil {
+		r.AddMailmap(repositoryMailmap(ctx, cli, owner, repo))
+	}
+	return r
+}
+
+// repositoryMailmap returns the .mailmap entries of a repository, from the cache while fresh.
+// A missing file is cached as no entries; other errors are logged and retried on the next call.
+func repositoryMailmap(ctx context.Context, cli *github.Client, owner, repo string) []MailmapEntry {
+	key := owner + "/" + repo
+	mailmapMu.Lock()
+	cached, ok := mailmapCache[key]
+	mailmapMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < mailmapTTL {
+		return cached.entries
+	}
+
+	var entries []MailmapEntry
+	file, _, resp, err := cli.Repositories.GetContents(ctx, owner, repo, ".mailmap", nil)
+	switch {
+	case err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound):
+		gl.Log("warn", fmt.Sprintf("Failed to fetch .mailmap of %s: %v", key, err))
+		return nil
+	case err == nil && file != nil:
+		content, err := file.GetContent()
+		if err != nil {
+			gl.Log("warn", fmt.Sprintf("Failed to decode .mailmap of %s: %v", key, err))
+			return nil
+		}
+		entries = ParseMailmap([]byte(content))
+	}
+
+	mailmapMu.Lock()
+	mailmapCache[key] = cachedMailmap{entries: entries, fetchedAt: time.Now()}
+	mailmapMu.Unlock()
+	return entries
+}
+
+// globalAliases loads the alias file once per process
+func globalAliases(path string) *AliasFile {
+	aliasOnce.Do(func() {
+		if path == "" {
+			return
+		}
+		if _, err := os.Stat(path); err != nil {
+			return
+		}
+		file, err := LoadAliasFile(path)
+		if err != nil {
+			gl.Log("warn", fmt.Sprintf("Ignoring alias file %s: %v", path, err))
+			return
+		}
+		aliases = file
+	})
+	return aliases
+}
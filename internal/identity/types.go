@@ -0,0 +1,45 @@
+package identity
+
+// Identity is one person (or bot) behind any number of GitHub logins and commit emails
+type Identity struct {
+	Key    string   `json:"key" yaml:"key"` // Canonical id: primary login, else primary email, else name
+	Name   string   `json:"name" yaml:"name"`
+	Logins []string `json:"logins,omitempty" yaml:"logins,omitempty"`
+	Emails []string `json:"emails,omitempty" yaml:"emails,omitempty"`
+	Bot    bool     `json:"bot" yaml:"bot"`
+}
+
+// Options configures a Resolver
+type Options struct {
+	// ExcludeBots makes Key report bots as not counted, removing them from people metrics
+	ExcludeBots bool
+	// BotPatterns are extra regular expressions matched against logins, names and emails
+	BotPatterns []string
+	// AliasFile is the global alias file; empty disables it
+	AliasFile string
+}
+
+// AliasFile is the global alias file of the ghbex configuration
+type AliasFile struct {
+	ExcludeBots *bool    `json:"exclude_bots,omitempty" yaml:"exclude_bots,omitempty"`
+	BotPatterns []string `json:"bot_patterns,omitempty" yaml:"bot_patterns,omitempty"`
+	People      []Person `json:"people" yaml:"people"`
+}
+
+// Person is one entry of the alias file
+type Person struct {
+	Name   string   `json:"name" yaml:"name"`
+	Login  string   `json:"login,omitempty" yaml:"login,omitempty"`
+	Logins []string `json:"logins,omitempty" yaml:"logins,omitempty"`
+	Emails []string `json:"emails,omitempty" yaml:"emails,omitempty"`
+	Bot    bool     `json:"bot,omitempty" yaml:"bot,omitempty"`
+}
+
+// MailmapEntry is one line of a .mailmap file. CommitName is empty when the line
+// matches on the commit email alone.
+type MailmapEntry struct {
+	ProperName  string
+	ProperEmail string
+	CommitName  string
+	CommitEmail string
+}
@@ -0,0 +1,207 @@
+// Package incremental keeps per-repository sync cursors and an aggregate of every issue and
+// pull request, so that analyses list only what changed since the previous run instead of
+// the whole history.
+package incremental
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+)
+
+// Store is a directory with one JSON snapshot per repository. It is safe for concurrent use
+// within a process.
+type Store struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// DefaultDir is GHBEX_SYNC_DIR, defaulting to ~/.kubex/ghbex/sync
+func DefaultDir() string {
+	return config.GetEnvOrDefault("GHBEX_SYNC_DIR", filepath.Join(config.GetBaseFilesPath(), "sync"))
+}
+
+// NewStore opens the store at dir, DefaultDir when empty
+func NewStore(dir string) *Store {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	return &Store{dir: dir}
+}
+
+// Load reads the snapshot of a repository; a repository never synced has an empty snapshot
+func (s *Store) Load(owner, repo string) (*Snapshot, error) {
+	path, err := s.path(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := NewSnapshot(owner, repo)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snap, nil
+		}
+		return nil, fmt.Errorf("failed to read sync snapshot: %w", err)
+	}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, fmt.Errorf("failed to decode sync snapshot: %w", err)
+	}
+	if snap.Items == nil {
+		snap.Items = map[int]*Item{}
+	}
+	return snap, nil
+}
+
+// Save writes the snapshot of a repository atomically
+func (s *Store) Save(snap *Snapshot) error {
+	path, err := s.path(snap.Owner, snap.Repo)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode sync snapshot: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create sync directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write sync snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace sync snapshot: %w", err)
+	}
+	return nil
+}
+
+// NewSnapshot creates an empty snapshot
+func NewSnapshot(owner, repo string) *Snapshot {
+	return &Snapshot{Owner: owner, Repo: repo, Items: map[int]*Item{}}
+}
+
+// Sync brings a snapshot up to date. Without a cursor, or with opts.FullResync, it lists the
+// whole history; otherwise it lists only the issues and pull requests updated since the cursor,
+// and nothing at all when the newest repository event is the one seen by the previous sync.
+func Sync(ctx context.Context, cli *github.Client, snap *Snapshot, opts Options) (Result, error) {
+	start := time.Now()
+	result := Result{Full: opts.FullResync || snap.Cursor.SyncedAt.IsZero()}
+
+	// Read the newest event before listing, so events that happen during the listing are
+	// not mistaken for already synced on the next run
+	eventID, err := latestEventID(ctx, cli, snap.Owner, snap.Repo)
+	if err != nil {
+		eventID = ""
+	}
+	if !result.Full && eventID != "" && eventID == snap.Cursor.LastEventID {
+		result.Skipped = true
+		result.Total = len(snap.Items)
+		snap.Cursor.SyncedAt = start
+		result.Duration = time.Since(start)
+		return result, nil
+	}
+
+	items := snap.Items
+	since := snap.Cursor.UpdatedAt
+	if result.Full {
+		items = map[int]*Item{}
+		since = time.Time{}
+	}
+
+	opt := &github.IssueListByRepoOptions{
+		State:       "all",
+		Sort:        "updated",
+		Direction:   "asc",
+		Since:       since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	latest := since
+	for {
+		issues, resp, err := cli.Issues.ListByRepo(ctx, snap.Owner, snap.Repo, opt)
+		if err != nil {
+			return result, fmt.Errorf("failed to list issues of %s/%s: %w", snap.Owner, snap.Repo, err)
+		}
+		result.Pages++
+		for _, issue := range issues {
+			item := fromIssue(issue)
+			items[item.Number] = item
+			result.Fetched++
+			if item.UpdatedAt.After(latest) {
+				latest = item.UpdatedAt
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	snap.Items = items
+	snap.Cursor.UpdatedAt = latest
+	snap.Cursor.LastEventID = eventID
+	snap.Cursor.SyncedAt = start
+	if result.Full {
+		snap.Cursor.FullSyncAt = start
+	}
+	result.Total = len(items)
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// SyncRepository loads, syncs and saves the snapshot of a repository
+func (s *Store) SyncRepository(ctx context.Context, cli *github.Client, owner, repo string, opts Options) (*Snapshot, Result, error) {
+	snap, err := s.Load(owner, repo)
+	if err != nil {
+		return nil, Result{}, err
+	}
+	result, err := Sync(ctx, cli, snap, opts)
+	if err != nil {
+		return nil, result, err
+	}
+	if err := s.Save(snap); err != nil {
+		return nil, result, err
+	}
+	return snap, result, nil
+}
+
+// Issues lists the stored issues, excluding pull requests
+func (snap *Snapshot) Issues() []*Item {
+	return snap.filter(func(it *Item) bool { return !it.PR })
+}
+
+// PullRequests lists the stored pull requests
+func (snap *Snapshot) PullRequests() []*Item {
+	return snap.filter(func(it *Item) bool { return it.PR })
+}
+
+func (snap *Snapshot) filter(keep func(*Item) bool) []*Item {
+	var items []*Item
+	for _, it := range snap.Items {
+		if keep(it) {
+			items = append(items, it)
+		}
+	}
+	return items
+}
+
+func (s *Store) path(owner, repo string) (string, error) {
+	for _, part := range []string{owner, repo} {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `/\`) {
+			return "", fmt.Errorf("invalid repository %s/%s", owner, repo)
+		}
+	}
+	return filepath.Join(s.dir, owner, repo+".json"), nil
+}
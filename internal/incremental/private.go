@@ -0,0 +1,31 @@
+package incremental
+
+import (
+	"context"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// latestEventID returns the id of the newest repository event, empty when there is none
+func latestEventID(ctx context.Context, cli *github.Client, owner, repo string) (string, error) {
+	events, _, err := cli.Activity.ListRepositoryEvents(ctx, owner, repo, &github.ListOptions{PerPage: 1})
+	if err != nil || len(events) == 0 {
+		return "", err
+	}
+	return events[0].GetID(), nil
+}
+
+func fromIssue(issue *github.Issue) *Item {
+	item := &Item{
+		Number:    issue.GetNumber(),
+		PR:        issue.IsPullRequest(),
+		State:     issue.GetState(),
+		CreatedAt: issue.GetCreatedAt().Time,
+		UpdatedAt: issue.GetUpdatedAt().Time,
+		ClosedAt:  issue.GetClosedAt().Time,
+	}
+	if links := issue.PullRequestLinks; links != nil && links.MergedAt != nil {
+		item.Merged = true
+	}
+	return item
+}
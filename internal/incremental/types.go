@@ -0,0 +1,47 @@
+package incremental
+
+import "time"
+
+// Options controls a sync
+type Options struct {
+	// FullResync discards the stored items and lists the whole history again, which also drops
+	// issues that were deleted or transferred since they were stored
+	FullResync bool
+}
+
+// Cursor records how far a repository has been synced
+type Cursor struct {
+	UpdatedAt   time.Time `json:"updated_at"`              // Latest update time among the synced items
+	LastEventID string    `json:"last_event_id,omitempty"` // Newest repository event when the sync started
+	SyncedAt    time.Time `json:"synced_at"`
+	FullSyncAt  time.Time `json:"full_sync_at"`
+}
+
+// Item is the stored aggregate of one issue or pull request
+type Item struct {
+	Number    int       `json:"number"`
+	PR        bool      `json:"pr,omitempty"`
+	State     string    `json:"state"`
+	Merged    bool      `json:"merged,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ClosedAt  time.Time `json:"closed_at,omitempty"`
+}
+
+// Snapshot is the synced state of the issues and pull requests of one repository
+type Snapshot struct {
+	Owner  string        `json:"owner"`
+	Repo   string        `json:"repo"`
+	Cursor Cursor        `json:"cursor"`
+	Items  map[int]*Item `json:"items"`
+}
+
+// Result describes what a sync did
+type Result struct {
+	Full     bool          `json:"full"`
+	Skipped  bool          `json:"skipped"` // No repository event since the previous sync
+	Fetched  int           `json:"fetched"` // Items listed from the API
+	Total    int           `json:"total"`   // Items stored after the sync
+	Pages    int           `json:"pages"`
+	Duration time.Duration `json:"duration"`
+}
@@ -0,0 +1,355 @@
+// Package jobqueue is a durable, file-backed job queue. Every job and each of its state
+// transitions, attempts, checkpoints and results is persisted as it happens, so that the jobs
+// interrupted by a crash or Ctrl-C resume on the next run, skipping the stages they had
+// already checkpointed.
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/state"
+)
+
+// ErrNotFound is returned for an unknown job ID
+var ErrNotFound = errors.New("job not found")
+
+// DefaultDir is GHBEX_JOBS_DIR, defaulting to ~/.kubex/ghbex/jobs
+func DefaultDir() string {
+	return config.GetEnvOrDefault("GHBEX_JOBS_DIR", filepath.Join(config.GetBaseFilesPath(), "jobs"))
+}
+
+// Queue stores one JSON file per job under <dir>/jobs and appends every transition to
+// <dir>/events.jsonl
+type Queue struct {
+	dir    string
+	opts   Options
+	worker string
+	mu     sync.Mutex // Serializes the file updates of this process; see lock for other processes
+}
+
+// Open opens the queue in dir, DefaultDir when empty
+func Open(dir string, opts Options) (*Queue, error) {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 30 * time.Second
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = time.Minute
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "jobs"), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create job queue directory: %w", err)
+	}
+	host, _ := os.Hostname()
+	return &Queue{dir: dir, opts: opts, worker: fmt.Sprintf("%d@%s", os.Getpid(), host)}, nil
+}
+
+// Dir is the queue directory
+func (q *Queue) Dir() string { return q.dir }
+
+// Enqueue persists jobs as pending, filling in their ID, limits and timestamps
+func (q *Queue) Enqueue(jobs ...Job) ([]Job, error) {
+	if err := q.lock(); err != nil {
+		return nil, err
+	}
+	defer q.unlock()
+	now := time.Now().UTC()
+	out := make([]Job, 0, len(jobs))
+	for _, job := range jobs {
+		if job.ID == "" {
+			job.ID = newID(job.Kind, now)
+		}
+		if job.MaxAttempts <= 0 {
+			job.MaxAttempts = q.opts.MaxAttempts
+		}
+		job.Status, job.Attempts = StatusPending, 0
+		job.CreatedAt, job.UpdatedAt = now, now
+		if err := q.save(&job); err != nil {
+			return out, err
+		}
+		q.event(Transition{Time: now, Job: job.ID, To: StatusPending})
+		out = append(out, job)
+	}
+	return out, nil
+}
+
+// Get returns the job with id
+func (q *Queue) Get(id string) (Job, error) {
+	job, err := q.load(id)
+	if err != nil {
+		return Job{}, err
+	}
+	return *job, nil
+}
+
+// List returns the jobs matching filter (all when nil), oldest first
+func (q *Queue) List(filter func(Job) bool) ([]Job, error) {
+	entries, err := os.ReadDir(filepath.Join(q.dir, "jobs"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	var jobs []Job
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		job, err := q.load(e.Name()[:len(e.Name())-len(".json")])
+		if err != nil {
+			return nil, err
+		}
+		if filter == nil || filter(*job) {
+			jobs = append(jobs, *job)
+		}
+	}
+	slices.SortStableFunc(jobs, func(a, b Job) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return jobs, nil
+}
+
+// Unfinished selects the jobs that are not in a terminal status
+func Unfinished(job Job) bool { return !job.Status.Terminal() }
+
+// Stale reports whether a running job stopped beating, i.e. its process died
+func (q *Queue) Stale(job Job, now time.Time) bool {
+	return job.Status == StatusRunning && now.Sub(job.Heartbeat) > q.opts.StaleAfter
+}
+
+// Recover puts the running jobs whose process died back to pending, keeping their
+// checkpoints, and returns them
+func (q *Queue) Recover() ([]Job, error) {
+	now := time.Now()
+	stale, err := q.List(func(j Job) bool { return q.Stale(j, now) })
+	if err != nil {
+		return nil, err
+	}
+	if err := q.lock(); err != nil {
+		return nil, err
+	}
+	defer q.unlock()
+	var out []Job
+	for _, job := range stale {
+		cur, err := q.load(job.ID)
+		if err != nil || !q.Stale(*cur, now) {
+			continue
+		}
+		q.transition(cur, StatusPending, fmt.Sprintf("interrupted on %s", cur.Worker))
+		cur.Worker, cur.Heartbeat = "", time.Time{}
+		if err := q.save(cur); err != nil {
+			return out, err
+		}
+		out = append(out, *cur)
+	}
+	return out, nil
+}
+
+// Retry puts a failed or timed out job back to pending with a fresh set of attempts. Its
+// checkpoints are kept, so the stages that succeeded are not run again.
+func (q *Queue) Retry(id string) (Job, error) {
+	if err := q.lock(); err != nil {
+		return Job{}, err
+	}
+	defer q.unlock()
+	job, err := q.load(id)
+	if err != nil {
+		return Job{}, err
+	}
+	if job.Status != StatusFailed && job.Status != StatusTimeout {
+		return Job{}, fmt.Errorf("job %s is %s, only failed or timed out jobs can be retried", id, job.Status)
+	}
+	job.Attempts, job.NextAttempt = 0, time.Time{}
+	q.transition(job, StatusPending, "")
+	return *job, q.save(job)
+}
+
+// Prune removes the terminal jobs finished before cutoff and returns how many it removed
+func (q *Queue) Prune(cutoff time.Time) (int, error) {
+	done, err := q.List(func(j Job) bool { return j.Status.Terminal() && j.FinishedAt.Before(cutoff) })
+	if err != nil {
+		return 0, err
+	}
+	if err := q.lock(); err != nil {
+		return 0, err
+	}
+	defer q.unlock()
+	for i, job := range done {
+		if err := os.Remove(q.path(job.ID)); err != nil && !os.IsNotExist(err) {
+			return i, fmt.Errorf("failed to remove job %s: %w", job.ID, err)
+		}
+	}
+	return len(done), nil
+}
+
+// History returns the transitions of a job, oldest first
+func (q *Queue) History(id string) ([]Transition, error) {
+	return q.events(id)
+}
+
+// Run processes the runnable jobs matching filter (all when nil) with their kind's handler
+// until none is left, waiting for the retries that are due. Stale running jobs are recovered
+// first. When ctx is canceled, the running jobs go back to pending without losing an attempt
+// and Run returns ctx's error.
+func (q *Queue) Run(ctx context.Context, handlers map[string]Handler, filter func(Job) bool) (Summary, error) {
+	var sum Summary
+	var sumMu sync.Mutex
+	if _, err := q.Recover(); err != nil {
+		return sum, err
+	}
+	match := func(j Job) bool {
+		return handlers[j.Kind] != nil && (filter == nil || filter(j))
+	}
+
+	sem := make(chan struct{}, q.opts.Concurrency)
+	var wg sync.WaitGroup
+	running := map[string]bool{}
+	var runMu sync.Mutex
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs, err := q.List(func(j Job) bool { return match(j) && (j.Status == StatusPending || j.Status == StatusRetry) })
+		if err != nil {
+			wg.Wait()
+			return sum, err
+		}
+		now := time.Now()
+		var next time.Time
+		started := 0
+		for _, job := range jobs {
+			runMu.Lock()
+			busy := running[job.ID]
+			runMu.Unlock()
+			if busy {
+				continue
+			}
+			if job.NextAttempt.After(now) {
+				if next.IsZero() || job.NextAttempt.Before(next) {
+					next = job.NextAttempt
+				}
+				continue
+			}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			runMu.Lock()
+			running[job.ID] = true
+			runMu.Unlock()
+			started++
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				outcome := q.execute(ctx, job.ID, handlers[job.Kind])
+				sumMu.Lock()
+				switch outcome {
+				case StatusComplete:
+					sum.Complete++
+				case StatusRetry:
+					sum.Retried++
+				case StatusFailed:
+					sum.Failed++
+				case StatusTimeout:
+					sum.TimedOut++
+				case StatusPending:
+					sum.Interrupted++
+				}
+				sumMu.Unlock()
+				runMu.Lock()
+				delete(running, job.ID)
+				runMu.Unlock()
+			}()
+		}
+
+		runMu.Lock()
+		busy := len(running)
+		runMu.Unlock()
+		if started == 0 && busy == 0 && next.IsZero() {
+			break
+		}
+		// Poll while jobs run or a retry is pending
+		wait := time.Second
+		if started == 0 && busy == 0 {
+			wait = time.Until(next)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+	wg.Wait()
+	return sum, ctx.Err()
+}
+
+// Task is a running attempt of a job, handed to its Handler
+type Task struct {
+	q   *Queue
+	job *Job
+	mu  sync.Mutex
+}
+
+// Job returns a copy of the job
+func (t *Task) Job() Job {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return *t.job
+}
+
+// Done reports whether stage was checkpointed by a previous attempt
+func (t *Task) Done(stage state.Stage) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.job.Stages.Has(stage)
+}
+
+// Restore decodes the partial result saved with the last checkpoint into v, reporting
+// whether there was one
+func (t *Task) Restore(v any) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.job.Checkpoint) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(t.job.Checkpoint, v); err != nil {
+		return false, fmt.Errorf("failed to decode checkpoint of %s: %w", t.job.ID, err)
+	}
+	return true, nil
+}
+
+// Complete checkpoints stage with partial, the result so far, persisting both at once
+func (t *Task) Complete(stage state.Stage, partial any) error {
+	data, err := json.Marshal(partial)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.q.lock(); err != nil {
+		return err
+	}
+	defer t.q.unlock()
+	t.job.Stages |= stage
+	t.job.Checkpoint = data
+	t.job.UpdatedAt = time.Now().UTC()
+	t.q.event(Transition{Time: t.job.UpdatedAt, Job: t.job.ID, From: StatusRunning, To: StatusRunning, Attempt: t.job.Attempts, Stage: stage})
+	return t.q.save(t.job)
+}
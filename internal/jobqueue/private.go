@@ -0,0 +1,276 @@
+package jobqueue
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// lockStale is how long the queue lock file may be held. The updates it guards take
+// milliseconds, so an older lock was left behind by a crashed process.
+const lockStale = 30 * time.Second
+
+// lock serializes the job file updates of this process (mu) and of every process sharing the
+// queue directory (an O_EXCL lock file), so that two runners cannot claim the same job
+func (q *Queue) lock() error {
+	q.mu.Lock()
+	path := filepath.Join(q.dir, "queue.lock")
+	deadline := time.Now().Add(2 * lockStale)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			f.Close()
+			return nil
+		}
+		if !os.IsExist(err) {
+			q.mu.Unlock()
+			return fmt.Errorf("failed to lock job queue: %w", err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockStale {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			q.mu.Unlock()
+			return fmt.Errorf("failed to lock job queue: %s is held by another process", path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// unlock releases the lock taken by lock
+func (q *Queue) unlock() {
+	if err := os.Remove(filepath.Join(q.dir, "queue.lock")); err != nil && !os.IsNotExist(err) {
+		gl.Log("warning", fmt.Sprintf("Failed to unlock job queue: %v", err))
+	}
+	q.mu.Unlock()
+}
+
+// execute runs one attempt of a job and records its outcome, returning the new status (empty
+// when the job was claimed by another runner meanwhile)
+func (q *Queue) execute(ctx context.Context, id string, handler Handler) Status {
+	if err := q.lock(); err != nil {
+		gl.Log("error", err.Error())
+		return ""
+	}
+	job, err := q.load(id)
+	if err != nil || (job.Status != StatusPending && job.Status != StatusRetry) {
+		q.unlock()
+		return ""
+	}
+	now := time.Now().UTC()
+	job.Attempts++
+	job.StartedAt, job.Worker, job.Heartbeat = now, q.worker, now
+	q.transition(job, StatusRunning, "")
+	err = q.save(job)
+	q.unlock()
+	if err != nil {
+		gl.Log("error", err.Error())
+		return ""
+	}
+	gl.Log("info", fmt.Sprintf("▶️  Job %s (%s %s/%s, attempt %d/%d)", job.ID, job.Kind, job.Owner, job.Repo, job.Attempts, job.MaxAttempts))
+
+	task := &Task{q: q, job: job}
+	jobCtx, cancel := context.WithCancel(ctx)
+	if job.Timeout > 0 {
+		jobCtx, cancel = context.WithTimeout(ctx, job.Timeout)
+	}
+	stop := q.heartbeat(task)
+	result, err := call(jobCtx, task, handler)
+	timedOut := errors.Is(jobCtx.Err(), context.DeadlineExceeded)
+	cancel()
+	stop()
+
+	task.mu.Lock()
+	defer task.mu.Unlock()
+	if err := q.lock(); err != nil {
+		// Left running without a heartbeat, the job is recovered from its checkpoints
+		gl.Log("error", fmt.Sprintf("Job %s: %v", job.ID, err))
+		return ""
+	}
+	defer q.unlock()
+	now = time.Now().UTC()
+	switch {
+	case err == nil:
+		job.Result, err = json.Marshal(result)
+		if err != nil {
+			job.Result = nil
+			gl.Log("warning", fmt.Sprintf("Job %s: failed to encode result: %v", job.ID, err))
+		}
+		job.FinishedAt = now
+		q.transition(job, StatusComplete, "")
+	case ctx.Err() != nil:
+		// Interrupted, not failed: the attempt is given back
+		job.Attempts--
+		q.transition(job, StatusPending, "interrupted")
+	default:
+		msg, final := err.Error(), StatusFailed
+		if timedOut {
+			msg, final = fmt.Sprintf("timed out after %s", job.Timeout), StatusTimeout
+		}
+		if job.Attempts < job.MaxAttempts {
+			job.NextAttempt = now.Add(q.opts.Backoff << (job.Attempts - 1))
+			q.transition(job, StatusRetry, msg)
+		} else {
+			job.FinishedAt = now
+			q.transition(job, final, msg)
+		}
+	}
+	job.Worker, job.Heartbeat = "", time.Time{}
+	if err := q.save(job); err != nil {
+		gl.Log("error", err.Error())
+	}
+
+	switch job.Status {
+	case StatusComplete:
+		gl.Log("success", fmt.Sprintf("Job %s complete", job.ID))
+	case StatusRetry:
+		gl.Log("warning", fmt.Sprintf("Job %s failed, retrying at %s: %s", job.ID, job.NextAttempt.Local().Format("15:04:05"), job.Error))
+	case StatusPending:
+		gl.Log("warning", fmt.Sprintf("Job %s interrupted, it resumes on the next run", job.ID))
+	default:
+		gl.Log("error", fmt.Sprintf("Job %s %s: %s", job.ID, job.Status, job.Error))
+	}
+	return job.Status
+}
+
+// heartbeat refreshes the heartbeat of a running job until the returned func is called
+func (q *Queue) heartbeat(task *Task) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(q.opts.StaleAfter / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				task.mu.Lock()
+				if err := q.lock(); err != nil {
+					gl.Log("warning", err.Error())
+					task.mu.Unlock()
+					continue
+				}
+				task.job.Heartbeat = time.Now().UTC()
+				if err := q.save(task.job); err != nil {
+					gl.Log("warning", err.Error())
+				}
+				q.unlock()
+				task.mu.Unlock()
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func call(ctx context.Context, task *Task, handler Handler) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, task)
+}
+
+// transition moves job to status and logs it; the caller saves the job
+func (q *Queue) transition(job *Job, status Status, msg string) {
+	from := job.Status
+	job.Status, job.Error = status, msg
+	job.UpdatedAt = time.Now().UTC()
+	q.event(Transition{Time: job.UpdatedAt, Job: job.ID, From: from, To: status, Attempt: job.Attempts, Error: msg})
+}
+
+func (q *Queue) path(id string) string {
+	return filepath.Join(q.dir, "jobs", id+".json")
+}
+
+func (q *Queue) load(id string) (*Job, error) {
+	data, err := os.ReadFile(q.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to read job %s: %w", id, err)
+	}
+	job := &Job{}
+	if err := json.Unmarshal(data, job); err != nil {
+		return nil, fmt.Errorf("failed to decode job %s: %w", id, err)
+	}
+	return job, nil
+}
+
+// save replaces the job file atomically
+func (q *Queue) save(job *Job) error {
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode job %s: %w", job.ID, err)
+	}
+	path := q.path(job.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write job %s: %w", job.ID, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// event appends a transition to the event log. The job files are authoritative, so a failed
+// append is only logged.
+func (q *Queue) event(t Transition) {
+	data, _ := json.Marshal(t)
+	f, err := os.OpenFile(filepath.Join(q.dir, "events.jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err == nil {
+		_, err = f.Write(append(data, '\n'))
+		err = errors.Join(err, f.Close())
+	}
+	if err != nil {
+		gl.Log("warning", fmt.Sprintf("Failed to append to the job event log: %v", err))
+	}
+}
+
+func (q *Queue) events(id string) ([]Transition, error) {
+	f, err := os.Open(filepath.Join(q.dir, "events.jsonl"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open the job event log: %w", err)
+	}
+	defer f.Close()
+	var out []Transition
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var t Transition
+		if json.Unmarshal(sc.Bytes(), &t) != nil || (id != "" && t.Job != id) {
+			continue // A torn last line after a crash is skipped
+		}
+		out = append(out, t)
+	}
+	return out, sc.Err()
+}
+
+func newID(kind string, now time.Time) string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	if kind == "" {
+		kind = "job"
+	}
+	return fmt.Sprintf("%s-%s-%s", kind, now.Format("20060102T150405"), hex.EncodeToString(b))
+}
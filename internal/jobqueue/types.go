@@ -0,0 +1,96 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/state"
+)
+
+// Status is the state of a job
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusRunning  Status = "running"
+	StatusRetry    Status = "retry"    // Failed, waiting for its next attempt
+	StatusComplete Status = "complete" // Terminal
+	StatusFailed   Status = "failed"   // Terminal, out of attempts
+	StatusTimeout  Status = "timeout"  // Terminal, timed out on its last attempt
+)
+
+// Terminal reports whether a job in this status will not run again without Retry
+func (s Status) Terminal() bool {
+	return s == StatusComplete || s == StatusFailed || s == StatusTimeout
+}
+
+// Job is a durable unit of work on one repository
+type Job struct {
+	ID     string         `json:"id"`
+	Kind   string         `json:"kind"`            // Selects the handler, e.g. "sanitize"
+	Batch  string         `json:"batch,omitempty"` // Groups the jobs enqueued together
+	Owner  string         `json:"owner"`
+	Repo   string         `json:"repo"`
+	Params map[string]any `json:"params,omitempty"`
+	DryRun bool           `json:"dry_run"`
+
+	Status      Status        `json:"status"`
+	Attempts    int           `json:"attempts"`
+	MaxAttempts int           `json:"max_attempts"`
+	Timeout     time.Duration `json:"timeout,omitempty"` // Per attempt, 0 for none
+	Error       string        `json:"error,omitempty"`   // Error of the last attempt
+
+	// Stages are the checkpointed stages, skipped when an interrupted job resumes, and
+	// Checkpoint the partial result saved with the last of them
+	Stages     state.Stage     `json:"stages"`
+	Checkpoint json.RawMessage `json:"checkpoint,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	StartedAt   time.Time `json:"started_at,omitempty"`
+	FinishedAt  time.Time `json:"finished_at,omitempty"`
+	NextAttempt time.Time `json:"next_attempt,omitempty"`
+
+	// Worker ("pid@host") and Heartbeat identify the process running the job; a running job
+	// whose heartbeat went stale was interrupted by a crash
+	Worker    string    `json:"worker,omitempty"`
+	Heartbeat time.Time `json:"heartbeat,omitempty"`
+}
+
+// Transition is one line of the event log
+type Transition struct {
+	Time    time.Time   `json:"time"`
+	Job     string      `json:"job"`
+	From    Status      `json:"from,omitempty"`
+	To      Status      `json:"to"`
+	Attempt int         `json:"attempt"`
+	Stage   state.Stage `json:"stage,omitempty"` // Set for checkpoints
+	Error   string      `json:"error,omitempty"`
+}
+
+// Handler runs one attempt of a job. Its result is stored with the completed job.
+type Handler func(ctx context.Context, task *Task) (any, error)
+
+// Summary counts the outcomes of a Run
+type Summary struct {
+	Complete    int `json:"complete"`
+	Retried     int `json:"retried"`
+	Failed      int `json:"failed"`
+	TimedOut    int `json:"timed_out"`
+	Interrupted int `json:"interrupted"`
+}
+
+// Options configures a queue
+type Options struct {
+	// MaxAttempts is the default of the jobs enqueued without one (default 3)
+	MaxAttempts int
+	// Backoff delays the second attempt and doubles for every further one (default 30s)
+	Backoff time.Duration
+	// Concurrency bounds the jobs running at once (default 1)
+	Concurrency int
+	// StaleAfter is how old the heartbeat of a running job gets before it is considered
+	// interrupted (default 1m); running jobs beat four times as often
+	StaleAfter time.Duration
+}
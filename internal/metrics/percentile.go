@@ -0,0 +1,34 @@
+package metrics
+
+import "sort"
+
+// Percentile retorna o percentil p (0..100) dos valores usando interpolação linear.
+// Retorna 0 para uma amostra vazia; a entrada não é modificada.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	p = clamp(p, 0, 100)
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(rank)
+	if lo >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	frac := rank - float64(lo)
+	return sorted[lo] + frac*(sorted[lo+1]-sorted[lo])
+}
+
+// Mean retorna a média aritmética dos valores (0 para amostra vazia).
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
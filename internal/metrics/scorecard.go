@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"sort"
 	"time"
 )
 
@@ -63,6 +64,14 @@ type DepsMetrics struct {
 	Vulnerable int     `json:"vulnerable" yaml:"vulnerable"`
 	Outdated   int     `json:"outdated" yaml:"outdated"`
 	Health     float64 `json:"health" yaml:"health"`
+
+	// Alertas de segurança (Dependabot, code scanning e secret scanning)
+	// VulnerableBySeverity cobre só as dependências; ScanningBySeverity, os alertas de code e secret scanning
+	VulnerableBySeverity map[string]int `json:"vulnerable_by_severity,omitempty" yaml:"vulnerable_by_severity,omitempty"`
+	ScanningBySeverity   map[string]int `json:"scanning_by_severity,omitempty" yaml:"scanning_by_severity,omitempty"`
+	CodeScanningOpen     int            `json:"code_scanning_open,omitempty" yaml:"code_scanning_open,omitempty"`
+	SecretScanningOpen   int            `json:"secret_scanning_open,omitempty" yaml:"secret_scanning_open,omitempty"`
+	RemediationMTTRHours float64        `json:"remediation_mttr_hours,omitempty" yaml:"remediation_mttr_hours,omitempty"`
 }
 
 type CommunityMetrics struct {
@@ -73,11 +82,44 @@ type CommunityMetrics struct {
 	ReviewCoveragePct float64   `json:"review_coverage_pct,omitempty" yaml:"review_coverage_pct,omitempty"`
 	OnboardingDaysP50 float64   `json:"onboarding_days_p50,omitempty" yaml:"onboarding_days_p50,omitempty"`
 	TrendLeadTime     []float64 `json:"trend_lead_time,omitempty" yaml:"trend_lead_time,omitempty"`
+	TrendFirstReview  []float64 `json:"trend_first_review,omitempty" yaml:"trend_first_review,omitempty"`
+	// TrendCycleTime é o tempo de abertura até o merge dos PRs; TrendLeadTime fica para DORA/histórico
+	TrendCycleTime []float64 `json:"trend_cycle_time,omitempty" yaml:"trend_cycle_time,omitempty"`
+
+	// Propriedade por diretório, calculada a partir do histórico recente de commits
+	KnowledgeSilos int             `json:"knowledge_silos,omitempty" yaml:"knowledge_silos,omitempty"`
+	DepartedOwners int             `json:"departed_owners,omitempty" yaml:"departed_owners,omitempty"`
+	Paths          []PathOwnership `json:"paths,omitempty" yaml:"paths,omitempty"`
+}
+
+// PathOwnership resume o risco de conhecimento de um diretório
+type PathOwnership struct {
+	Path           string   `json:"path" yaml:"path"`
+	BusFactor      int      `json:"bus_factor" yaml:"bus_factor"`
+	Owners         []string `json:"owners" yaml:"owners"`
+	Silo           bool     `json:"silo" yaml:"silo"`
+	DepartedOwners []string `json:"departed_owners,omitempty" yaml:"departed_owners,omitempty"`
+	Risk           string   `json:"risk" yaml:"risk"`
 }
 
 type Provenance struct {
-	Sources []DataSource `json:"sources" yaml:"sources"`
-	Notes   string       `json:"notes,omitempty" yaml:"notes,omitempty"`
+	Sources  []DataSource      `json:"sources" yaml:"sources"`
+	Notes    string            `json:"notes,omitempty" yaml:"notes,omitempty"`
+	Releases *ReleaseIntegrity `json:"releases,omitempty" yaml:"releases,omitempty"`
+}
+
+// ReleaseIntegrity resume a auditoria de tags e releases
+type ReleaseIntegrity struct {
+	TagsChecked              int `json:"tags_checked" yaml:"tags_checked"`
+	ReleasesChecked          int `json:"releases_checked" yaml:"releases_checked"`
+	NonSemverTags            int `json:"non_semver_tags" yaml:"non_semver_tags"`
+	LightweightTags          int `json:"lightweight_tags" yaml:"lightweight_tags"`
+	UnsignedTags             int `json:"unsigned_tags" yaml:"unsigned_tags"`
+	UnsignedCommits          int `json:"unsigned_commits" yaml:"unsigned_commits"`
+	UnreachableTags          int `json:"unreachable_tags" yaml:"unreachable_tags"`
+	ReleasesWithoutAssets    int `json:"releases_without_assets" yaml:"releases_without_assets"`
+	ReleasesWithoutChecksums int `json:"releases_without_checksums" yaml:"releases_without_checksums"`
+	ChecksumMismatches       int `json:"checksum_mismatches" yaml:"checksum_mismatches"`
 }
 
 type DataSource struct {
@@ -145,6 +187,40 @@ func ConvertFromGHbexModel(data map[string]interface{}, owner, repo string) *Enh
 			if health, ok := deps["dependency_health"].(float64); ok {
 				scorecard.Deps.Health = health
 			}
+			if bySeverity, ok := deps["vulnerable_by_severity"].(map[string]interface{}); ok {
+				scorecard.Deps.VulnerableBySeverity = make(map[string]int)
+				for sev, count := range bySeverity {
+					if countFloat, ok := count.(float64); ok {
+						scorecard.Deps.VulnerableBySeverity[sev] = int(countFloat)
+					}
+				}
+			}
+			if bySeverity, ok := deps["scanning_by_severity"].(map[string]interface{}); ok {
+				scorecard.Deps.ScanningBySeverity = make(map[string]int)
+				for sev, count := range bySeverity {
+					if countFloat, ok := count.(float64); ok {
+						scorecard.Deps.ScanningBySeverity[sev] = int(countFloat)
+					}
+				}
+			}
+			if codeScanning, ok := deps["code_scanning_open"].(float64); ok {
+				scorecard.Deps.CodeScanningOpen = int(codeScanning)
+			}
+			if secretScanning, ok := deps["secret_scanning_open"].(float64); ok {
+				scorecard.Deps.SecretScanningOpen = int(secretScanning)
+			}
+			if mttr, ok := deps["remediation_mttr_hours"].(float64); ok {
+				scorecard.Deps.RemediationMTTRHours = mttr
+			}
+		}
+
+		if loc, ok := codeIntel["lines_of_code"].(map[string]interface{}); ok {
+			if total, ok := loc["total"].(float64); ok {
+				scorecard.Code.LocTotal = int(total)
+			}
+			if code, ok := loc["code"].(float64); ok {
+				scorecard.Code.LocCode = int(code)
+			}
 		}
 
 		if languages, ok := codeIntel["languages"].(map[string]interface{}); ok {
@@ -169,6 +245,50 @@ func ConvertFromGHbexModel(data map[string]interface{}, owner, repo string) *Enh
 			if reviewTime, ok := collaboration["average_review_time"].(float64); ok {
 				scorecard.Community.FirstReviewP50 = reviewTime
 			}
+			// Percentis reais, quando calculados a partir das reviews dos PRs
+			if p50, ok := collaboration["first_review_p50"].(float64); ok && p50 > 0 {
+				scorecard.Community.FirstReviewP50 = p50
+			}
+			if p90, ok := collaboration["first_review_p90"].(float64); ok {
+				scorecard.Community.FirstReviewP90 = p90
+			}
+			if coverage, ok := collaboration["pr_review_rate"].(float64); ok {
+				scorecard.Community.ReviewCoveragePct = coverage
+			}
+			scorecard.Community.TrendCycleTime = toFloatSlice(collaboration["trend_cycle_time"])
+			scorecard.Community.TrendFirstReview = toFloatSlice(collaboration["trend_first_review"])
+		}
+	}
+
+	// Extract ownership risk (bus factor por diretório)
+	if risk, ok := data["risk"].(map[string]interface{}); ok {
+		if busFactor, ok := risk["bus_factor"].(float64); ok && busFactor > 0 {
+			scorecard.Community.BusFactor = int(busFactor)
+		}
+		if silos, ok := risk["silos"].([]interface{}); ok {
+			scorecard.Community.KnowledgeSilos = len(silos)
+		}
+		if departed, ok := risk["departed_owners"].([]interface{}); ok {
+			scorecard.Community.DepartedOwners = len(departed)
+		}
+		if paths, ok := risk["paths"].([]interface{}); ok {
+			for _, item := range paths {
+				p, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				po := PathOwnership{
+					Owners:         toStringSlice(p["owners"]),
+					DepartedOwners: toStringSlice(p["departed_owners"]),
+				}
+				po.Path, _ = p["path"].(string)
+				po.Risk, _ = p["risk"].(string)
+				po.Silo, _ = p["silo"].(bool)
+				if bf, ok := p["bus_factor"].(float64); ok {
+					po.BusFactor = int(bf)
+				}
+				scorecard.Community.Paths = append(scorecard.Community.Paths, po)
+			}
 		}
 	}
 
@@ -201,9 +321,87 @@ func ConvertFromGHbexModel(data map[string]interface{}, owner, repo string) *Enh
 		Notes: "Converted from GHbex analysis model",
 	}
 
+	// Extract release integrity (auditoria de tags e releases)
+	if integrity, ok := data["release_integrity"].(map[string]interface{}); ok {
+		count := func(key string) int {
+			if v, ok := integrity[key].(float64); ok {
+				return int(v)
+			}
+			return 0
+		}
+		scorecard.Provenance.Releases = &ReleaseIntegrity{
+			TagsChecked:              count("tags_checked"),
+			ReleasesChecked:          count("releases_checked"),
+			NonSemverTags:            count("non_semver_tags"),
+			LightweightTags:          count("lightweight_tags"),
+			UnsignedTags:             count("unsigned_tags"),
+			UnsignedCommits:          count("unsigned_commits"),
+			UnreachableTags:          count("unreachable_tags"),
+			ReleasesWithoutAssets:    count("releases_without_assets"),
+			ReleasesWithoutChecksums: count("releases_without_checksums"),
+			ChecksumMismatches:       count("checksum_mismatches"),
+		}
+	}
+
 	return scorecard
 }
 
+// toFloatSlice converte um []interface{} decodificado de JSON em []float64
+func toFloatSlice(value interface{}) []float64 {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]float64, 0, len(items))
+	for _, item := range items {
+		if f, ok := item.(float64); ok {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// toStringSlice converte um []interface{} decodificado de JSON em []string
+func toStringSlice(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			out = append(out, str)
+		}
+	}
+	return out
+}
+
+// BusFactorFromCounts retorna o menor número de autores que somam mais de threshold
+// (0..1) das mudanças. Retorna 0 quando não há mudanças.
+func BusFactorFromCounts(counts map[string]int, threshold float64) int {
+	total := 0
+	values := make([]int, 0, len(counts))
+	for _, n := range counts {
+		if n > 0 {
+			values = append(values, n)
+			total += n
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(values)))
+
+	covered := 0
+	for i, n := range values {
+		covered += n
+		if float64(covered) > threshold*float64(total) {
+			return i + 1
+		}
+	}
+	return len(values)
+}
+
 // CalculateBusFactor estima o bus factor baseado na distribuição de commits
 func CalculateBusFactor(contributors []map[string]interface{}) int {
 	if len(contributors) == 0 {
@@ -0,0 +1,44 @@
+package metrics
+
+// Pesos por severidade usados para penalizar a saúde das dependências.
+var severityPenalty = map[string]float64{
+	"critical": 25,
+	"high":     10,
+	"medium":   4,
+	"low":      1,
+}
+
+// ComputeDepsHealth calcula a saúde das dependências (0..100) a partir dos alertas
+// abertos por severidade, da quantidade de dependências desatualizadas e do MTTR
+// de remediação (horas). Sem alertas e sem atrasos a nota é 100.
+func ComputeDepsHealth(openBySeverity map[string]int, outdated int, mttrHours float64) float64 {
+	score := 100.0
+	for sev, count := range openBySeverity {
+		penalty, ok := severityPenalty[sev]
+		if !ok {
+			penalty = severityPenalty["low"]
+		}
+		score -= penalty * float64(count)
+	}
+	score -= 0.5 * float64(outdated)
+
+	// remediação lenta pesa menos que alertas abertos, mas ainda conta
+	switch {
+	case mttrHours > 720: // mais de 30 dias
+		score -= 10
+	case mttrHours > 168: // mais de 7 dias
+		score -= 5
+	}
+
+	return clamp(score, 0, 100)
+}
+
+// ApplySecurityPenalty reduz o CHI conforme os alertas de segurança abertos.
+// Cada alerta crítico retira 5 pontos e cada alto 2, limitado a 30 pontos no total.
+func ApplySecurityPenalty(chi float64, openBySeverity map[string]int) float64 {
+	penalty := 5*float64(openBySeverity["critical"]) + 2*float64(openBySeverity["high"])
+	if penalty > 30 {
+		penalty = 30
+	}
+	return clamp(chi-penalty, 0, 100)
+}
@@ -60,6 +60,16 @@ func (m *Ghbex) Command() *cobra.Command {
 
 	rtCmd.AddCommand(cc.OperationsCmdList())
 	rtCmd.AddCommand(cc.ScoreCardRootCmd())
+	rtCmd.AddCommand(cc.SBOMCmd())
+	rtCmd.AddCommand(cc.ChangelogCmd())
+	rtCmd.AddCommand(cc.ReleaseCmd())
+	rtCmd.AddCommand(cc.HistoryCmd())
+	rtCmd.AddCommand(cc.BackfillCmd())
+	rtCmd.AddCommand(cc.AlertingCmd())
+	rtCmd.AddCommand(cc.WebhooksCmd())
+	rtCmd.AddCommand(cc.ScheduleCmd())
+	rtCmd.AddCommand(cc.JobsCmd())
+	rtCmd.AddCommand(cc.PipelineCmd())
 	rtCmd.AddCommand(vs.CliCommand())
 
 	// Set usage definitions for the command and its subcommands
@@ -150,18 +150,7 @@ func (v *ServiceImpl) updateLatestVersion() error {
 	return nil
 }
 func (v *ServiceImpl) vrsCompare(v1, v2 []int) (int, error) {
-	compare := 0
-	for i := 0; i < len(v1) && i < len(v2); i++ {
-		if v1[i] < v2[i] {
-			compare = -1
-			break
-		}
-		if v1[i] > v2[i] {
-			compare = 1
-			break
-		}
-	}
-	return compare, nil
+	return CompareVersions(v1, v2), nil
 }
 func (v *ServiceImpl) versionAtMost(versionAtMostArg, max []int) (bool, error) {
 	if comp, err := v.vrsCompare(versionAtMostArg, max); err != nil {
@@ -172,6 +161,11 @@ func (v *ServiceImpl) versionAtMost(versionAtMostArg, max []int) (bool, error) {
 	return true, nil
 }
 func (v *ServiceImpl) parseVersion(versionToParse string) []int {
+	return ParseVersion(versionToParse)
+}
+
+// ParseVersion parses "v1.2.3" (prerelease suffix ignored) into its numeric parts, nil if invalid
+func ParseVersion(versionToParse string) []int {
 	if versionToParse == "" {
 		return nil
 	}
@@ -192,6 +186,22 @@ func (v *ServiceImpl) parseVersion(versionToParse string) []int {
 	}
 	return parsedVersion
 }
+
+// CompareVersions compares two parsed versions part by part, returning -1, 0 or 1
+func CompareVersions(v1, v2 []int) int {
+	compare := 0
+	for i := 0; i < len(v1) && i < len(v2); i++ {
+		if v1[i] < v2[i] {
+			compare = -1
+			break
+		}
+		if v1[i] > v2[i] {
+			compare = 1
+			break
+		}
+	}
+	return compare
+}
 func (v *ServiceImpl) IsLatestVersion() (bool, error) {
 	if info.IsPrivate() {
 		return false, fmt.Errorf("cannot check version for private repositories")
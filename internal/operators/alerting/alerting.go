@@ -0,0 +1,374 @@
+// Package alerting evaluates alert rules against the metric history of repositories, with
+// static thresholds, week-over-week deltas and statistical detection (z-score and EWMA), and
+// turns the outcomes into deduplicated firing, repeat and resolve notifications.
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+)
+
+// DefaultOptions returns EWMA alpha 0.3, 5 samples minimum, a 12 sample window and a 24h cooldown
+func DefaultOptions() Options {
+	return Options{
+		Alpha:      0.3,
+		MinSamples: 5,
+		Window:     12,
+		Cooldown:   24 * time.Hour,
+	}
+}
+
+// DefaultStatePath is GHBEX_ALERTS_STATE, defaulting to ~/.kubex/ghbex/alerts/state.json
+func DefaultStatePath() string {
+	return config.GetEnvOrDefault("GHBEX_ALERTS_STATE", filepath.Join(config.GetBaseFilesPath(), "alerts", "state.json"))
+}
+
+// NewRule validates a configured alert rule and applies the defaults
+func NewRule(spec interfaces.IAlertRule, opts Options) (Rule, error) {
+	rule := Rule{
+		Name:      spec.GetName(),
+		Metric:    spec.GetMetric(),
+		Condition: strings.ToLower(spec.GetCondition()),
+		Operator:  spec.GetOperator(),
+		Value:     spec.GetValue(),
+		Window:    spec.GetWindow(),
+		Cooldown:  opts.Cooldown,
+		Severity:  strings.ToLower(spec.GetSeverity()),
+	}
+	if rule.Metric == "" {
+		return rule, fmt.Errorf("alert %q has no metric", rule.Name)
+	}
+	if rule.Name == "" {
+		rule.Name = rule.Metric + " " + rule.Condition
+	}
+	switch rule.Condition {
+	case "":
+		rule.Condition = ConditionThreshold
+	case ConditionThreshold, ConditionWeekOverWeek, ConditionZScore, ConditionEWMA:
+	default:
+		return rule, fmt.Errorf("alert %q has unknown condition %q", rule.Name, rule.Condition)
+	}
+	if _, ok := compare(rule.Operator, 0, 0); !ok {
+		return rule, fmt.Errorf("alert %q has unknown operator %q", rule.Name, rule.Operator)
+	}
+	if rule.Window <= 0 {
+		rule.Window = opts.Window
+	}
+	if cooldown := spec.GetCooldown(); cooldown != "" {
+		d, err := time.ParseDuration(cooldown)
+		if err != nil {
+			return rule, fmt.Errorf("alert %q has invalid cooldown: %w", rule.Name, err)
+		}
+		rule.Cooldown = d
+	}
+	switch rule.Severity {
+	case "":
+		rule.Severity = SeverityWarning
+	case SeverityCritical, SeverityWarning, SeverityInfo:
+	default:
+		return rule, fmt.Errorf("alert %q has unknown severity %q", rule.Name, rule.Severity)
+	}
+	return rule, nil
+}
+
+// RulesFromConfig validates the alert rules of a monitoring rule, returning the valid ones
+// and the errors of the others
+func RulesFromConfig(monitoring interfaces.IMonitoringRule, opts Options) ([]Rule, []error) {
+	if monitoring == nil {
+		return nil, nil
+	}
+	var rules []Rule
+	var errs []error
+	for _, spec := range monitoring.GetAlerts() {
+		rule, err := NewRule(spec, opts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errs
+}
+
+// Evaluate checks one rule against the history points of a repository, oldest first
+func Evaluate(repository string, rule Rule, points []history.Point, opts Options) Evaluation {
+	eval := Evaluation{Repository: repository, Rule: rule}
+	samples := history.Samples(points, rule.Metric)
+	if len(samples) == 0 {
+		eval.Skipped = "no samples of " + rule.Metric
+		return eval
+	}
+	last := samples[len(samples)-1]
+	eval.At = last.Time
+	eval.Latest = last.Value
+
+	switch rule.Condition {
+	case ConditionThreshold:
+		eval.Observed = last.Value
+	case ConditionWeekOverWeek:
+		trend := history.ComputeTrend(rule.Metric, samples)
+		if trend.WeekOverWeek == nil {
+			eval.Skipped = "less than a week of history"
+			return eval
+		}
+		eval.Observed = *trend.WeekOverWeek
+	case ConditionZScore, ConditionEWMA:
+		past := pastValues(samples, rule.Window)
+		if len(past) < opts.MinSamples {
+			eval.Skipped = fmt.Sprintf("%d of %d samples needed", len(past), opts.MinSamples)
+			return eval
+		}
+		var center, spread float64
+		if rule.Condition == ConditionZScore {
+			center, spread = meanStdDev(past)
+		} else {
+			center, spread = ewma(past, opts.Alpha)
+		}
+		eval.Observed = deviation(last.Value, center, spread)
+	}
+	eval.Breached, _ = compare(rule.Operator, eval.Observed, rule.Value)
+	return eval
+}
+
+// Engine keeps the state of every alert between runs. It is safe for concurrent use.
+type Engine struct {
+	mu     sync.Mutex
+	path   string
+	states map[string]*State
+}
+
+// NewEngine loads the alert state from path, DefaultStatePath when empty. A missing file
+// starts with no alerts firing.
+func NewEngine(path string) (*Engine, error) {
+	if path == "" {
+		path = DefaultStatePath()
+	}
+	e := &Engine{path: path, states: map[string]*State{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return e, nil
+		}
+		return nil, fmt.Errorf("failed to read alert state: %w", err)
+	}
+	var states []*State
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("failed to decode alert state: %w", err)
+	}
+	for _, s := range states {
+		e.states[stateKey(s.Repository, s.Rule)] = s
+	}
+	return e, nil
+}
+
+// Process returns the notifications to send for evaluations: a newly breached rule fires, a
+// rule still breached after its cooldown repeats and a firing rule no longer breached
+// resolves. It only records the observed values; the alert states change when the events are
+// reported as delivered (see Delivered), so an undelivered notification is sent again on the
+// next run. Skipped evaluations leave the state untouched.
+func (e *Engine) Process(evals []Evaluation, now time.Time) []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var events []Event
+	for _, eval := range evals {
+		if eval.Skipped != "" {
+			continue
+		}
+		key := stateKey(eval.Repository, eval.Rule.Name)
+		state, ok := e.states[key]
+		if !ok {
+			state = &State{Repository: eval.Repository, Rule: eval.Rule.Name}
+			e.states[key] = state
+		}
+		state.LastObserved = finite(eval.Observed)
+
+		switch {
+		case eval.Breached && !state.Firing:
+			events = append(events, Event{Kind: EventFiring, Evaluation: eval, Since: now})
+		case eval.Breached && now.Sub(state.LastNotified) >= eval.Rule.Cooldown:
+			events = append(events, Event{Kind: EventRepeat, Evaluation: eval, Since: state.Since})
+		case !eval.Breached && state.Firing:
+			events = append(events, Event{Kind: EventResolved, Evaluation: eval, Since: state.Since})
+		}
+	}
+	return events
+}
+
+// Delivered applies the events that were sent to the alert states: a firing event starts
+// the alert, a repeat restarts its cooldown and a resolution ends it
+func (e *Engine) Delivered(events []Event, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, ev := range events {
+		key := stateKey(ev.Evaluation.Repository, ev.Evaluation.Rule.Name)
+		state, ok := e.states[key]
+		if !ok {
+			state = &State{Repository: ev.Evaluation.Repository, Rule: ev.Evaluation.Rule.Name}
+			e.states[key] = state
+		}
+		switch ev.Kind {
+		case EventFiring:
+			state.Firing = true
+			state.Since = ev.Since
+			state.LastNotified = now
+			state.Notifications++
+		case EventRepeat:
+			state.LastNotified = now
+			state.Notifications++
+		case EventResolved:
+			state.Firing = false
+			state.ResolvedAt = now
+		}
+	}
+}
+
+// States lists the alert states, firing first
+func (e *Engine) States() []State {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	states := make([]State, 0, len(e.states))
+	for _, s := range e.states {
+		states = append(states, *s)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Firing != states[j].Firing {
+			return states[i].Firing
+		}
+		if states[i].Repository != states[j].Repository {
+			return states[i].Repository < states[j].Repository
+		}
+		return states[i].Rule < states[j].Rule
+	})
+	return states
+}
+
+// Save writes the alert state atomically
+func (e *Engine) Save() error {
+	data, err := json.MarshalIndent(e.States(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode alert state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(e.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create alert state directory: %w", err)
+	}
+	tmp := e.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write alert state: %w", err)
+	}
+	if err := os.Rename(tmp, e.path); err != nil {
+		return fmt.Errorf("failed to replace alert state: %w", err)
+	}
+	return nil
+}
+
+// Notify sends every event through the notifiers. A failed notifier does not stop the others.
+func Notify(ctx context.Context, notifiers []interfaces.INotifier, events []Event) error {
+	_, err := Deliver(ctx, notifiers, events)
+	return err
+}
+
+// Deliver sends every event through the notifiers and returns the events every notifier
+// accepted, to be passed to Engine.Delivered. A failed notifier does not stop the others.
+func Deliver(ctx context.Context, notifiers []interfaces.INotifier, events []Event) ([]Event, error) {
+	var delivered []Event
+	var failures []string
+	for _, ev := range events {
+		title, text := FormatEvent(ev)
+		ok := true
+		for _, n := range notifiers {
+			if err := n.Send(ctx, title, text); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", n.GetType(), err))
+				ok = false
+			}
+		}
+		if ok {
+			delivered = append(delivered, ev)
+		}
+	}
+	if len(failures) > 0 {
+		return delivered, fmt.Errorf("failed to send %d notifications: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return delivered, nil
+}
+
+// FormatEvent renders the title and text of a notification
+func FormatEvent(ev Event) (title, text string) {
+	eval := ev.Evaluation
+	rule := eval.Rule
+	switch ev.Kind {
+	case EventResolved:
+		title = fmt.Sprintf("✅ RESOLVED %s: %s", eval.Repository, rule.Name)
+	case EventRepeat:
+		title = fmt.Sprintf("%s STILL FIRING %s: %s", severityIcon(rule.Severity), eval.Repository, rule.Name)
+	default:
+		title = fmt.Sprintf("%s %s %s: %s", severityIcon(rule.Severity), strings.ToUpper(rule.Severity), eval.Repository, rule.Name)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Metric %s is %s", rule.Metric, formatFloat(eval.Latest))
+	switch rule.Condition {
+	case ConditionWeekOverWeek:
+		fmt.Fprintf(&b, ", %s week over week", signed(eval.Observed))
+	case ConditionZScore, ConditionEWMA:
+		fmt.Fprintf(&b, ", %s standard deviations from its %s", signed(eval.Observed), centerName(rule.Condition))
+	}
+	fmt.Fprintf(&b, " (rule: %s %s %s).\n", conditionName(rule.Condition), rule.Operator, formatFloat(rule.Value))
+	if !ev.Since.IsZero() {
+		fmt.Fprintf(&b, "Firing since %s.\n", ev.Since.Format(time.RFC3339))
+	}
+	if !eval.At.IsZero() {
+		fmt.Fprintf(&b, "Latest sample at %s.\n", eval.At.Format(time.RFC3339))
+	}
+	return title, b.String()
+}
+
+// compare applies an operator, reporting whether the operator is known
+func compare(operator string, observed, value float64) (breached, ok bool) {
+	switch operator {
+	case ">":
+		return observed > value, true
+	case ">=":
+		return observed >= value, true
+	case "<":
+		return observed < value, true
+	case "<=":
+		return observed <= value, true
+	}
+	return false, false
+}
+
+// finite returns v, or nil when JSON cannot encode it (±Inf from a flat history, NaN)
+func finite(v float64) *float64 {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+// deviation is how many spreads value is from center; a flat history makes any change infinite
+func deviation(value, center, spread float64) float64 {
+	if spread < 1e-9 {
+		switch {
+		case value > center:
+			return math.Inf(1)
+		case value < center:
+			return math.Inf(-1)
+		}
+		return 0
+	}
+	return (value - center) / spread
+}
@@ -0,0 +1,39 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	rt "github.com/kubex-ecosystem/ghbex/internal/runtime"
+)
+
+// OperatorNotify is the runtime name of a plain notification
+const OperatorNotify = "alerting.notify"
+
+// Register adds the notification operator to a runtime registry. It sends the title and text
+// params through the configured notifiers; a dry run only reports who would be notified.
+func Register(reg rt.Registry, cfg interfaces.IMainConfig) {
+	reg.Register(rt.NewOperator(OperatorNotify, "1.0.0", func(ctx context.Context, in rt.OpInput) (rt.OpOutput, error) {
+		title := rt.ParamString(in.Params, "title", fmt.Sprintf("ghbex: %s/%s", in.Repo.Owner, in.Repo.Name))
+		text := rt.ParamString(in.Params, "text", "")
+		var notifiers []interfaces.INotifier
+		if cfg != nil && cfg.GetNotifiers() != nil {
+			notifiers = cfg.GetNotifiers().GetNotifiers()
+		}
+		out := rt.OpOutput{Metrics: []rt.Metric{{Name: "notifiers", Value: float64(len(notifiers)), Unit: "count"}}}
+		if in.DryRun {
+			out.Insights = append(out.Insights, rt.Insight{
+				Key:     "dry_run",
+				Summary: fmt.Sprintf("would notify %d notifiers: %s", len(notifiers), title),
+			})
+			return out, nil
+		}
+		for _, n := range notifiers {
+			if err := n.Send(ctx, title, text); err != nil {
+				return out, fmt.Errorf("failed to notify through %s: %w", n.GetType(), err)
+			}
+		}
+		return out, nil
+	}))
+}
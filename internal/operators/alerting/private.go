@@ -0,0 +1,92 @@
+package alerting
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+)
+
+func stateKey(repository, rule string) string {
+	return repository + "\x00" + rule
+}
+
+// pastValues are up to window samples before the latest one
+func pastValues(samples []history.Sample, window int) []float64 {
+	past := samples[:len(samples)-1]
+	if window > 0 && len(past) > window {
+		past = past[len(past)-window:]
+	}
+	values := make([]float64, len(past))
+	for i, s := range past {
+		values[i] = s.Value
+	}
+	return values
+}
+
+func meanStdDev(values []float64) (mean, stddev float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		stddev += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(stddev / float64(len(values)))
+}
+
+// ewma is the exponentially weighted mean of values and the matching weighted standard deviation
+func ewma(values []float64, alpha float64) (mean, stddev float64) {
+	mean = values[0]
+	variance := 0.0
+	for _, v := range values[1:] {
+		diff := v - mean
+		incr := alpha * diff
+		mean += incr
+		variance = (1 - alpha) * (variance + diff*incr)
+	}
+	return mean, math.Sqrt(variance)
+}
+
+func severityIcon(severity string) string {
+	switch severity {
+	case SeverityCritical:
+		return "🚨"
+	case SeverityInfo:
+		return "ℹ️"
+	}
+	return "⚠️"
+}
+
+func conditionName(condition string) string {
+	switch condition {
+	case ConditionWeekOverWeek:
+		return "week over week"
+	case ConditionZScore:
+		return "z-score"
+	case ConditionEWMA:
+		return "EWMA deviation"
+	}
+	return "value"
+}
+
+func centerName(condition string) string {
+	if condition == ConditionEWMA {
+		return "EWMA"
+	}
+	return "mean"
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 0) {
+		return "∞"
+	}
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func signed(v float64) string {
+	if v >= 0 || math.IsInf(v, 1) {
+		return "+" + formatFloat(math.Abs(v))
+	}
+	return "-" + formatFloat(math.Abs(v))
+}
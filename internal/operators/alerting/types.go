@@ -0,0 +1,81 @@
+package alerting
+
+import (
+	"time"
+)
+
+// Conditions
+const (
+	ConditionThreshold    = "threshold"
+	ConditionWeekOverWeek = "week_over_week"
+	ConditionZScore       = "zscore"
+	ConditionEWMA         = "ewma"
+)
+
+// Severities
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// Event kinds
+const (
+	EventFiring   = "firing"
+	EventRepeat   = "repeat"
+	EventResolved = "resolved"
+)
+
+// Options tunes the statistical conditions and the rule defaults
+type Options struct {
+	// Alpha is the smoothing factor of the EWMA condition
+	Alpha float64
+	// MinSamples is the history needed by the zscore and ewma conditions, besides the latest sample
+	MinSamples int
+	// Window is the default number of samples of the zscore and ewma conditions
+	Window int
+	// Cooldown is the default time between repeated notifications of a firing alert
+	Cooldown time.Duration
+}
+
+// Rule is a validated alert rule with its defaults applied
+type Rule struct {
+	Name      string        `json:"name"`
+	Metric    string        `json:"metric"`
+	Condition string        `json:"condition"`
+	Operator  string        `json:"operator"`
+	Value     float64       `json:"value"`
+	Window    int           `json:"window"`
+	Cooldown  time.Duration `json:"cooldown"`
+	Severity  string        `json:"severity"`
+}
+
+// Evaluation is the outcome of one rule against the history of one repository
+type Evaluation struct {
+	Repository string    `json:"repository"`
+	Rule       Rule      `json:"rule"`
+	At         time.Time `json:"at"`       // Time of the latest sample
+	Latest     float64   `json:"latest"`   // Latest value of the metric
+	Observed   float64   `json:"observed"` // Value compared by the rule: the latest value, the delta or the deviation
+	Breached   bool      `json:"breached"`
+	Skipped    string    `json:"skipped,omitempty"` // Why the rule could not be evaluated
+}
+
+// State is the persisted state of one alert, used for deduplication, cooldown and resolution
+type State struct {
+	Repository    string    `json:"repository"`
+	Rule          string    `json:"rule"`
+	Firing        bool      `json:"firing"`
+	Since         time.Time `json:"since,omitempty"`
+	LastNotified  time.Time `json:"last_notified,omitempty"`
+	LastObserved  *float64  `json:"last_observed"` // Null when the observed value was not finite (a change over a flat history)
+	Notifications int       `json:"notifications"`
+	ResolvedAt    time.Time `json:"resolved_at,omitempty"`
+}
+
+// Event is a notification produced by the engine
+type Event struct {
+	Kind       string     `json:"kind"`
+	Evaluation Evaluation `json:"evaluation"`
+	Since      time.Time  `json:"since"`
+}
@@ -7,10 +7,22 @@ import (
 	"time"
 
 	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/integrity"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/licenses"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/ownership"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
 )
 
-// AnalyzeRepository performs comprehensive repository analysis
+// AnalyzeRepository performs comprehensive repository analysis, checking dependency
+// licenses against the policy from the environment
 func AnalyzeRepository(ctx context.Context, client *github.Client, owner, repo string, analysisDays int) (*InsightsReport, error) {
+	return AnalyzeRepositoryWithPolicy(ctx, client, owner, repo, analysisDays, licenses.DefaultPolicy())
+}
+
+// AnalyzeRepositoryWithPolicy performs comprehensive repository analysis, checking
+// dependency licenses against the given policy (see licenses.PolicyForRepository)
+func AnalyzeRepositoryWithPolicy(ctx context.Context, client *github.Client, owner, repo string, analysisDays int, policy licenses.Policy) (*InsightsReport, error) {
 	if analysisDays <= 0 {
 		analysisDays = 90 // Default to 90 days
 	}
@@ -33,7 +45,7 @@ func AnalyzeRepository(ctx context.Context, client *github.Client, owner, repo s
 	report.DevPatterns = devPatterns
 
 	// Analyze code intelligence
-	codeIntel, err := analyzeCodeIntelligence(ctx, client, owner, repo)
+	codeIntel, err := analyzeCodeIntelligence(ctx, client, owner, repo, policy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze code intelligence: %w", err)
 	}
@@ -50,6 +62,22 @@ func AnalyzeRepository(ctx context.Context, client *github.Client, owner, repo s
 	}
 	report.Community = community
 
+	// Analyze file ownership (bus factor, knowledge silos, departed owners)
+	risk, err := ownership.Analyze(ctx, client, owner, repo, ownership.DefaultOptions())
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to analyze ownership for %s/%s: %v", owner, repo, err))
+	} else {
+		report.Risk = risk
+	}
+
+	// Audit tags and releases (semver, signatures, reachability, checksums)
+	releaseIntegrity, err := integrity.Audit(ctx, client, owner, repo, integrity.DefaultOptions())
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to audit releases for %s/%s: %v", owner, repo, err))
+	} else {
+		report.ReleaseIntegrity = releaseIntegrity
+	}
+
 	// Calculate productivity metrics
 	productivity := calculateProductivityMetrics(devPatterns, community)
 	report.Productivity = productivity
@@ -0,0 +1,34 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	rt "github.com/kubex-ecosystem/ghbex/internal/runtime"
+)
+
+// OperatorInsights is the runtime name of the full repository analysis
+const OperatorInsights = "analytics.insights"
+
+// Register adds the repository analysis to a runtime registry. It reads analysis_days from
+// the params and needs a *github.Client in the GitHub client bundle; the report is returned
+// as the insights.json artifact.
+func Register(reg rt.Registry) {
+	reg.Register(rt.NewOperator(OperatorInsights, "1.0.0", func(ctx context.Context, in rt.OpInput) (rt.OpOutput, error) {
+		cli, ok := rt.GitHubClient(in)
+		if !ok {
+			return rt.OpOutput{}, fmt.Errorf("%s needs a GitHub client", OperatorInsights)
+		}
+		report, err := AnalyzeRepository(ctx, cli, in.Repo.Owner, in.Repo.Name, rt.ParamInt(in.Params, "analysis_days", 90))
+		if err != nil {
+			return rt.OpOutput{}, err
+		}
+		data, _ := json.MarshalIndent(report, "", "  ")
+		out := rt.OpOutput{Data: report, Artifacts: map[string][]byte{"insights.json": data}}
+		if report.HealthScore != nil {
+			out.Metrics = append(out.Metrics, rt.Metric{Name: "health", Value: report.HealthScore.Overall, Unit: "score"})
+		}
+		return out, nil
+	}))
+}
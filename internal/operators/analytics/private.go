@@ -10,7 +10,15 @@ import (
 
 	"github.com/google/go-github/v61/github"
 
+	"github.com/kubex-ecosystem/ghbex/internal/identity"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
 	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/codestats"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/conventional"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/deps"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/licenses"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/security"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/worktime"
 )
 
 // analyzeDevelopmentPatterns analyzes commit patterns and development habits
@@ -47,7 +55,7 @@ func analyzeDevelopmentPatterns(ctx context.Context, client *github.Client, owne
 	timeDistribution := analyzeTimeDistribution(commits)
 
 	// Analyze commit types
-	commitTypes := analyzeCommitTypes(commits)
+	commitTypes, compliance := analyzeCommitTypes(commits)
 
 	// Calculate average commit size
 	avgCommitSize := calculateAverageCommitSize(commits)
@@ -61,6 +69,7 @@ func analyzeDevelopmentPatterns(ctx context.Context, client *github.Client, owne
 		CommitTypes:       commitTypes,
 		AverageCommitSize: avgCommitSize,
 		BranchingStrategy: branchingStrategy,
+		Conventional:      &compliance,
 	}, nil
 }
 
@@ -191,38 +200,50 @@ func analyzeTimeDistribution(commits []*github.RepositoryCommit) *TimeDistributi
 	}
 }
 
-// analyzeCommitTypes categorizes commits by their message
-func analyzeCommitTypes(commits []*github.RepositoryCommit) map[string]int {
+// commitTypeBuckets maps conventional types to the keys reported in CommitTypes
+var commitTypeBuckets = map[string]string{
+	"feat": "feature",
+	"fix":  "bugfix",
+	"docs": "documentation",
+}
+
+// analyzeCommitTypes categorizes commits by their conventional type and
+// measures the convention compliance of the same commits
+func analyzeCommitTypes(commits []*github.RepositoryCommit) (map[string]int, conventional.Compliance) {
 	types := make(map[string]int)
+	parsed := make([]conventional.Commit, 0, len(commits))
 
 	for _, commit := range commits {
-		if commit.Commit != nil && commit.Commit.Message != nil {
-			message := strings.ToLower(*commit.Commit.Message)
-
-			// Conventional commit types
-			if strings.HasPrefix(message, "feat") {
-				types["feature"]++
-			} else if strings.HasPrefix(message, "fix") {
-				types["bugfix"]++
-			} else if strings.HasPrefix(message, "docs") {
-				types["documentation"]++
-			} else if strings.HasPrefix(message, "style") {
-				types["style"]++
-			} else if strings.HasPrefix(message, "refactor") {
-				types["refactor"]++
-			} else if strings.HasPrefix(message, "test") {
-				types["test"]++
-			} else if strings.HasPrefix(message, "chore") {
-				types["chore"]++
-			} else if strings.Contains(message, "merge") {
-				types["merge"]++
+		if commit.Commit == nil || commit.Commit.Message == nil {
+			continue
+		}
+		c := conventional.Parse(commit.GetCommit().GetMessage())
+		c.SHA = commit.GetSHA()
+		c.Author = commit.GetAuthor().GetLogin()
+		if c.Author == "" {
+			c.Author = commit.GetCommit().GetAuthor().GetName()
+		}
+		if len(commit.Parents) > 1 {
+			c.Merge = true
+			c.Valid = false
+		}
+		parsed = append(parsed, c)
+
+		switch {
+		case c.Merge:
+			types["merge"]++
+		case c.Valid:
+			if bucket, ok := commitTypeBuckets[c.Type]; ok {
+				types[bucket]++
 			} else {
-				types["other"]++
+				types[c.Type]++
 			}
+		default:
+			types["other"]++
 		}
 	}
 
-	return types
+	return types, conventional.ComputeCompliance(parsed)
 }
 
 // calculateAverageCommitSize calculates average lines changed per commit
@@ -293,8 +314,8 @@ func determineBranchingStrategy(ctx context.Context, client *github.Client, owne
 	}
 }
 
-// analyzeCodeIntelligence performs code analysis
-func analyzeCodeIntelligence(ctx context.Context, client *github.Client, owner, repo string) (*CodeIntelligence, error) {
+// analyzeCodeIntelligence performs code analysis, checking dependency licenses against policy
+func analyzeCodeIntelligence(ctx context.Context, client *github.Client, owner, repo string, policy licenses.Policy) (*CodeIntelligence, error) {
 	// Get languages
 	languages, _, err := client.Repositories.ListLanguages(ctx, owner, repo)
 	if err != nil {
@@ -327,17 +348,20 @@ func analyzeCodeIntelligence(ctx context.Context, client *github.Client, owner,
 		CodeDuplication:      estimateCodeDuplication(languages),
 		TechnicalDebt:        assessTechnicalDebt(languages),
 		MaintainabilityIndex: calculateMaintainabilityIndex(languages),
+		Source:               "estimate",
 	}
 
 	// Analyze dependencies (simplified)
 	dependencies := &DependencyAnalysis{
 		TotalDependencies: estimateDependencies(primaryLanguage),
 		OutdatedCount:     0, // Would require package file analysis
-		VulnerableCount:   0, // Would require security scanning
-		LicenseIssues:     0, // Would require license scanning
+		VulnerableCount:   0,
+		LicenseIssues:     0, // Filled by applyDependencyInventory
 		DependencyHealth:  calculateDependencyHealth(primaryLanguage, total),
 		CriticalUpdates:   []string{},
 	}
+	applyDependencyInventory(ctx, client, owner, repo, policy, dependencies)
+	applySecurityAlerts(ctx, client, owner, repo, dependencies)
 
 	// Analyze file types
 	fileTypes := analyzeFileTypes(languages)
@@ -352,6 +376,9 @@ func analyzeCodeIntelligence(ctx context.Context, client *github.Client, owner,
 		GrowthRate: 0.0, // Would require historical analysis
 	}
 
+	// Replace the estimates with real metrics from a source snapshot when possible
+	applyCodeStats(ctx, client, owner, repo, complexity, loc)
+
 	return &CodeIntelligence{
 		Languages:       langPercentages,
 		PrimaryLanguage: primaryLanguage,
@@ -362,6 +389,131 @@ func analyzeCodeIntelligence(ctx context.Context, client *github.Client, owner,
 	}, nil
 }
 
+// applyCodeStats analyzes the repository tarball and overwrites the complexity and LOC estimates
+func applyCodeStats(ctx context.Context, client *github.Client, owner, repo string, complexity *ComplexityMetrics, loc *LOCAnalysis) {
+	stats, err := codestats.AnalyzeSnapshot(ctx, client, owner, repo, codestats.DefaultOptions())
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to analyze source snapshot for %s/%s, using estimates: %v", owner, repo, err))
+		return
+	}
+	if stats.Lines.Total == 0 {
+		return
+	}
+
+	loc.Total = stats.Lines.Total
+	loc.Code = stats.Lines.Code
+	loc.Comments = stats.Lines.Comments
+	loc.Blank = stats.Lines.Blank
+	loc.ByLanguage = make(map[string]int, len(stats.Languages))
+	for lang, ls := range stats.Languages {
+		loc.ByLanguage[lang] = ls.Lines.Code
+	}
+
+	if stats.Functions == 0 {
+		return
+	}
+	complexity.CyclomaticComplexity = stats.CyclomaticAvg
+	complexity.CodeDuplication = stats.DuplicationPct
+	complexity.MaintainabilityIndex = stats.MaintainabilityIndex
+	complexity.CodeHealthIndex = stats.CHI
+	complexity.TechnicalDebt = technicalDebtLevel(stats.CyclomaticAvg)
+	complexity.Source = "snapshot"
+}
+
+// applySecurityAlerts replaces the dependency estimates with real alert data when available
+func applySecurityAlerts(ctx context.Context, client *github.Client, owner, repo string, deps *DependencyAnalysis) {
+	alerts, err := security.CollectSecurityAlerts(ctx, client, owner, repo)
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to collect security alerts for %s/%s: %v", owner, repo, err))
+		return
+	}
+
+	for _, source := range []*security.AlertSummary{alerts.CodeScanning, alerts.SecretScanning} {
+		if !source.Available {
+			continue
+		}
+		if deps.ScanningBySeverity == nil {
+			deps.ScanningBySeverity = make(map[string]int)
+		}
+		for sev, count := range source.BySeverity {
+			deps.ScanningBySeverity[sev] += count
+		}
+	}
+	if alerts.CodeScanning.Available {
+		deps.CodeScanningOpen = alerts.CodeScanning.Open
+	}
+	if alerts.SecretScanning.Available {
+		deps.SecretScanningOpen = alerts.SecretScanning.Open
+	}
+	if !alerts.Dependabot.Available && !alerts.CodeScanning.Available && !alerts.SecretScanning.Available {
+		return
+	}
+
+	// the offline inventory may already have findings: keep the larger count of each source
+	if alerts.Dependabot.Open > deps.VulnerableCount {
+		deps.VulnerableCount = alerts.Dependabot.Open
+	}
+	if deps.VulnerableBySeverity == nil {
+		deps.VulnerableBySeverity = make(map[string]int)
+	}
+	for sev, count := range alerts.Dependabot.BySeverity {
+		if count > deps.VulnerableBySeverity[sev] {
+			deps.VulnerableBySeverity[sev] = count
+		}
+	}
+	deps.RemediationMTTRHours = alerts.MTTRHours
+	deps.CriticalUpdates = appendMissing(deps.CriticalUpdates, alerts.CriticalFixes...)
+	deps.DependencyHealth = metrics.ComputeDepsHealth(deps.VulnerableBySeverity, deps.OutdatedCount, alerts.MTTRHours)
+}
+
+// applyDependencyInventory replaces the dependency estimate with the parsed manifests
+// and matches them against the offline OSV database, when one is installed
+func applyDependencyInventory(ctx context.Context, client *github.Client, owner, repo string, policy licenses.Policy, analysis *DependencyAnalysis) {
+	db, err := deps.DefaultDatabase()
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to load OSV database: %v", err))
+	}
+
+	inventory, err := deps.AnalyzeRepository(ctx, client, owner, repo, db)
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to analyze dependency manifests for %s/%s, using estimates: %v", owner, repo, err))
+		return
+	}
+	if len(inventory.Manifests) == 0 {
+		return
+	}
+
+	analysis.TotalDependencies = len(inventory.Dependencies)
+
+	licenses.ResolveLicenses(ctx, client, inventory.Dependencies)
+	analysis.LicenseIssues = licenses.Evaluate(inventory.Dependencies, policy).Issues()
+
+	if !inventory.DatabaseLoaded {
+		return
+	}
+	analysis.VulnerableCount = len(inventory.Vulnerable)
+	analysis.VulnerableBySeverity = inventory.BySeverity
+	analysis.CriticalUpdates = appendMissing(analysis.CriticalUpdates, inventory.CriticalUpdates()...)
+	analysis.DependencyHealth = metrics.ComputeDepsHealth(inventory.BySeverity, analysis.OutdatedCount, 0)
+}
+
+// appendMissing appends the values not already present in list
+func appendMissing(list []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 // Helper functions for code intelligence
 func calculateCyclomaticComplexity(languages map[string]int) float64 {
 	// Simplified estimation based on language complexity
@@ -414,8 +566,11 @@ func estimateCodeDuplication(languages map[string]int) float64 {
 }
 
 func assessTechnicalDebt(languages map[string]int) string {
-	complexity := calculateCyclomaticComplexity(languages)
+	return technicalDebtLevel(calculateCyclomaticComplexity(languages))
+}
 
+// technicalDebtLevel classifies an average cyclomatic complexity
+func technicalDebtLevel(complexity float64) string {
 	if complexity < 2.5 {
 		return "low"
 	} else if complexity < 3.5 {
@@ -508,8 +663,11 @@ func calculateHealthScore(devPatterns *DevelopmentPatterns, codeIntel *CodeIntel
 
 	// Code quality score (0-25 points)
 	if codeIntel.Complexity != nil {
-		maintainability := codeIntel.Complexity.MaintainabilityIndex
-		scores["code_quality"] = maintainability * 0.25
+		quality := codeIntel.Complexity.MaintainabilityIndex
+		if codeIntel.Complexity.CodeHealthIndex > 0 {
+			quality = codeIntel.Complexity.CodeHealthIndex
+		}
+		scores["code_quality"] = quality * 0.25
 	}
 
 	// Diversity score (0-25 points)
@@ -583,6 +741,10 @@ func analyzeCommunityInsights(ctx context.Context, client *github.Client, owner,
 		return nil, fmt.Errorf("failed to fetch contributors: %w", err)
 	}
 
+	// Merge aliases of the same person and drop bots before any people metric
+	people := identity.ForRepository(ctx, client, owner, repo)
+	contributors = mergeContributors(people, contributors)
+
 	// Analyze contributors
 	contributorAnalysis := analyzeContributors(contributors, since)
 
@@ -595,19 +757,35 @@ func analyzeCommunityInsights(ctx context.Context, client *github.Client, owner,
 	// Analyze growth metrics
 	growth := analyzeGrowthMetrics(repository)
 
-	// Calculate collaboration metrics (simplified)
-	collaboration := &CollaborationMetrics{
-		PRReviewRate:      75.0, // Would require PR analysis
-		AverageReviewTime: 24.0, // Hours
-		IssueResponseTime: 12.0, // Hours
-		CrossTeamCommits:  len(contributors) / 2,
+	// Calculate collaboration metrics from merged PR reviews and issue comments
+	collaboration, err := ComputeReviewMetrics(ctx, client, owner, repo, since)
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to compute review metrics for %s/%s: %v", owner, repo, err))
+		collaboration = &CollaborationMetrics{}
 	}
+	collaboration.CrossTeamCommits = len(contributors) / 2
 
-	// Calculate diversity metrics (simplified)
+	// Calculate diversity metrics from the author offsets of the commits, estimating on failure
 	diversity := &DiversityMetrics{
 		TimezoneSpread:      estimateTimezoneSpread(len(contributors)),
 		GeographicDiversity: estimateGeographicDiversity(len(contributors)),
 		ContributionBalance: calculateContributionBalance(contributors),
+		Source:              "estimate",
+	}
+
+	opts := worktime.DefaultOptions()
+	opts.WindowDays = int(time.Since(since).Hours()/24) + 1
+	workPatterns, err := worktime.Analyze(ctx, client, owner, repo, opts)
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to analyze working hours for %s/%s, using estimates: %v", owner, repo, err))
+		workPatterns = nil
+	} else if workPatterns.CommitsAnalyzed > 0 {
+		diversity.TimezoneSpread = workPatterns.TimezoneSpread
+		diversity.GeographicDiversity = workPatterns.Diversity
+		diversity.WeekendPct = workPatterns.WeekendPct
+		diversity.AfterHoursPct = workPatterns.AfterHoursPct
+		diversity.FollowTheSun = workPatterns.FollowTheSun
+		diversity.Source = "commits"
 	}
 
 	return &CommunityInsights{
@@ -615,9 +793,45 @@ func analyzeCommunityInsights(ctx context.Context, client *github.Client, owner,
 		Collaboration: collaboration,
 		Growth:        growth,
 		Diversity:     diversity,
+		WorkPatterns:  workPatterns,
 	}, nil
 }
 
+// mergeContributors sums the contributions of logins resolving to the same identity,
+// drops bots when the resolver excludes them and keeps the list sorted by contributions
+func mergeContributors(people *identity.Resolver, contributors []*github.Contributor) []*github.Contributor {
+	merged := make(map[string]*github.Contributor)
+	order := make([]string, 0, len(contributors))
+	for _, c := range contributors {
+		if c.GetType() == "Bot" && people.ExcludesBots() {
+			continue
+		}
+		key, counted := people.Key(c.GetLogin(), c.GetName(), c.GetEmail())
+		if !counted {
+			continue
+		}
+		if m, ok := merged[key]; ok {
+			m.Contributions = github.Int(m.GetContributions() + c.GetContributions())
+			continue
+		}
+		merged[key] = &github.Contributor{
+			Login:         github.String(key),
+			Contributions: github.Int(c.GetContributions()),
+			Type:          c.Type,
+		}
+		order = append(order, key)
+	}
+
+	out := make([]*github.Contributor, 0, len(order))
+	for _, key := range order {
+		out = append(out, merged[key])
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].GetContributions() > out[j].GetContributions()
+	})
+	return out
+}
+
 func analyzeContributors(contributors []*github.Contributor, since time.Time) *ContributorAnalysis {
 	if len(contributors) == 0 {
 		return &ContributorAnalysis{}
@@ -0,0 +1,247 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/identity"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+const (
+	// maxReviewedPullRequests caps the merged pull requests whose reviews are listed
+	maxReviewedPullRequests = 200
+	// maxRespondedIssues caps the commented issues whose comments are listed
+	maxRespondedIssues = 200
+)
+
+// prReviewTimes holds the review timings of one merged pull request, in hours
+type prReviewTimes struct {
+	mergedAt    time.Time
+	firstReview float64 // -1 when no one but the author reviewed
+	approval    float64 // -1 when no non-author approval
+	cycle       float64
+}
+
+// ComputeReviewMetrics measures pull request review latency, review coverage, cycle time
+// and issue first-response time for the PRs merged and issues opened since the given time
+func ComputeReviewMetrics(ctx context.Context, client *github.Client, owner, repo string, since time.Time) (*CollaborationMetrics, error) {
+	pulls, err := listMergedPullRequestsSince(ctx, client, owner, repo, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged pull requests: %w", err)
+	}
+
+	people := identity.ForRepository(ctx, client, owner, repo)
+	collab := &CollaborationMetrics{PRsAnalyzed: len(pulls)}
+	times := make([]prReviewTimes, 0, len(pulls))
+	var firstReviews, approvals, cycles []float64
+	approved := 0
+
+	for _, pr := range pulls {
+		t, err := reviewTimes(ctx, client, people, owner, repo, pr)
+		if err != nil {
+			gl.Log("debug", fmt.Sprintf("Failed to list reviews of %s/%s#%d: %v", owner, repo, pr.GetNumber(), err))
+			continue
+		}
+		times = append(times, t)
+		cycles = append(cycles, t.cycle)
+		if t.firstReview >= 0 {
+			firstReviews = append(firstReviews, t.firstReview)
+		}
+		if t.approval >= 0 {
+			approvals = append(approvals, t.approval)
+			approved++
+		}
+	}
+
+	if len(times) > 0 {
+		collab.PRReviewRate = float64(approved) / float64(len(times)) * 100
+	}
+	collab.AverageReviewTime = metrics.Mean(firstReviews)
+	collab.FirstReviewP50 = metrics.Percentile(firstReviews, 50)
+	collab.FirstReviewP90 = metrics.Percentile(firstReviews, 90)
+	collab.ApprovalP50 = metrics.Percentile(approvals, 50)
+	collab.CycleTimeP50 = metrics.Percentile(cycles, 50)
+	collab.CycleTimeP90 = metrics.Percentile(cycles, 90)
+	collab.TrendFirstReview = weeklyTrend(times, since, func(t prReviewTimes) float64 { return t.firstReview })
+	collab.TrendCycleTime = weeklyTrend(times, since, func(t prReviewTimes) float64 { return t.cycle })
+
+	responses, err := issueResponseTimes(ctx, client, people, owner, repo, since)
+	if err != nil {
+		gl.Log("warn", fmt.Sprintf("Failed to measure issue response times for %s/%s: %v", owner, repo, err))
+	} else {
+		collab.IssuesAnalyzed = len(responses)
+		collab.IssueResponseTime = metrics.Percentile(responses, 50)
+		collab.IssueResponseP90 = metrics.Percentile(responses, 90)
+	}
+
+	return collab, nil
+}
+
+// listMergedPullRequestsSince lists the pull requests merged after since, newest first
+func listMergedPullRequestsSince(ctx context.Context, client *github.Client, owner, repo string, since time.Time) ([]*github.PullRequest, error) {
+	var pulls []*github.PullRequest
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		page, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range page {
+			if pr.MergedAt == nil || pr.GetMergedAt().Time.Before(since) {
+				continue
+			}
+			pulls = append(pulls, pr)
+			if len(pulls) >= maxReviewedPullRequests {
+				return pulls, nil
+			}
+		}
+		if len(page) == 0 || page[len(page)-1].GetUpdatedAt().Time.Before(since) || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return pulls, nil
+}
+
+// reviewTimes computes the first-review, approval and cycle times of a merged pull request.
+// Reviews by the author (under any alias) or by bots are ignored.
+func reviewTimes(ctx context.Context, client *github.Client, people *identity.Resolver, owner, repo string, pr *github.PullRequest) (prReviewTimes, error) {
+	created := pr.GetCreatedAt().Time
+	t := prReviewTimes{
+		mergedAt:    pr.GetMergedAt().Time,
+		firstReview: -1,
+		approval:    -1,
+		cycle:       pr.GetMergedAt().Time.Sub(created).Hours(),
+	}
+
+	author, _ := people.Key(pr.GetUser().GetLogin(), "", "")
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pr.GetNumber(), opts)
+		if err != nil {
+			return t, err
+		}
+		for _, review := range reviews {
+			reviewer, counted := people.Key(review.GetUser().GetLogin(), "", "")
+			if reviewer == author || !counted || review.GetState() == "PENDING" || review.SubmittedAt == nil {
+				continue
+			}
+			hours := review.GetSubmittedAt().Time.Sub(created).Hours()
+			if t.firstReview < 0 || hours < t.firstReview {
+				t.firstReview = hours
+			}
+			if review.GetState() == "APPROVED" && (t.approval < 0 || hours < t.approval) {
+				t.approval = hours
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return t, nil
+}
+
+// issueResponseTimes returns the hours until the first non-author, non-bot comment
+// of the issues opened since the given time, newest first and at most maxRespondedIssues
+// commented issues. Unanswered issues are not included.
+func issueResponseTimes(ctx context.Context, client *github.Client, people *identity.Resolver, owner, repo string, since time.Time) ([]float64, error) {
+	var issues []*github.Issue
+	opts := &github.IssueListByRepoOptions{
+		State:       "all",
+		Since:       since,
+		Sort:        "created",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for len(issues) < maxRespondedIssues {
+		page, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range page {
+			if issue.IsPullRequest() || issue.GetCreatedAt().Time.Before(since) || issue.GetComments() == 0 {
+				continue
+			}
+			if len(issues) < maxRespondedIssues {
+				issues = append(issues, issue)
+			}
+		}
+		if len(page) == 0 || page[len(page)-1].GetCreatedAt().Time.Before(since) || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var responses []float64
+	for _, issue := range issues {
+		comments, _, err := client.Issues.ListComments(ctx, owner, repo, issue.GetNumber(), &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{PerPage: 30},
+		})
+		if err != nil {
+			gl.Log("debug", fmt.Sprintf("Failed to list comments of %s/%s#%d: %v", owner, repo, issue.GetNumber(), err))
+			continue
+		}
+		author, _ := people.Key(issue.GetUser().GetLogin(), "", "")
+		for _, comment := range comments {
+			commenter, counted := people.Key(comment.GetUser().GetLogin(), "", "")
+			if commenter == author || !counted {
+				continue
+			}
+			responses = append(responses, comment.GetCreatedAt().Time.Sub(issue.GetCreatedAt().Time).Hours())
+			break
+		}
+	}
+	return responses, nil
+}
+
+// weeklyTrend buckets the pull requests by merge week and returns the median of each week,
+// oldest first, starting at the first week with data. Later weeks without data repeat the
+// previous value so the sparkline stays continuous.
+func weeklyTrend(times []prReviewTimes, since time.Time, value func(prReviewTimes) float64) []float64 {
+	weeks := int(time.Since(since).Hours()/(24*7)) + 1
+	if weeks <= 1 || len(times) == 0 {
+		return nil
+	}
+
+	buckets := make([][]float64, weeks)
+	for _, t := range times {
+		v := value(t)
+		if v < 0 {
+			continue
+		}
+		week := int(t.mergedAt.Sub(since).Hours() / (24 * 7))
+		if week < 0 || week >= weeks {
+			continue
+		}
+		buckets[week] = append(buckets[week], v)
+	}
+
+	first := 0
+	for first < weeks && len(buckets[first]) == 0 {
+		first++
+	}
+	if first == weeks {
+		return nil
+	}
+
+	trend := make([]float64, 0, weeks-first)
+	last := 0.0
+	for _, bucket := range buckets[first:] {
+		if len(bucket) > 0 {
+			last = metrics.Percentile(bucket, 50)
+		}
+		trend = append(trend, last)
+	}
+	return trend
+}
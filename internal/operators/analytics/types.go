@@ -1,6 +1,13 @@
 package analytics
 
-import "time"
+import (
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/operators/conventional"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/integrity"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/ownership"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/worktime"
+)
 
 // InsightsReport represents comprehensive repository analytics
 type InsightsReport struct {
@@ -24,6 +31,12 @@ type InsightsReport struct {
 	// Productivity Metrics
 	Productivity *ProductivityMetrics `json:"productivity_metrics"`
 
+	// Ownership risk: bus factor per path, knowledge silos and departed owners
+	Risk *ownership.Report `json:"risk,omitempty"`
+
+	// Tag hygiene and release integrity
+	ReleaseIntegrity *integrity.Report `json:"release_integrity,omitempty"`
+
 	// Recommendations
 	Recommendations []string `json:"recommendations"`
 }
@@ -35,6 +48,8 @@ type DevelopmentPatterns struct {
 	CommitTypes       map[string]int    `json:"commit_types"`
 	AverageCommitSize float64           `json:"average_commit_size"`
 	BranchingStrategy string            `json:"branching_strategy"`
+	// Conventional is the Conventional Commits compliance of the analyzed commits
+	Conventional *conventional.Compliance `json:"conventional,omitempty"`
 }
 
 // CommitFrequency represents commit frequency analysis
@@ -72,6 +87,8 @@ type ComplexityMetrics struct {
 	CodeDuplication      float64 `json:"code_duplication"`
 	TechnicalDebt        string  `json:"technical_debt"`
 	MaintainabilityIndex float64 `json:"maintainability_index"`
+	CodeHealthIndex      float64 `json:"code_health_index"`
+	Source               string  `json:"source"` // "snapshot" or "estimate"
 }
 
 // DependencyAnalysis checks dependencies health
@@ -82,6 +99,15 @@ type DependencyAnalysis struct {
 	LicenseIssues     int      `json:"license_issues"`
 	DependencyHealth  float64  `json:"dependency_health"`
 	CriticalUpdates   []string `json:"critical_updates"`
+
+	// Populated from Dependabot, code-scanning and secret-scanning alerts.
+	// VulnerableBySeverity breaks down the same findings as VulnerableCount (dependencies only);
+	// ScanningBySeverity breaks down the open code-scanning and secret-scanning alerts.
+	VulnerableBySeverity map[string]int `json:"vulnerable_by_severity,omitempty"`
+	ScanningBySeverity   map[string]int `json:"scanning_by_severity,omitempty"`
+	CodeScanningOpen     int            `json:"code_scanning_open"`
+	SecretScanningOpen   int            `json:"secret_scanning_open"`
+	RemediationMTTRHours float64        `json:"remediation_mttr_hours"`
 }
 
 // LOCAnalysis provides lines of code insights
@@ -108,6 +134,7 @@ type CommunityInsights struct {
 	Collaboration *CollaborationMetrics `json:"collaboration"`
 	Growth        *GrowthMetrics        `json:"growth"`
 	Diversity     *DiversityMetrics     `json:"diversity"`
+	WorkPatterns  *worktime.Report      `json:"work_patterns,omitempty"`
 }
 
 // ContributorAnalysis analyzes contributor patterns
@@ -131,10 +158,21 @@ type ContributorInfo struct {
 
 // CollaborationMetrics measures teamwork effectiveness
 type CollaborationMetrics struct {
-	PRReviewRate      float64 `json:"pr_review_rate"`
-	AverageReviewTime float64 `json:"average_review_time"`
-	IssueResponseTime float64 `json:"issue_response_time"`
+	PRReviewRate      float64 `json:"pr_review_rate"`      // % of merged PRs with a non-author approval
+	AverageReviewTime float64 `json:"average_review_time"` // Mean hours to first review
+	IssueResponseTime float64 `json:"issue_response_time"` // Median hours to first response
 	CrossTeamCommits  int     `json:"cross_team_commits"`
+
+	PRsAnalyzed      int       `json:"prs_analyzed"`
+	IssuesAnalyzed   int       `json:"issues_analyzed"`
+	FirstReviewP50   float64   `json:"first_review_p50"`
+	FirstReviewP90   float64   `json:"first_review_p90"`
+	ApprovalP50      float64   `json:"approval_p50"`
+	CycleTimeP50     float64   `json:"cycle_time_p50"`
+	CycleTimeP90     float64   `json:"cycle_time_p90"`
+	IssueResponseP90 float64   `json:"issue_response_p90"`
+	TrendFirstReview []float64 `json:"trend_first_review,omitempty"` // Weekly medians, oldest first
+	TrendCycleTime   []float64 `json:"trend_cycle_time,omitempty"`   // Weekly medians, oldest first
 }
 
 // GrowthMetrics tracks repository growth
@@ -158,6 +196,10 @@ type DiversityMetrics struct {
 	TimezoneSpread      int     `json:"timezone_spread"`
 	GeographicDiversity float64 `json:"geographic_diversity"`
 	ContributionBalance float64 `json:"contribution_balance"`
+	WeekendPct          float64 `json:"weekend_pct"`
+	AfterHoursPct       float64 `json:"after_hours_pct"`
+	FollowTheSun        float64 `json:"follow_the_sun"`
+	Source              string  `json:"source"` // "commits" when measured, "estimate" otherwise
 }
 
 // ProductivityMetrics measures development efficiency
@@ -0,0 +1,39 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
+	rt "github.com/kubex-ecosystem/ghbex/internal/runtime"
+)
+
+// OperatorCleanup is the runtime name of the artifact cleanup rule
+const OperatorCleanup = "artifacts.cleanup"
+
+// CleanupResult is the outcome of one cleanup run
+type CleanupResult struct {
+	Deleted int     `json:"deleted"`
+	IDs     []int64 `json:"ids"`
+	DryRun  bool    `json:"dry_run"`
+}
+
+// Register adds the artifact cleanup to a runtime registry. It reads max_age_days from the
+// params and needs a *github.Client in the GitHub client bundle.
+func Register(reg rt.Registry) {
+	reg.Register(rt.NewOperator(OperatorCleanup, "1.0.0", func(ctx context.Context, in rt.OpInput) (rt.OpOutput, error) {
+		cli, ok := rt.GitHubClient(in)
+		if !ok {
+			return rt.OpOutput{}, fmt.Errorf("%s needs a GitHub client", OperatorCleanup)
+		}
+		rule := gitz.NewArtifactsRuleType(rt.ParamInt(in.Params, "max_age_days", 30))
+		deleted, ids, err := CleanArtifacts(ctx, cli, in.Repo.Owner, in.Repo.Name, rule, in.DryRun)
+		if err != nil {
+			return rt.OpOutput{}, fmt.Errorf("failed to clean artifacts: %w", err)
+		}
+		return rt.OpOutput{
+			Data:    &CleanupResult{Deleted: deleted, IDs: ids, DryRun: in.DryRun},
+			Metrics: []rt.Metric{{Name: "artifacts_deleted", Value: float64(deleted), Unit: "count"}},
+		}, nil
+	}))
+}
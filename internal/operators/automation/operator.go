@@ -0,0 +1,47 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	rt "github.com/kubex-ecosystem/ghbex/internal/runtime"
+)
+
+// OperatorSanitize is the runtime name of the full repository sanitization
+const OperatorSanitize = "automation.sanitize"
+
+// Register adds the sanitization to a runtime registry. It applies the rules the configuration
+// holds for the repository and needs a *github.Client in the GitHub client bundle.
+func Register(reg rt.Registry, cfg interfaces.IMainConfig) {
+	reg.Register(rt.NewOperator(OperatorSanitize, "1.0.0", func(ctx context.Context, in rt.OpInput) (rt.OpOutput, error) {
+		cli, ok := rt.GitHubClient(in)
+		if !ok {
+			return rt.OpOutput{}, fmt.Errorf("%s needs a GitHub client", OperatorSanitize)
+		}
+		var rules interfaces.IRules
+		if cfg != nil && cfg.GetGitHub() != nil {
+			for _, rc := range cfg.GetGitHub().GetRepos() {
+				if strings.EqualFold(rc.GetOwner(), in.Repo.Owner) && strings.EqualFold(rc.GetName(), in.Repo.Name) {
+					rules = rc.GetRules()
+				}
+			}
+		}
+		if rules == nil {
+			return rt.OpOutput{}, fmt.Errorf("%s/%s has no configured rules", in.Repo.Owner, in.Repo.Name)
+		}
+		rpt, err := New(cli, cfg).SanitizeRepo(ctx, in.Repo.Owner, in.Repo.Name, rules, in.DryRun)
+		if err != nil {
+			return rt.OpOutput{}, fmt.Errorf("failed to sanitize %s/%s: %w", in.Repo.Owner, in.Repo.Name, err)
+		}
+		return rt.OpOutput{
+			Data: rpt,
+			Metrics: []rt.Metric{
+				{Name: "runs_deleted", Value: float64(rpt.Runs.Deleted), Unit: "count"},
+				{Name: "artifacts_deleted", Value: float64(rpt.Artifacts.Deleted), Unit: "count"},
+				{Name: "drafts_deleted", Value: float64(rpt.Releases.DeletedDrafts), Unit: "count"},
+			},
+		}, nil
+	}))
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/identity"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/codeowners"
 )
 
 func analyzeLabelManagement(ctx context.Context, client *github.Client, owner, repo string, report *AutomationReport) error {
@@ -804,6 +806,11 @@ func getAutoMergeReason(pr *github.PullRequest) string {
 
 func generateReviewerSuggestions(ctx context.Context, client *github.Client, owner, repo string, prs []*github.PullRequest) []ReviewerSuggestion {
 	var suggestions []ReviewerSuggestion
+	people := identity.ForRepository(ctx, client, owner, repo)
+
+	// CODEOWNERS decides who must review; the title heuristics are only a fallback.
+	// A failed lookup leaves owners nil, same as a repository without the file.
+	owners, _ := codeowners.Load(ctx, client, owner, repo, "")
 
 	// Intelligent reviewer suggestions based on PR characteristics and complexity
 	for _, pr := range prs {
@@ -827,8 +834,14 @@ func generateReviewerSuggestions(ctx context.Context, client *github.Client, own
 		title := strings.ToLower(pr.GetTitle())
 		daysOld := time.Since(pr.GetCreatedAt().Time).Hours() / 24
 
-		// Security-related changes need immediate maintainer review
-		if containsAny([]string{title}, []string{"security", "auth", "permission", "token", "credential"}) {
+		codeReviewers, ownedFiles := codeownersReviewers(ctx, client, owner, repo, owners, pr)
+
+		if len(codeReviewers) > 0 {
+			suggestedReviewers = codeReviewers
+			reason = fmt.Sprintf("👥 CODEOWNERS of %d changed files", ownedFiles)
+			confidence = 0.85
+		} else if containsAny([]string{title}, []string{"security", "auth", "permission", "token", "credential"}) {
+			// Security-related changes need immediate maintainer review
 			suggestedReviewers = []string{owner}
 			reason = "🔐 Security-related changes require maintainer review for safety"
 			confidence = 0.95
@@ -884,6 +897,9 @@ func generateReviewerSuggestions(ctx context.Context, client *github.Client, own
 			reason += " - large changeset needs thorough review"
 		}
 
+		// Never suggest the author (under any alias) or a bot
+		suggestedReviewers = filterReviewers(people, pr.GetUser().GetLogin(), suggestedReviewers)
+
 		// Only suggest if there's a meaningful recommendation
 		if len(suggestedReviewers) > 0 || confidence > 0.65 {
 			suggestions = append(suggestions, ReviewerSuggestion{
@@ -904,6 +920,43 @@ func generateReviewerSuggestions(ctx context.Context, client *github.Client, own
 	return suggestions
 }
 
+// codeownersReviewers ranks the CODEOWNERS of the files changed by a pull request
+func codeownersReviewers(ctx context.Context, client *github.Client, owner, repo string, owners *codeowners.File, pr *github.PullRequest) ([]string, int) {
+	if owners == nil {
+		return nil, 0
+	}
+	files, _, err := client.PullRequests.ListFiles(ctx, owner, repo, pr.GetNumber(), &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, 0
+	}
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.GetFilename())
+	}
+	reviewers, owned := owners.ReviewersFor(paths)
+	for i, r := range reviewers {
+		// Teams keep their @org/team form; users are plain logins like the other suggestions
+		if codeowners.OwnerKind(r) == codeowners.KindUser {
+			reviewers[i] = strings.TrimPrefix(r, "@")
+		}
+	}
+	return reviewers, owned
+}
+
+// filterReviewers removes the pull request author and bots from the suggested reviewers
+func filterReviewers(people *identity.Resolver, author string, reviewers []string) []string {
+	authorKey, _ := people.Key(author, "", "")
+	filtered := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		key, counted := people.Key(r, "", "")
+		if !counted || key == authorKey {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
 func calculateAverageDuration(durations []time.Duration) time.Duration {
 	if len(durations) == 0 {
 		return 0
@@ -4,6 +4,7 @@ package automation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,61 +14,168 @@ import (
 	"github.com/kubex-ecosystem/ghbex/internal/defs/common"
 	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
 	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
-	"github.com/kubex-ecosystem/ghbex/internal/defs/notifiers"
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
 	artifacts "github.com/kubex-ecosystem/ghbex/internal/operators/artifacts"
+	integrity "github.com/kubex-ecosystem/ghbex/internal/operators/integrity"
+	ownership "github.com/kubex-ecosystem/ghbex/internal/operators/ownership"
 	releases "github.com/kubex-ecosystem/ghbex/internal/operators/releases"
 	sanitize "github.com/kubex-ecosystem/ghbex/internal/operators/sanitize"
 	workflows "github.com/kubex-ecosystem/ghbex/internal/operators/workflows"
+	"github.com/kubex-ecosystem/ghbex/internal/state"
 )
 
 type Service struct {
 	cli       *github.Client
 	cfg       interfaces.IMainConfig
-	notifiers []*notifiers.Discord
+	notifiers []interfaces.INotifier
 }
 
-func New(cli *github.Client, cfg interfaces.IMainConfig, ntf ...*notifiers.Discord) *Service {
+func New(cli *github.Client, cfg interfaces.IMainConfig, ntf ...interfaces.INotifier) *Service {
 	return &Service{cli: cli, cfg: cfg, notifiers: ntf}
 }
 
+// SanitizeRepo runs every sanitization stage. A failed stage, notifier failures included, is
+// noted in the report and logged without stopping the next ones.
 func (s *Service) SanitizeRepo(ctx context.Context, owner, repo string, rules interfaces.IRules, dryRun bool) (*gitz.Report, error) {
-	rpt := &gitz.Report{Owner: owner, Repo: repo, When: time.Now(), DryRun: dryRun}
+	rpt, failures, err := s.sanitize(ctx, owner, repo, rules, dryRun, nil, nil)
+	for _, f := range failures {
+		gl.Log("warn", fmt.Sprintf("Sanitize %s/%s: %v", owner, repo, f))
+	}
+	return rpt, err
+}
 
-	d1, k1, ids1, err := workflows.CleanRuns(ctx, s.cli, owner, repo, rules.GetRunsRule(), dryRun)
+// SanitizeRepoResume runs the sanitization stages cp has not recorded as done, starting from
+// rpt, the report saved with the last checkpoint (a new report when nil). A stage that fails
+// or is interrupted by ctx is not checkpointed: with cp, its error is returned right away so
+// that the stage runs again on resume or retry; without cp, the failures are returned together.
+func (s *Service) SanitizeRepoResume(ctx context.Context, owner, repo string, rules interfaces.IRules, dryRun bool, rpt *gitz.Report, cp Checkpoint) (*gitz.Report, error) {
+	rpt, failures, err := s.sanitize(ctx, owner, repo, rules, dryRun, rpt, cp)
 	if err != nil {
-		rpt.Notes = append(rpt.Notes, "runs: "+err.Error())
+		return rpt, err
+	}
+	return rpt, errors.Join(failures...)
+}
+
+// sanitize runs the stages and returns the failures noted in the report apart from the error
+// that stopped the run (a cancelled ctx, or a failed stage when checkpointing).
+func (s *Service) sanitize(ctx context.Context, owner, repo string, rules interfaces.IRules, dryRun bool, rpt *gitz.Report, cp Checkpoint) (*gitz.Report, []error, error) {
+	if rpt == nil {
+		rpt = &gitz.Report{Owner: owner, Repo: repo, When: time.Now(), DryRun: dryRun}
+	}
+	var failures []error
+	stage := func(st state.Stage, run func() error) error {
+		if cp != nil && cp.Done(st) {
+			return nil
+		}
+		err := run()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			rpt.Notes = append(rpt.Notes, st.String()+": "+err.Error())
+			if cp != nil {
+				return fmt.Errorf("failed to run stage %s: %w", st, err)
+			}
+			failures = append(failures, fmt.Errorf("%s: %w", st, err))
+			return nil
+		}
+		if cp != nil {
+			return cp.Complete(st, rpt)
+		}
+		return nil
 	}
-	rpt.Runs.Deleted, rpt.Runs.Kept, rpt.Runs.IDs = d1, k1, ids1
 
-	d2, ids2, err := artifacts.CleanArtifacts(ctx, s.cli, owner, repo, rules.GetArtifactsRule(), dryRun)
+	err := stage(state.StageRunsCleanup, func() error {
+		d1, k1, ids1, err := workflows.CleanRuns(ctx, s.cli, owner, repo, rules.GetRunsRule(), dryRun)
+		rpt.Runs.Deleted, rpt.Runs.Kept, rpt.Runs.IDs = d1, k1, ids1
+		return err
+	})
 	if err != nil {
-		rpt.Notes = append(rpt.Notes, "artifacts: "+err.Error())
+		return rpt, failures, err
 	}
-	rpt.Artifacts.Deleted, rpt.Artifacts.IDs = d2, ids2
 
-	d3, tags, err := releases.CleanReleases(ctx, s.cli, owner, repo, rules.GetReleasesRule(), dryRun)
+	err = stage(state.StageArtifactsCleanup, func() error {
+		d2, ids2, err := artifacts.CleanArtifacts(ctx, s.cli, owner, repo, rules.GetArtifactsRule(), dryRun)
+		rpt.Artifacts.Deleted, rpt.Artifacts.IDs = d2, ids2
+		return err
+	})
 	if err != nil {
-		rpt.Notes = append(rpt.Notes, "releases: "+err.Error())
+		return rpt, failures, err
 	}
-	rpt.Releases.DeletedDrafts, rpt.Releases.Tags = d3, tags
 
-	// persist report
-	dir := filepath.Join(s.cfg.GetRuntime().GetReportDir(), time.Now().Format("2006-01-02"))
-	_ = os.MkdirAll(dir, 0o755)
+	err = stage(state.StageReleaseCleanup, func() error {
+		d3, tags, err := releases.CleanReleases(ctx, s.cli, owner, repo, rules.GetReleasesRule(), dryRun)
+		rpt.Releases.DeletedDrafts, rpt.Releases.Tags = d3, tags
+		if err != nil {
+			return err
+		}
 
-	jb, _ := json.MarshalIndent(rpt, "", "  ")
-	md := sanitize.ToMarkdown(rpt)
-	_ = os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s_%s.json", owner, repo)), jb, 0o644)
-	_ = os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s_%s.md", owner, repo)), []byte(md), 0o644)
+		// The audit is informational: its failure is noted without failing the stage
+		if rr := rules.GetReleasesRule(); rr != nil && rr.GetAudit() {
+			audit, err := integrity.Audit(ctx, s.cli, owner, repo, integrity.DefaultOptions())
+			if err != nil {
+				rpt.Notes = append(rpt.Notes, "releases audit: "+err.Error())
+			} else {
+				rpt.Releases.Audit = audit.ToReleaseAudit(20)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return rpt, failures, err
+	}
+
+	jb, md := []byte(nil), ""
+	err = stage(state.StageReportPersist, func() error {
+		// Like the audit, the risk analysis is informational
+		if monitoring := rules.GetMonitoringRule(); monitoring != nil && monitoring.GetCheckInactivity() {
+			risk, err := ownership.Analyze(ctx, s.cli, owner, repo, ownership.DefaultOptions())
+			if err != nil {
+				rpt.Notes = append(rpt.Notes, "risk: "+err.Error())
+			} else {
+				rpt.Risk = risk.ToRisk(15)
+			}
+		}
 
-	// notify
-	title := fmt.Sprintf("Repo sanitize: %s/%s (dry_run=%v)", owner, repo, dryRun)
-	for _, n := range s.notifiers {
-		_ = n.Send(ctx, title, md,
-			common.NewAttachment("report.json", jb),
-			common.NewAttachment("report.md", []byte(md)),
-		)
+		// persist report
+		dir := filepath.Join(s.cfg.GetRuntime().GetReportDir(), rpt.When.Format("2006-01-02"))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+		jb, _ = json.MarshalIndent(rpt, "", "  ")
+		md = sanitize.ToMarkdown(rpt)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s_%s.json", owner, repo)), jb, 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s_%s.md", owner, repo)), []byte(md), 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return rpt, failures, err
 	}
-	return rpt, nil
 
+	err = stage(state.StageNotify, func() error {
+		if jb == nil {
+			jb, _ = json.MarshalIndent(rpt, "", "  ")
+			md = sanitize.ToMarkdown(rpt)
+		}
+		title := fmt.Sprintf("Repo sanitize: %s/%s (dry_run=%v)", owner, repo, dryRun)
+		var errs []error
+		for _, n := range s.notifiers {
+			err := n.Send(ctx, title, md,
+				common.NewAttachment("report.json", jb),
+				common.NewAttachment("report.md", []byte(md)),
+			)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", n.GetType(), err))
+			}
+		}
+		return errors.Join(errs...)
+	})
+	if err != nil {
+		return rpt, failures, err
+	}
+	return rpt, failures, nil
 }
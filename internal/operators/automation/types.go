@@ -1,6 +1,19 @@
 package automation
 
-import "time"
+import (
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
+	"github.com/kubex-ecosystem/ghbex/internal/state"
+)
+
+// Checkpoint records the sanitization stages that already ran, so that a resumed
+// sanitization skips them. Complete receives the report with the results of every finished
+// stage.
+type Checkpoint interface {
+	Done(stage state.Stage) bool
+	Complete(stage state.Stage, rpt *gitz.Report) error
+}
 
 // AutomationReport contains the results of automation analysis and actions.
 type AutomationReport struct {
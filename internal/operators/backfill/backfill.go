@@ -0,0 +1,125 @@
+// Package backfill reconstructs past weekly snapshots of a repository (commits, pull request
+// flow, issue flow, releases and DORA) from historical API data in a single pass, and writes
+// them into the history store as if ghbex had been running all along.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/dora"
+)
+
+// DefaultOptions returns the options used by the backfill command
+func DefaultOptions() Options {
+	opts := Options{Weeks: 52, Dora: dora.DefaultOptions()}
+	opts.Dora.PeriodUnit = "week"
+	opts.Dora.MaxPullRequests = 500
+	return opts
+}
+
+// Collect reconstructs the complete weeks before now. Issues and pull requests come from the
+// incremental store (a nil store syncs in memory); commits, releases and DORA are listed once
+// for the whole range and bucketed by week. A failed DORA or release listing is recorded in
+// Result.Errors; only failed commit or issue listings are returned as errors.
+func Collect(ctx context.Context, cli *github.Client, store *incremental.Store, owner, repo string, opts Options, now time.Time) (*Result, error) {
+	if opts.Weeks <= 0 {
+		opts.Weeks = DefaultOptions().Weeks
+	}
+	until := weekStart(now)
+	since := until.AddDate(0, 0, -7*opts.Weeks)
+	result := &Result{Owner: owner, Repo: repo, Since: since, Until: until}
+
+	var snap *incremental.Snapshot
+	var err error
+	if store != nil {
+		snap, result.Sync, err = store.SyncRepository(ctx, cli, owner, repo, opts.Sync)
+	} else {
+		snap = incremental.NewSnapshot(owner, repo)
+		result.Sync, err = incremental.Sync(ctx, cli, snap, opts.Sync)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync issues of %s/%s: %w", owner, repo, err)
+	}
+
+	commits, err := listCommitTimes(ctx, cli, owner, repo, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of %s/%s: %w", owner, repo, err)
+	}
+	result.Commits = len(commits)
+
+	releases, releaseErr := listReleaseTimes(ctx, cli, owner, repo, since, until)
+	if releaseErr != nil {
+		result.Errors = append(result.Errors, "releases: "+releaseErr.Error())
+	}
+	result.Releases = len(releases)
+
+	if !opts.SkipDora {
+		doraOpts := opts.Dora
+		// The DORA window ends now, so it reaches back over the partial current week too
+		doraOpts.PeriodDays = int(now.Sub(since).Hours()/24) + 1
+		if report, err := dora.ComputeMetrics(ctx, cli, owner, repo, doraOpts); err != nil {
+			result.Errors = append(result.Errors, "dora: "+err.Error())
+		} else {
+			result.Dora = report
+		}
+	}
+
+	for start := since; start.Before(until); start = start.AddDate(0, 0, 7) {
+		end := start.AddDate(0, 0, 7)
+		week := Week{Start: start, End: end, Metrics: map[string]float64{}}
+		week.Metrics[history.MetricCommits] = float64(countBetween(commits, start, end))
+		if releaseErr == nil {
+			week.Metrics[history.MetricReleases] = float64(countBetween(releases, start, end))
+		}
+		pullRequestFlow(week.Metrics, snap.PullRequests(), start, end)
+		issueFlow(week.Metrics, snap.Issues(), start, end)
+		if result.Dora != nil {
+			doraWeek(week.Metrics, result.Dora, start, end, opts.Dora.PeriodUnit)
+		}
+		result.Weeks = append(result.Weeks, week)
+	}
+	return result, nil
+}
+
+// Write stores the weeks as weekly points of the backfill source, replacing a previous
+// backfill of the repository. Metrics that real runs already recorded in a week are left out
+// so measured values always win over reconstructed ones.
+func Write(store *history.Store, result *Result) (WriteResult, error) {
+	var out WriteResult
+	existing, err := store.Load(result.Owner, result.Repo, result.Since)
+	if err != nil {
+		return out, fmt.Errorf("failed to load history of %s/%s: %w", result.Owner, result.Repo, err)
+	}
+	measured := measuredByWeek(existing)
+
+	points := make([]history.Point, 0, len(result.Weeks))
+	for _, week := range result.Weeks {
+		values := make(map[string]float64, len(week.Metrics))
+		for name, value := range week.Metrics {
+			if measured[week.Start.Format("2006-01-02")][name] {
+				out.Shadowed++
+				continue
+			}
+			values[name] = value
+		}
+		if len(values) == 0 {
+			continue
+		}
+		points = append(points, history.Point{
+			Time:       week.Start,
+			Resolution: history.ResolutionWeekly,
+			Count:      1,
+			Metrics:    values,
+		})
+	}
+	out.Points, err = store.Replace(result.Owner, result.Repo, Source, points)
+	if err != nil {
+		return out, fmt.Errorf("failed to write history of %s/%s: %w", result.Owner, result.Repo, err)
+	}
+	return out, nil
+}
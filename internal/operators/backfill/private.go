@@ -0,0 +1,185 @@
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/dora"
+)
+
+// weekStart is the Monday of the ISO week of t, in UTC
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func in(t, start, end time.Time) bool {
+	return !t.IsZero() && !t.Before(start) && t.Before(end)
+}
+
+func countBetween(times []time.Time, start, end time.Time) int {
+	n := 0
+	for _, t := range times {
+		if in(t, start, end) {
+			n++
+		}
+	}
+	return n
+}
+
+// openAt reports whether an item created at created and closed at closed was open at t
+func openAt(created, closed, t time.Time) bool {
+	return created.Before(t) && (closed.IsZero() || !closed.Before(t))
+}
+
+// listCommitTimes lists the commit dates of the default branch in [since, until)
+func listCommitTimes(ctx context.Context, cli *github.Client, owner, repo string, since, until time.Time) ([]time.Time, error) {
+	var times []time.Time
+	opt := &github.CommitsListOptions{
+		Since:       since,
+		Until:       until,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		commits, resp, err := cli.Repositories.ListCommits(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range commits {
+			times = append(times, c.GetCommit().GetCommitter().GetDate().Time)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return times, nil
+}
+
+// listReleaseTimes lists the publish dates of non-draft releases in [since, until).
+// Releases come newest first, so listing stops at the first page older than since.
+func listReleaseTimes(ctx context.Context, cli *github.Client, owner, repo string, since, until time.Time) ([]time.Time, error) {
+	var times []time.Time
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := cli.Repositories.ListReleases(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		older := len(releases) > 0
+		for _, r := range releases {
+			at := r.GetPublishedAt().Time
+			if at.IsZero() {
+				at = r.GetCreatedAt().Time
+			}
+			if !at.Before(since) {
+				older = false
+			}
+			if r.GetDraft() || !in(at, since, until) {
+				continue
+			}
+			times = append(times, at)
+		}
+		if older || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return times, nil
+}
+
+// pullRequestFlow adds the merged count, median cycle time and open count at week end
+func pullRequestFlow(values map[string]float64, pulls []*incremental.Item, start, end time.Time) {
+	var cycle []float64
+	open := 0
+	for _, pr := range pulls {
+		if openAt(pr.CreatedAt, pr.ClosedAt, end) {
+			open++
+		}
+		if pr.Merged && in(pr.ClosedAt, start, end) {
+			cycle = append(cycle, pr.ClosedAt.Sub(pr.CreatedAt).Hours())
+		}
+	}
+	values[history.MetricOpenPRs] = float64(open)
+	values[history.MetricMergedPRs] = float64(len(cycle))
+	if len(cycle) > 0 {
+		values[history.MetricPRCycleTimeP50] = metrics.Percentile(cycle, 50)
+	}
+}
+
+// issueFlow adds the opened and closed counts and the open count at week end
+func issueFlow(values map[string]float64, issues []*incremental.Item, start, end time.Time) {
+	opened, closed, open := 0, 0, 0
+	for _, issue := range issues {
+		if in(issue.CreatedAt, start, end) {
+			opened++
+		}
+		if in(issue.ClosedAt, start, end) {
+			closed++
+		}
+		if openAt(issue.CreatedAt, issue.ClosedAt, end) {
+			open++
+		}
+	}
+	values[history.MetricIssuesOpened] = float64(opened)
+	values[history.MetricIssuesClosed] = float64(closed)
+	values[history.MetricOpenIssues] = float64(open)
+}
+
+// doraWeek adds the DORA metrics of one week. Metrics without data that week (no deploys,
+// no lead times, no failures) are left out rather than recorded as zero.
+func doraWeek(values map[string]float64, report *dora.Report, start, end time.Time, unit string) {
+	m := report.MetricsBetween(start, end, unit)
+	values[history.MetricDeployFreq] = m.DeploymentFrequency
+
+	deploys, changes, failures := 0, 0, 0
+	for _, d := range report.Deploys {
+		if !d.Failed && in(d.At, start, end) {
+			deploys++
+		}
+	}
+	for _, lt := range report.Changes {
+		if in(lt.DeployedAt, start, end) {
+			changes++
+		}
+	}
+	for _, f := range report.Failures {
+		if in(f.At, start, end) {
+			failures++
+		}
+	}
+	if changes > 0 {
+		values[history.MetricLeadTimeP50] = m.LeadTimeP50
+		values[history.MetricLeadTimeP95] = m.LeadTimeP95
+	}
+	if deploys > 0 {
+		values[history.MetricChangeFailRate] = m.ChangeFailRate
+	}
+	if failures > 0 {
+		values[history.MetricMTTR] = m.MTTR
+	}
+}
+
+// measuredByWeek indexes the metrics recorded by other sources per week start
+func measuredByWeek(points []history.Point) map[string]map[string]bool {
+	out := map[string]map[string]bool{}
+	for _, p := range points {
+		if p.Source == Source {
+			continue
+		}
+		week := weekStart(p.Time).Format("2006-01-02")
+		if out[week] == nil {
+			out[week] = map[string]bool{}
+		}
+		for name := range p.Metrics {
+			out[week][name] = true
+		}
+	}
+	return out
+}
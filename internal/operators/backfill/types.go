@@ -0,0 +1,51 @@
+package backfill
+
+import (
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/dora"
+)
+
+// Source is the history source of backfilled points
+const Source = "backfill"
+
+// Options controls a backfill
+type Options struct {
+	// Weeks is the number of complete weeks reconstructed before the current one
+	Weeks int
+	// Dora configures the deploy detection; its period is derived from Weeks
+	Dora dora.Options
+	// SkipDora skips the DORA reconstruction (deploys, lead times, failures)
+	SkipDora bool
+	// Sync controls the issue and pull request sync backing the flow metrics
+	Sync incremental.Options
+}
+
+// Week is the reconstructed snapshot of one ISO week, Monday to Monday in UTC
+type Week struct {
+	Start   time.Time          `json:"start"`
+	End     time.Time          `json:"end"`
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// Result is the reconstructed history of one repository
+type Result struct {
+	Owner    string             `json:"owner"`
+	Repo     string             `json:"repo"`
+	Since    time.Time          `json:"since"`
+	Until    time.Time          `json:"until"`
+	Weeks    []Week             `json:"weeks"`
+	Commits  int                `json:"commits"`
+	Releases int                `json:"releases"`
+	Sync     incremental.Result `json:"sync"`
+	Dora     *dora.Report       `json:"dora,omitempty"`
+	Errors   []string           `json:"errors,omitempty"`
+}
+
+// WriteResult describes what was written to the history store
+type WriteResult struct {
+	Points int `json:"points"`
+	// Shadowed counts the metrics left out because a real run already measured them that week
+	Shadowed int `json:"shadowed"`
+}
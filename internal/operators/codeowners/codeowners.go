@@ -0,0 +1,242 @@
+// Package codeowners parses and validates CODEOWNERS files, measures how much of the
+// repository tree they cover and finds rules that never apply or only name departed owners.
+package codeowners
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// DefaultOptions returns the options used by the codeowners command
+func DefaultOptions() Options {
+	return Options{CheckOwners: true, InactiveDays: 90, MaxUnowned: 50}
+}
+
+// Load fetches and parses the CODEOWNERS file GitHub uses for ref; nil when there is none
+func Load(ctx context.Context, cli *github.Client, owner, repo, ref string) (*File, error) {
+	for _, path := range Locations {
+		data, found, err := fetchFile(ctx, cli, owner, repo, path, ref)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return Parse(path, data), nil
+		}
+	}
+	return nil, nil
+}
+
+// Analyze validates the CODEOWNERS file of a repository and computes its coverage
+func Analyze(ctx context.Context, cli *github.Client, owner, repo string, opts Options) (*Report, error) {
+	ref := opts.Ref
+	if ref == "" {
+		r, _, err := cli.Repositories.Get(ctx, owner, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository: %w", err)
+		}
+		ref = r.GetDefaultBranch()
+	}
+
+	file, err := Load(ctx, cli, owner, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	files, truncated, err := listTreeFiles(ctx, cli, owner, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []OwnerCheck
+	if file != nil && opts.CheckOwners {
+		active, err := activeUsers(ctx, cli, owner, repo, opts.InactiveDays)
+		if err != nil {
+			return nil, err
+		}
+		checks = checkOwners(ctx, cli, owner, repo, file, active)
+	}
+
+	report := Compute(file, files, checks, opts)
+	report.Owner, report.Repo, report.Ref = owner, repo, ref
+	report.TreeTruncated = truncated
+	return report, nil
+}
+
+// Compute measures coverage and rule issues of file over the repository files.
+// checks holds the owner validation, used to find departed-only rules.
+func Compute(file *File, files []string, checks []OwnerCheck, opts Options) *Report {
+	report := &Report{
+		Found:          file != nil,
+		File:           file,
+		FilesTotal:     len(files),
+		Unowned:        []string{},
+		Owners:         []OwnerCheck{},
+		Unmatched:      []RuleIssue{},
+		Shadowed:       []RuleIssue{},
+		DepartedRules:  []DepartedRule{},
+		DepartedOwners: []string{},
+	}
+	if file == nil {
+		report.Unowned = sample(files, opts.MaxUnowned)
+		return report
+	}
+
+	matches := make([]int, len(file.Rules))
+	wins := make([]int, len(file.Rules))
+	// overriders[i][j]: files matched by rule i but decided by the later rule j
+	overriders := make([]map[int]int, len(file.Rules))
+	for _, path := range files {
+		winner := -1
+		for i := len(file.Rules) - 1; i >= 0; i-- {
+			if !Match(file.Rules[i].Pattern, path) {
+				continue
+			}
+			matches[i]++
+			if winner < 0 {
+				winner = i
+				wins[i]++
+				continue
+			}
+			if overriders[i] == nil {
+				overriders[i] = make(map[int]int)
+			}
+			overriders[i][winner]++
+		}
+		if winner >= 0 && len(file.Rules[winner].Owners) > 0 {
+			report.FilesCovered++
+		} else if opts.MaxUnowned <= 0 || len(report.Unowned) < opts.MaxUnowned {
+			report.Unowned = append(report.Unowned, path)
+		}
+	}
+	if report.FilesTotal > 0 {
+		report.CoveragePct = float64(report.FilesCovered) / float64(report.FilesTotal) * 100
+	}
+
+	for i, rule := range file.Rules {
+		switch {
+		case matches[i] == 0:
+			report.Unmatched = append(report.Unmatched, RuleIssue{Line: rule.Line, Pattern: rule.Pattern})
+		case wins[i] == 0:
+			report.Shadowed = append(report.Shadowed, RuleIssue{
+				Line:       rule.Line,
+				Pattern:    rule.Pattern,
+				Matches:    matches[i],
+				ShadowedBy: file.Rules[mainOverrider(overriders[i])].Line,
+			})
+		}
+	}
+
+	byOwner := make(map[string]OwnerCheck, len(checks))
+	for _, c := range checks {
+		byOwner[strings.ToLower(c.Owner)] = c
+		report.Owners = append(report.Owners, c)
+		if !c.Exists || !c.HasAccess {
+			report.InvalidOwners++
+		}
+		if c.Departed {
+			report.DepartedOwners = append(report.DepartedOwners, c.Owner)
+		}
+	}
+	if len(checks) == 0 {
+		return report
+	}
+	for i, rule := range file.Rules {
+		if wins[i] == 0 || len(rule.Owners) == 0 {
+			continue
+		}
+		allDeparted := true
+		for _, o := range rule.Owners {
+			if !byOwner[strings.ToLower(o)].Departed {
+				allDeparted = false
+				break
+			}
+		}
+		if allDeparted {
+			report.DepartedRules = append(report.DepartedRules, DepartedRule{Line: rule.Line, Pattern: rule.Pattern, Owners: rule.Owners, Files: wins[i]})
+		}
+	}
+	return report
+}
+
+// ReviewersFor ranks the owners of the changed files by the number of files they own
+func (f *File) ReviewersFor(paths []string) ([]string, int) {
+	counts := make(map[string]int)
+	owned := 0
+	for _, path := range paths {
+		owners := f.OwnersFor(path)
+		if len(owners) > 0 {
+			owned++
+		}
+		for _, o := range owners {
+			counts[o]++
+		}
+	}
+	reviewers := make([]string, 0, len(counts))
+	for o := range counts {
+		reviewers = append(reviewers, o)
+	}
+	sort.Slice(reviewers, func(i, j int) bool {
+		if counts[reviewers[i]] != counts[reviewers[j]] {
+			return counts[reviewers[i]] > counts[reviewers[j]]
+		}
+		return reviewers[i] < reviewers[j]
+	})
+	return reviewers, owned
+}
+
+// ToMarkdown renders the CODEOWNERS reports
+func ToMarkdown(reports []*Report) string {
+	var b strings.Builder
+	b.WriteString("# CODEOWNERS Report\n\n")
+	b.WriteString(fmt.Sprintf("_Generated %s_\n\n", time.Now().Format(time.RFC3339)))
+	b.WriteString("| Repository | File | Coverage | Invalid Owners | Unmatched | Shadowed | Departed-only Rules |\n")
+	b.WriteString("|------------|------|----------|----------------|-----------|----------|---------------------|\n")
+	for _, r := range reports {
+		path := "—"
+		if r.File != nil {
+			path = "`" + r.File.Path + "`"
+		}
+		b.WriteString(fmt.Sprintf("| %s/%s | %s | %.1f%% (%d/%d) | %d | %d | %d | %d |\n",
+			r.Owner, r.Repo, path, r.CoveragePct, r.FilesCovered, r.FilesTotal, r.InvalidOwners, len(r.Unmatched), len(r.Shadowed), len(r.DepartedRules)))
+	}
+
+	for _, r := range reports {
+		b.WriteString(fmt.Sprintf("\n## %s/%s\n\n", r.Owner, r.Repo))
+		if !r.Found {
+			b.WriteString("No CODEOWNERS file in " + strings.Join(Locations, ", ") + ".\n")
+			continue
+		}
+		if r.TreeTruncated {
+			b.WriteString("> The git tree was truncated by the API; coverage is approximate.\n\n")
+		}
+		for _, e := range r.File.Errors {
+			b.WriteString(fmt.Sprintf("- ❌ line %d: %s\n", e.Line, e.Message))
+		}
+		for _, o := range r.Owners {
+			if o.Problem != "" {
+				b.WriteString(fmt.Sprintf("- ❌ `%s`: %s\n", o.Owner, o.Problem))
+			}
+		}
+		for _, u := range r.Unmatched {
+			b.WriteString(fmt.Sprintf("- ⚠️ line %d `%s` matches no file\n", u.Line, u.Pattern))
+		}
+		for _, s := range r.Shadowed {
+			b.WriteString(fmt.Sprintf("- ⚠️ line %d `%s` is overridden by line %d for all %d matching files\n", s.Line, s.Pattern, s.ShadowedBy, s.Matches))
+		}
+		for _, d := range r.DepartedRules {
+			b.WriteString(fmt.Sprintf("- 👋 line %d `%s` (%d files) is owned only by departed %s\n", d.Line, d.Pattern, d.Files, strings.Join(d.Owners, ", ")))
+		}
+		if len(r.Unowned) > 0 {
+			b.WriteString(fmt.Sprintf("\n<details><summary>Unowned files (%d shown)</summary>\n\n", len(r.Unowned)))
+			for _, u := range r.Unowned {
+				b.WriteString("- `" + u + "`\n")
+			}
+			b.WriteString("\n</details>\n")
+		}
+	}
+	return b.String()
+}
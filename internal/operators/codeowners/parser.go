@@ -0,0 +1,133 @@
+package codeowners
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	userRe  = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?$`)
+	teamRe  = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?/[A-Za-z0-9._-]+$`)
+	emailRe = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// Parse parses a CODEOWNERS file. Rules without owners are kept: they unset ownership.
+func Parse(path string, data []byte) *File {
+	f := &File{Path: path, Rules: []Rule{}, Errors: []ParseError{}}
+	for i, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		rule := Rule{Line: i + 1, Pattern: fields[0], Owners: []string{}}
+		if strings.HasPrefix(rule.Pattern, "!") || strings.Contains(rule.Pattern, "[") {
+			f.Errors = append(f.Errors, ParseError{Line: rule.Line, Message: "negation and character ranges are not supported by CODEOWNERS"})
+			continue
+		}
+		for _, owner := range fields[1:] {
+			if OwnerKind(owner) == "" {
+				f.Errors = append(f.Errors, ParseError{Line: rule.Line, Message: "invalid owner " + owner})
+				continue
+			}
+			rule.Owners = append(rule.Owners, owner)
+		}
+		f.Rules = append(f.Rules, rule)
+	}
+	return f
+}
+
+// OwnerKind classifies an owner as user, team or email; empty when invalid
+func OwnerKind(owner string) string {
+	switch {
+	case teamRe.MatchString(owner):
+		return KindTeam
+	case userRe.MatchString(owner):
+		return KindUser
+	case emailRe.MatchString(owner):
+		return KindEmail
+	default:
+		return ""
+	}
+}
+
+// OwnersFor returns the owners of a path: those of the last matching rule
+func (f *File) OwnersFor(path string) []string {
+	if i := f.RuleFor(path); i >= 0 {
+		return f.Rules[i].Owners
+	}
+	return nil
+}
+
+// RuleFor returns the index of the rule deciding ownership of a path, -1 when none matches
+func (f *File) RuleFor(path string) int {
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if Match(f.Rules[i].Pattern, path) {
+			return i
+		}
+	}
+	return -1
+}
+
+var (
+	patternMu    sync.Mutex
+	patternCache = map[string]*regexp.Regexp{}
+)
+
+// Match reports whether a CODEOWNERS pattern (gitignore syntax) matches a repository path
+func Match(pattern, path string) bool {
+	patternMu.Lock()
+	re, ok := patternCache[pattern]
+	if !ok {
+		re = regexp.MustCompile(patternRegexp(pattern))
+		patternCache[pattern] = re
+	}
+	patternMu.Unlock()
+	return re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// patternRegexp translates a gitignore-style pattern. A pattern with a slash other than a
+// trailing one is anchored at the root; a match on a directory covers everything below it.
+func patternRegexp(pattern string) string {
+	if pattern == "*" {
+		return `^.*$`
+	}
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	p := strings.TrimPrefix(pattern, "/")
+	p = strings.TrimSuffix(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; {
+		case c == '*' && strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if strings.HasSuffix(p, "/*") {
+		// "docs/*" owns the files of docs but not of its subdirectories
+		b.WriteString("$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return b.String()
+}
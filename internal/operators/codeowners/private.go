@@ -0,0 +1,154 @@
+package codeowners
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// maxActivityCommits bounds the commits read to find active users
+const maxActivityCommits = 500
+
+func fetchFile(ctx context.Context, cli *github.Client, owner, repo, path, ref string) ([]byte, bool, error) {
+	fc, _, resp, err := cli.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get %s: %w", path, err)
+	}
+	if fc == nil {
+		return nil, false, nil
+	}
+	content, err := fc.GetContent()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return []byte(content), true, nil
+}
+
+func listTreeFiles(ctx context.Context, cli *github.Client, owner, repo, ref string) ([]string, bool, error) {
+	tree, _, err := cli.Git.GetTree(ctx, owner, repo, ref, true)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get tree of %s: %w", ref, err)
+	}
+	files := make([]string, 0, len(tree.Entries))
+	for _, e := range tree.Entries {
+		if e.GetType() == "blob" {
+			files = append(files, e.GetPath())
+		}
+	}
+	return files, tree.GetTruncated(), nil
+}
+
+// activeUsers returns the lowercased logins that committed in the last inactiveDays
+func activeUsers(ctx context.Context, cli *github.Client, owner, repo string, inactiveDays int) (map[string]bool, error) {
+	active := make(map[string]bool)
+	opt := &github.CommitsListOptions{
+		Since:       time.Now().AddDate(0, 0, -inactiveDays),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	read := 0
+	for {
+		commits, resp, err := cli.Repositories.ListCommits(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list recent commits: %w", err)
+		}
+		for _, c := range commits {
+			if login := c.GetAuthor().GetLogin(); login != "" {
+				active[strings.ToLower(login)] = true
+			}
+			if login := c.GetCommitter().GetLogin(); login != "" {
+				active[strings.ToLower(login)] = true
+			}
+		}
+		read += len(commits)
+		if resp.NextPage == 0 || read >= maxActivityCommits {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return active, nil
+}
+
+// checkOwners verifies each distinct owner: users must exist and be collaborators with
+// write access, teams must exist and have access to the repository
+func checkOwners(ctx context.Context, cli *github.Client, owner, repo string, file *File, active map[string]bool) []OwnerCheck {
+	seen := make(map[string]bool)
+	var checks []OwnerCheck
+	for _, rule := range file.Rules {
+		for _, o := range rule.Owners {
+			key := strings.ToLower(o)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			checks = append(checks, checkOwner(ctx, cli, owner, repo, o, active))
+		}
+	}
+	return checks
+}
+
+func checkOwner(ctx context.Context, cli *github.Client, owner, repo, o string, active map[string]bool) OwnerCheck {
+	c := OwnerCheck{Owner: o, Kind: OwnerKind(o)}
+	switch c.Kind {
+	case KindUser:
+		login := strings.TrimPrefix(o, "@")
+		if _, _, err := cli.Users.Get(ctx, login); err != nil {
+			c.Problem = "user does not exist"
+			return c
+		}
+		c.Exists = true
+		perm, _, err := cli.Repositories.GetPermissionLevel(ctx, owner, repo, login)
+		if err == nil {
+			switch perm.GetPermission() {
+			case "admin", "maintain", "write":
+				c.HasAccess = true
+			}
+		}
+		if !c.HasAccess {
+			c.Problem = "user has no write access to the repository"
+		}
+		c.Departed = !active[strings.ToLower(login)]
+	case KindTeam:
+		org, slug, _ := strings.Cut(strings.TrimPrefix(o, "@"), "/")
+		if _, _, err := cli.Teams.GetTeamBySlug(ctx, org, slug); err != nil {
+			c.Problem = "team does not exist or is not visible"
+			return c
+		}
+		c.Exists = true
+		repoPerm, _, err := cli.Teams.IsTeamRepoBySlug(ctx, org, slug, owner, repo)
+		if err == nil && repoPerm != nil {
+			perms := repoPerm.GetPermissions()
+			c.HasAccess = perms["admin"] || perms["maintain"] || perms["push"]
+		}
+		if !c.HasAccess {
+			c.Problem = "team has no write access to the repository"
+		}
+	case KindEmail:
+		// Emails resolve to users only when verified on an account; not checkable via the API
+		c.Exists, c.HasAccess = true, true
+	}
+	return c
+}
+
+func mainOverrider(overriders map[int]int) int {
+	best, bestCount := -1, -1
+	for rule, count := range overriders {
+		if count > bestCount || (count == bestCount && rule > best) {
+			best, bestCount = rule, count
+		}
+	}
+	return best
+}
+
+func sample(files []string, max int) []string {
+	if max > 0 && len(files) > max {
+		return append([]string(nil), files[:max]...)
+	}
+	return append([]string{}, files...)
+}
@@ -0,0 +1,89 @@
+package codeowners
+
+// Locations are the paths GitHub reads CODEOWNERS from, in precedence order
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Owner kinds
+const (
+	KindUser  = "user"
+	KindTeam  = "team"
+	KindEmail = "email"
+)
+
+// Options controls a CODEOWNERS analysis
+type Options struct {
+	// Ref is the branch or commit analyzed, the default branch when empty
+	Ref string
+	// CheckOwners verifies that users and teams exist and have write access (one or two calls per owner)
+	CheckOwners bool
+	// InactiveDays without commits marks a user owner as departed
+	InactiveDays int
+	// MaxUnowned bounds the sample of unowned files kept in the report
+	MaxUnowned int
+}
+
+// Rule is one CODEOWNERS line
+type Rule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// ParseError is an invalid CODEOWNERS line
+type ParseError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// File is a parsed CODEOWNERS file
+type File struct {
+	Path   string       `json:"path"`
+	Rules  []Rule       `json:"rules"`
+	Errors []ParseError `json:"errors"`
+}
+
+// OwnerCheck is the validation of one owner referenced by the rules
+type OwnerCheck struct {
+	Owner     string `json:"owner"`
+	Kind      string `json:"kind"`
+	Exists    bool   `json:"exists"`
+	HasAccess bool   `json:"has_access"` // Write access, required for review requests
+	Departed  bool   `json:"departed"`
+	Problem   string `json:"problem,omitempty"`
+}
+
+// RuleIssue is a rule that never decides ownership
+type RuleIssue struct {
+	Line       int    `json:"line"`
+	Pattern    string `json:"pattern"`
+	Matches    int    `json:"matches"`
+	ShadowedBy int    `json:"shadowed_by,omitempty"` // Line of the later rule that overrides it
+}
+
+// DepartedRule is a rule whose owners are all departed users
+type DepartedRule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+	Files   int      `json:"files"`
+}
+
+// Report is the CODEOWNERS validation and coverage of a repository
+type Report struct {
+	Owner          string         `json:"owner"`
+	Repo           string         `json:"repo"`
+	Ref            string         `json:"ref"`
+	Found          bool           `json:"found"`
+	File           *File          `json:"file,omitempty"`
+	FilesTotal     int            `json:"files_total"`
+	FilesCovered   int            `json:"files_covered"`
+	CoveragePct    float64        `json:"coverage_pct"`
+	TreeTruncated  bool           `json:"tree_truncated"`
+	Unowned        []string       `json:"unowned"`
+	Owners         []OwnerCheck   `json:"owners"`
+	InvalidOwners  int            `json:"invalid_owners"`
+	Unmatched      []RuleIssue    `json:"unmatched"`
+	Shadowed       []RuleIssue    `json:"shadowed"`
+	DepartedRules  []DepartedRule `json:"departed_rules"`
+	DepartedOwners []string       `json:"departed_owners"`
+}
@@ -0,0 +1,274 @@
+// Package codestats computes static code metrics (LOC, cyclomatic complexity, duplication,
+// maintainability index) from a repository source snapshot.
+package codestats
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// skippedDirs are never analyzed: dependencies, build output and VCS data
+var skippedDirs = map[string]bool{
+	".git":         true,
+	"vendor":       true,
+	"node_modules": true,
+	"dist":         true,
+	"build":        true,
+	"third_party":  true,
+	"testdata":     true,
+	"__pycache__":  true,
+	"target":       true,
+}
+
+// DefaultOptions returns the options used when none are provided
+func DefaultOptions() Options {
+	return Options{
+		MaxFileBytes:      1 << 20,
+		DuplicationWindow: 50,
+		TopFunctions:      10,
+	}
+}
+
+// AnalyzeSnapshot downloads the repository tarball at opts.Ref and analyzes it
+func AnalyzeSnapshot(ctx context.Context, cli *github.Client, owner, repo string, opts Options) (*Report, error) {
+	dir, cleanup, err := DownloadSnapshot(ctx, cli, owner, repo, opts.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download snapshot: %w", err)
+	}
+	defer cleanup()
+
+	report, err := AnalyzeDir(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	report.Owner = owner
+	report.Repo = repo
+	report.Ref = opts.Ref
+	return report, nil
+}
+
+// AnalyzeDir analyzes every recognized source file under dir.
+// Go files get exact AST-based metrics; other languages use the line-based fallback.
+func AnalyzeDir(dir string, opts Options) (*Report, error) {
+	opts = normalizeOptions(opts)
+
+	report := &Report{
+		Languages:  make(map[string]*LanguageStats),
+		TopComplex: []FunctionStat{},
+	}
+
+	var (
+		functions   []FunctionStat
+		tokenSets   [][]uint64
+		totalCC     int
+		miWeighted  float64
+		miWeight    float64
+		goParseErrs int
+	)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && (skippedDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		lang, ok := languages[strings.ToLower(filepath.Ext(path))]
+		if !ok {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > opts.MaxFileBytes {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		rel, _ := filepath.Rel(dir, path)
+
+		stats := report.Languages[lang.name]
+		if stats == nil {
+			stats = &LanguageStats{Exact: lang.name == "Go"}
+			report.Languages[lang.name] = stats
+		}
+		stats.Files++
+		report.Files++
+
+		if lang.name == "Go" {
+			gf, err := analyzeGoFile(filepath.ToSlash(rel), src)
+			if err != nil {
+				goParseErrs++
+			}
+			addLines(&stats.Lines, gf.lines)
+			tokenSets = append(tokenSets, gf.tokens)
+			for _, fn := range gf.functions {
+				stats.Functions++
+				stats.CyclomaticAvg += float64(fn.Cyclomatic)
+				stats.MaintainabilityIndex += fn.MaintainabilityIndex * float64(fn.Lines)
+				totalCC += fn.Cyclomatic
+				miWeighted += fn.MaintainabilityIndex * float64(fn.Lines)
+				miWeight += float64(fn.Lines)
+			}
+			functions = append(functions, gf.functions...)
+			return nil
+		}
+
+		gf := analyzeGenericFile(src, lang.syntax)
+		addLines(&stats.Lines, gf.lines)
+		tokenSets = append(tokenSets, gf.tokens)
+		if gf.functions > 0 {
+			// per-function averages keep the MI comparable with the Go numbers
+			n := float64(gf.functions)
+			mi := maintainabilityIndex(gf.volume/n, float64(gf.cyclomatic)/n, float64(gf.lines.Code)/n)
+			stats.Functions += gf.functions
+			stats.CyclomaticAvg += float64(gf.cyclomatic)
+			stats.MaintainabilityIndex += mi * float64(gf.lines.Code)
+			totalCC += gf.cyclomatic
+			miWeighted += mi * float64(gf.lines.Code)
+			miWeight += float64(gf.lines.Code)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze snapshot: %w", err)
+	}
+	if goParseErrs > 0 {
+		gl.Log("debug", fmt.Sprintf("codestats: %d Go files could not be parsed, only lines were counted", goParseErrs))
+	}
+
+	// language averages were accumulated as sums
+	for _, stats := range report.Languages {
+		addLines(&report.Lines, stats.Lines)
+		report.Functions += stats.Functions
+		if stats.Functions > 0 {
+			stats.CyclomaticAvg /= float64(stats.Functions)
+		}
+		weight := float64(stats.Lines.Code)
+		if stats.Exact {
+			weight = 0
+			for _, fn := range functions {
+				weight += float64(fn.Lines)
+			}
+		}
+		if weight > 0 {
+			stats.MaintainabilityIndex /= weight
+		}
+	}
+
+	if report.Functions > 0 {
+		report.CyclomaticAvg = float64(totalCC) / float64(report.Functions)
+	}
+	if miWeight > 0 {
+		report.MaintainabilityIndex = miWeighted / miWeight
+	}
+	report.DuplicationPct = duplicationPct(tokenSets, opts.DuplicationWindow)
+
+	sort.Slice(functions, func(i, j int) bool { return functions[i].Cyclomatic > functions[j].Cyclomatic })
+	if len(functions) > 0 {
+		report.CyclomaticMax = functions[0].Cyclomatic
+	}
+	if len(functions) > opts.TopFunctions {
+		functions = functions[:opts.TopFunctions]
+	}
+	report.TopComplex = append(report.TopComplex, functions...)
+
+	if report.Functions > 0 {
+		report.CHI = metrics.ComputeCHI(report.MaintainabilityIndex, report.DuplicationPct, report.CyclomaticAvg, metrics.DefaultCHI)
+	}
+
+	return report, nil
+}
+
+// duplicationPct returns the share of tokens covered by a window of tokens
+// that appears more than once across all files
+func duplicationPct(tokenSets [][]uint64, window int) float64 {
+	type location struct{ file, offset int }
+
+	total := 0
+	for _, tokens := range tokenSets {
+		total += len(tokens)
+	}
+	if total == 0 {
+		return 0
+	}
+
+	first := make(map[uint64]location)
+	duplicated := make([][]bool, len(tokenSets))
+	for f, tokens := range tokenSets {
+		duplicated[f] = make([]bool, len(tokens))
+		for i := 0; i+window <= len(tokens); i++ {
+			key := windowHash(tokens[i : i+window])
+			loc, seen := first[key]
+			if !seen {
+				first[key] = location{f, i}
+				continue
+			}
+			// overlapping windows of a single run are not clones of themselves
+			if loc.file == f && i-loc.offset < window {
+				continue
+			}
+			for j := 0; j < window; j++ {
+				duplicated[f][i+j] = true
+				duplicated[loc.file][loc.offset+j] = true
+			}
+		}
+	}
+
+	dup := 0
+	for _, flags := range duplicated {
+		for _, d := range flags {
+			if d {
+				dup++
+			}
+		}
+	}
+	return float64(dup) / float64(total) * 100
+}
+
+// windowHash combines token hashes (FNV-1a over the 64-bit values)
+func windowHash(tokens []uint64) uint64 {
+	h := uint64(14695981039346656037)
+	for _, t := range tokens {
+		h ^= t
+		h *= 1099511628211
+	}
+	return h
+}
+
+// addLines adds line counts into dst
+func addLines(dst *LineCounts, src LineCounts) {
+	dst.Total += src.Total
+	dst.Code += src.Code
+	dst.Comments += src.Comments
+	dst.Blank += src.Blank
+}
+
+// normalizeOptions fills unset options with the defaults
+func normalizeOptions(opts Options) Options {
+	def := DefaultOptions()
+	if opts.MaxFileBytes <= 0 {
+		opts.MaxFileBytes = def.MaxFileBytes
+	}
+	if opts.DuplicationWindow <= 0 {
+		opts.DuplicationWindow = def.DuplicationWindow
+	}
+	if opts.TopFunctions <= 0 {
+		opts.TopFunctions = def.TopFunctions
+	}
+	return opts
+}
@@ -0,0 +1,203 @@
+package codestats
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// commentSyntax describes how a language marks comments
+type commentSyntax struct {
+	line       []string
+	blockStart string
+	blockEnd   string
+}
+
+var (
+	cStyle    = commentSyntax{line: []string{"//"}, blockStart: "/*", blockEnd: "*/"}
+	hashStyle = commentSyntax{line: []string{"#"}}
+	pyStyle   = commentSyntax{line: []string{"#"}, blockStart: `"""`, blockEnd: `"""`}
+	luaStyle  = commentSyntax{line: []string{"--"}, blockStart: "--[[", blockEnd: "]]"}
+	rubyStyle = commentSyntax{line: []string{"#"}, blockStart: "=begin", blockEnd: "=end"}
+	phpStyle  = commentSyntax{line: []string{"//", "#"}, blockStart: "/*", blockEnd: "*/"}
+	sqlStyle  = commentSyntax{line: []string{"--"}, blockStart: "/*", blockEnd: "*/"}
+)
+
+// languages maps file extensions to a language name and its comment syntax
+var languages = map[string]struct {
+	name   string
+	syntax commentSyntax
+}{
+	".go":    {"Go", cStyle},
+	".js":    {"JavaScript", cStyle},
+	".jsx":   {"JavaScript", cStyle},
+	".mjs":   {"JavaScript", cStyle},
+	".cjs":   {"JavaScript", cStyle},
+	".ts":    {"TypeScript", cStyle},
+	".tsx":   {"TypeScript", cStyle},
+	".py":    {"Python", pyStyle},
+	".java":  {"Java", cStyle},
+	".kt":    {"Kotlin", cStyle},
+	".scala": {"Scala", cStyle},
+	".c":     {"C", cStyle},
+	".h":     {"C", cStyle},
+	".cc":    {"C++", cStyle},
+	".cpp":   {"C++", cStyle},
+	".cxx":   {"C++", cStyle},
+	".hpp":   {"C++", cStyle},
+	".cs":    {"C#", cStyle},
+	".rs":    {"Rust", cStyle},
+	".swift": {"Swift", cStyle},
+	".dart":  {"Dart", cStyle},
+	".rb":    {"Ruby", rubyStyle},
+	".php":   {"PHP", phpStyle},
+	".lua":   {"Lua", luaStyle},
+	".sh":    {"Shell", hashStyle},
+	".bash":  {"Shell", hashStyle},
+	".sql":   {"SQL", sqlStyle},
+	".ex":    {"Elixir", hashStyle},
+	".exs":   {"Elixir", hashStyle},
+	".vue":   {"Vue", cStyle},
+	".r":     {"R", hashStyle},
+	".pl":    {"Perl", hashStyle},
+	".zig":   {"Zig", commentSyntax{line: []string{"//"}}},
+	".hs":    {"Haskell", commentSyntax{line: []string{"--"}, blockStart: "{-", blockEnd: "-}"}},
+	".clj":   {"Clojure", commentSyntax{line: []string{";"}}},
+}
+
+var (
+	genericTokenRe    = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*|\d+(?:\.\d+)?|"(?:\\.|[^"\\])*"|'(?:\\.|[^'\\])*'|&&|\|\||==|!=|<=|>=|=>|->|::|[^\sA-Za-z0-9_]`)
+	genericDecisionRe = regexp.MustCompile(`\b(?:if|elif|elsif|for|foreach|while|until|unless|case|when|catch|except|rescue)\b|&&|\|\||\band\b|\bor\b`)
+	genericFuncRe     = regexp.MustCompile(`\b(?:func|function|def|fn|fun|sub)\b|=>|^\s*(?:(?:public|private|protected|internal|static|final|async|override|virtual)\s+)+[\w<>\[\],.?]+\s+\w+\s*\(`)
+)
+
+// genericKeywords are counted as Halstead operators by the line-based fallback
+var genericKeywords = map[string]bool{
+	"if": true, "else": true, "elif": true, "elsif": true, "for": true, "foreach": true, "while": true,
+	"do": true, "switch": true, "case": true, "default": true, "break": true, "continue": true,
+	"return": true, "try": true, "catch": true, "except": true, "finally": true, "throw": true,
+	"raise": true, "new": true, "delete": true, "class": true, "struct": true, "interface": true,
+	"func": true, "function": true, "def": true, "fn": true, "fun": true, "let": true, "var": true,
+	"const": true, "val": true, "import": true, "from": true, "package": true, "public": true,
+	"private": true, "protected": true, "static": true, "async": true, "await": true, "yield": true,
+	"in": true, "is": true, "not": true, "and": true, "or": true, "with": true, "as": true,
+	"match": true, "when": true, "unless": true, "until": true, "begin": true, "end": true,
+}
+
+// genericFile is the line-based analysis of a non-Go source file
+type genericFile struct {
+	lines      LineCounts
+	functions  int
+	cyclomatic int
+	volume     float64
+	tokens     []uint64
+}
+
+// analyzeGenericFile counts lines with the language comment syntax and estimates
+// complexity from decision keywords and function-like declarations
+func analyzeGenericFile(src []byte, syntax commentSyntax) *genericFile {
+	result := &genericFile{}
+	h := newHalstead()
+	decisions := 0
+	inBlock := false
+
+	for _, raw := range strings.Split(strings.TrimSuffix(string(src), "\n"), "\n") {
+		result.lines.Total++
+		line := strings.TrimSpace(raw)
+
+		if inBlock {
+			result.lines.Comments++
+			if syntax.blockEnd != "" && strings.Contains(line, syntax.blockEnd) {
+				inBlock = false
+			}
+			continue
+		}
+		if line == "" {
+			result.lines.Blank++
+			continue
+		}
+		if syntax.blockStart != "" && strings.HasPrefix(line, syntax.blockStart) {
+			rest := strings.TrimPrefix(line, syntax.blockStart)
+			if !strings.Contains(rest, syntax.blockEnd) {
+				inBlock = true
+			}
+			result.lines.Comments++
+			continue
+		}
+		if hasAnyPrefix(line, syntax.line) {
+			result.lines.Comments++
+			continue
+		}
+
+		result.lines.Code++
+		decisions += len(genericDecisionRe.FindAllStringIndex(line, -1))
+		result.functions += len(genericFuncRe.FindAllStringIndex(line, -1))
+
+		for _, tok := range genericTokenRe.FindAllString(line, -1) {
+			result.tokens = append(result.tokens, hashToken(tok))
+			switch {
+			case genericKeywords[tok]:
+				h.operator(tok)
+			case isWordOrLiteral(tok):
+				h.operand(tok)
+			default:
+				h.operator(tok)
+			}
+		}
+	}
+
+	if result.lines.Code > 0 && result.functions == 0 {
+		// scripts and configuration-like code: treat the file as one unit
+		result.functions = 1
+	}
+	result.cyclomatic = result.functions + decisions
+	result.volume = h.volume()
+	return result
+}
+
+// hasAnyPrefix reports whether s starts with any of the prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordOrLiteral reports whether a token is an identifier, number or string
+func isWordOrLiteral(tok string) bool {
+	c := tok[0]
+	return c == '_' || c == '"' || c == '\'' || (c >= '0' && c <= '9') || (c|0x20 >= 'a' && c|0x20 <= 'z')
+}
+
+// halstead accumulates operator and operand counts
+type halstead struct {
+	operators map[string]int
+	operands  map[string]int
+	n1, n2    int
+}
+
+func newHalstead() *halstead {
+	return &halstead{operators: make(map[string]int), operands: make(map[string]int)}
+}
+
+func (h *halstead) operator(s string) { h.operators[s]++; h.n1++ }
+func (h *halstead) operand(s string)  { h.operands[s]++; h.n2++ }
+
+// volume returns the Halstead volume N * log2(n)
+func (h *halstead) volume() float64 {
+	vocabulary := len(h.operators) + len(h.operands)
+	length := h.n1 + h.n2
+	if vocabulary < 2 || length == 0 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(vocabulary))
+}
+
+// maintainabilityIndex is the classic MI (171 - 5.2 ln V - 0.23 CC - 16.2 ln LOC)
+// rescaled to 0..100
+func maintainabilityIndex(volume, cyclomatic, loc float64) float64 {
+	mi := 171 - 5.2*math.Log(math.Max(volume, 1)) - 0.23*cyclomatic - 16.2*math.Log(math.Max(loc, 1))
+	return math.Max(0, math.Min(100, mi*100/171))
+}
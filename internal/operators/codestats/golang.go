@@ -0,0 +1,198 @@
+package codestats
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"hash/fnv"
+)
+
+// goFile is the analysis of a single Go source file
+type goFile struct {
+	lines     LineCounts
+	functions []FunctionStat
+	tokens    []uint64
+}
+
+// analyzeGoFile computes exact line counts, per-function cyclomatic complexity,
+// Halstead volume and maintainability index for a Go file
+func analyzeGoFile(path string, src []byte) (*goFile, error) {
+	result := &goFile{}
+	result.lines, result.tokens = scanGoLines(src)
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if err != nil {
+		// keep line counts and tokens even when the file does not parse
+		return result, err
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		start := fset.Position(fn.Pos())
+		end := fset.Position(fn.End())
+
+		stat := FunctionStat{
+			File:       path,
+			Name:       funcName(fn),
+			Line:       start.Line,
+			Cyclomatic: cyclomatic(fn),
+			Lines:      end.Line - start.Line + 1,
+		}
+		stat.HalsteadVolume = goHalsteadVolume(src[start.Offset:end.Offset])
+		stat.MaintainabilityIndex = maintainabilityIndex(stat.HalsteadVolume, float64(stat.Cyclomatic), float64(stat.Lines))
+		result.functions = append(result.functions, stat)
+	}
+
+	return result, nil
+}
+
+// scanGoLines classifies each line as code, comment or blank using the Go scanner,
+// and returns the token hashes used for duplication detection
+func scanGoLines(src []byte) (LineCounts, []uint64) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, nil, scanner.ScanComments)
+
+	code := make(map[int]bool)
+	comment := make(map[int]bool)
+	var tokens []uint64
+
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// automatic semicolons are not real tokens
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		startLine := file.Line(pos)
+		endLine := startLine
+		if lit != "" {
+			endLine = file.Line(pos + token.Pos(len(lit)) - 1)
+		}
+
+		if tok == token.COMMENT {
+			for l := startLine; l <= endLine; l++ {
+				comment[l] = true
+			}
+			continue
+		}
+		for l := startLine; l <= endLine; l++ {
+			code[l] = true
+		}
+
+		text := lit
+		if text == "" {
+			text = tok.String()
+		}
+		tokens = append(tokens, hashToken(text))
+	}
+
+	var counts LineCounts
+	counts.Total = file.LineCount()
+	if len(src) > 0 && src[len(src)-1] == '\n' {
+		// the scanner counts the empty line after the final newline
+		counts.Total--
+	}
+	for l := 1; l <= counts.Total; l++ {
+		switch {
+		case code[l]:
+			counts.Code++
+		case comment[l]:
+			counts.Comments++
+		default:
+			counts.Blank++
+		}
+	}
+	return counts, tokens
+}
+
+// cyclomatic computes McCabe complexity: 1 + decision points
+func cyclomatic(fn *ast.FuncDecl) int {
+	complexity := 1
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			complexity++
+		case *ast.CaseClause:
+			if x.List != nil {
+				complexity++
+			}
+		case *ast.CommClause:
+			if x.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if x.Op == token.LAND || x.Op == token.LOR {
+				complexity++
+			}
+		}
+		return true
+	})
+	return complexity
+}
+
+// goHalsteadVolume computes the Halstead volume of a Go source fragment
+func goHalsteadVolume(src []byte) float64 {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+
+	h := newHalstead()
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		switch {
+		case tok == token.SEMICOLON && lit == "\n":
+			continue
+		case tok.IsLiteral():
+			h.operand(lit)
+		case tok.IsOperator(), tok.IsKeyword():
+			h.operator(tok.String())
+		}
+	}
+	return h.volume()
+}
+
+// funcName returns "Recv.Name" for methods and "Name" for functions
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	expr := fn.Recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+			continue
+		case *ast.IndexExpr:
+			expr = t.X
+			continue
+		case *ast.IndexListExpr:
+			expr = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + fn.Name.Name
+		}
+		return fn.Name.Name
+	}
+}
+
+// hashToken hashes a token for the duplication windows
+func hashToken(text string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(text))
+	return h.Sum64()
+}
@@ -0,0 +1,105 @@
+package codestats
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// maxExtractBytes bounds a single extracted file, protecting the temp dir from huge blobs
+const maxExtractBytes = 8 << 20
+
+// DownloadSnapshot downloads the repository tarball at ref (HEAD when empty) and extracts it
+// into a temporary directory. The caller must invoke cleanup when done.
+func DownloadSnapshot(ctx context.Context, cli *github.Client, owner, repo, ref string) (dir string, cleanup func(), err error) {
+	if cli == nil {
+		return "", nil, fmt.Errorf("GitHub client is nil")
+	}
+
+	link, _, err := cli.Repositories.GetArchiveLink(ctx, owner, repo, github.Tarball, &github.RepositoryContentGetOptions{Ref: ref}, 3)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get tarball link: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build tarball request: %w", err)
+	}
+	resp, err := cli.Client().Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download tarball: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("failed to download tarball: unexpected status %s", resp.Status)
+	}
+
+	dir, err = os.MkdirTemp("", "ghbex-snapshot-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	cleanup = func() { _ = os.RemoveAll(dir) }
+
+	if err := extractTarGz(resp.Body, dir); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return dir, cleanup, nil
+}
+
+// extractTarGz extracts regular files from a GitHub tarball, dropping its top-level directory
+func extractTarGz(r io.Reader, dest string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to open tarball: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tarball: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > maxExtractBytes {
+			continue
+		}
+
+		// GitHub tarballs wrap everything in "<owner>-<repo>-<sha>/"
+		_, rel, ok := strings.Cut(hdr.Name, "/")
+		if !ok || rel == "" {
+			continue
+		}
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+		if !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+			continue // path traversal
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		_, copyErr := io.Copy(f, io.LimitReader(tr, maxExtractBytes))
+		closeErr := f.Close()
+		if copyErr != nil {
+			return fmt.Errorf("failed to extract %s: %w", rel, copyErr)
+		}
+		if closeErr != nil {
+			return fmt.Errorf("failed to extract %s: %w", rel, closeErr)
+		}
+	}
+}
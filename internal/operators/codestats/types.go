@@ -0,0 +1,60 @@
+package codestats
+
+// Options controls snapshot analysis
+type Options struct {
+	// Ref is the git ref to download; empty means the default branch HEAD
+	Ref string `json:"ref,omitempty"`
+	// MaxFileBytes skips files larger than this (generated code, bundles)
+	MaxFileBytes int64 `json:"max_file_bytes"`
+	// DuplicationWindow is the number of consecutive tokens that make a duplicate
+	DuplicationWindow int `json:"duplication_window"`
+	// TopFunctions is how many of the most complex functions are reported
+	TopFunctions int `json:"top_functions"`
+}
+
+// LineCounts holds physical line counts
+type LineCounts struct {
+	Total    int `json:"total"`
+	Code     int `json:"code"`
+	Comments int `json:"comments"`
+	Blank    int `json:"blank"`
+}
+
+// FunctionStat describes a single Go function
+type FunctionStat struct {
+	File                 string  `json:"file"`
+	Name                 string  `json:"name"`
+	Line                 int     `json:"line"`
+	Cyclomatic           int     `json:"cyclomatic"`
+	Lines                int     `json:"lines"`
+	HalsteadVolume       float64 `json:"halstead_volume"`
+	MaintainabilityIndex float64 `json:"maintainability_index"`
+}
+
+// LanguageStats aggregates metrics for one language
+type LanguageStats struct {
+	Files                int        `json:"files"`
+	Lines                LineCounts `json:"lines"`
+	Functions            int        `json:"functions"`
+	CyclomaticAvg        float64    `json:"cyclomatic_avg"`
+	MaintainabilityIndex float64    `json:"maintainability_index"`
+	// Exact is false when metrics come from the line-based fallback
+	Exact bool `json:"exact"`
+}
+
+// Report is the static analysis of a source snapshot
+type Report struct {
+	Owner                string                    `json:"owner,omitempty"`
+	Repo                 string                    `json:"repo,omitempty"`
+	Ref                  string                    `json:"ref,omitempty"`
+	Files                int                       `json:"files"`
+	Lines                LineCounts                `json:"lines"`
+	Languages            map[string]*LanguageStats `json:"languages"`
+	Functions            int                       `json:"functions"`
+	CyclomaticAvg        float64                   `json:"cyclomatic_avg"`
+	CyclomaticMax        int                       `json:"cyclomatic_max"`
+	DuplicationPct       float64                   `json:"duplication_pct"`
+	MaintainabilityIndex float64                   `json:"maintainability_index"`
+	CHI                  float64                   `json:"chi"`
+	TopComplex           []FunctionStat            `json:"top_complex"`
+}
@@ -0,0 +1,60 @@
+package conventional
+
+import "sort"
+
+// maxOffenses bounds the offending commits kept in a compliance report
+const maxOffenses = 50
+
+// ComputeCompliance measures how many non-merge commits follow the convention
+func ComputeCompliance(commits []Commit) Compliance {
+	c := Compliance{
+		ByType:           make(map[string]int),
+		Offenses:         []Offense{},
+		OffendingAuthors: make(map[string]int),
+	}
+	for _, cm := range commits {
+		if cm.Merge {
+			continue
+		}
+		c.Total++
+		if cm.Breaking {
+			c.Breaking++
+		}
+		if cm.Valid {
+			c.Compliant++
+			c.ByType[cm.Type]++
+			continue
+		}
+		c.OffendingAuthors[cm.Author]++
+		if len(c.Offenses) < maxOffenses {
+			c.Offenses = append(c.Offenses, Offense{
+				SHA:     cm.SHA,
+				Author:  cm.Author,
+				Header:  cm.Header,
+				Problem: cm.Problem,
+			})
+		}
+	}
+	if c.Total > 0 {
+		c.Percentage = float64(c.Compliant) / float64(c.Total) * 100
+	}
+	return c
+}
+
+// TopOffenders returns the authors with most non-compliant commits
+func (c Compliance) TopOffenders(limit int) []string {
+	authors := make([]string, 0, len(c.OffendingAuthors))
+	for a := range c.OffendingAuthors {
+		authors = append(authors, a)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if c.OffendingAuthors[authors[i]] != c.OffendingAuthors[authors[j]] {
+			return c.OffendingAuthors[authors[i]] > c.OffendingAuthors[authors[j]]
+		}
+		return authors[i] < authors[j]
+	})
+	if limit > 0 && len(authors) > limit {
+		authors = authors[:limit]
+	}
+	return authors
+}
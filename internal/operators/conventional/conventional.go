@@ -0,0 +1,153 @@
+// Package conventional parses Conventional Commits messages, measures compliance
+// and generates changelog sections between two refs.
+package conventional
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// maxCompareCommits bounds the commits read from a compare range
+const maxCompareCommits = 1000
+
+// DefaultOptions returns the options used by the changelog command
+func DefaultOptions() Options {
+	return Options{ResolvePullRequests: true, MaxLookups: 100}
+}
+
+// ListRange lists and parses the commits reachable from "to" but not from "from".
+// "HEAD" (or an empty "to") resolves to the default branch; an empty "from" lists the
+// history of "to", for repositories without a previous release.
+func ListRange(ctx context.Context, cli *github.Client, owner, repo, from, to string) ([]Commit, string, error) {
+	head, err := resolveHead(ctx, cli, owner, repo, to)
+	if err != nil {
+		return nil, "", err
+	}
+	if from == "" {
+		commits, err := listHistory(ctx, cli, owner, repo, head)
+		return commits, head, err
+	}
+
+	var commits []Commit
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		cmp, resp, err := cli.Repositories.CompareCommits(ctx, owner, repo, from, head, opt)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to compare %s...%s: %w", from, head, err)
+		}
+		for _, rc := range cmp.Commits {
+			commits = append(commits, fromRepositoryCommit(rc))
+		}
+		if resp.NextPage == 0 || len(commits) >= maxCompareCommits {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return commits, head, nil
+}
+
+// Generate builds the changelog of the range from...to
+func Generate(ctx context.Context, cli *github.Client, owner, repo, from, to string, opts Options) (*Changelog, error) {
+	commits, head, err := ListRange(ctx, cli, owner, repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ResolvePullRequests {
+		resolvePullRequests(ctx, cli, owner, repo, commits, opts)
+	}
+	if to == "" {
+		to = head
+	}
+	return Build(owner, repo, from, to, commits, opts, time.Now()), nil
+}
+
+// Build groups parsed commits into changelog sections
+func Build(owner, repo, from, to string, commits []Commit, opts Options, now time.Time) *Changelog {
+	cl := &Changelog{
+		Owner:      owner,
+		Repo:       repo,
+		From:       from,
+		To:         to,
+		Version:    to,
+		Date:       now,
+		Breaking:   []Entry{},
+		Sections:   []Section{},
+		Compliance: ComputeCompliance(commits),
+	}
+
+	grouped := make(map[string][]Entry)
+	for _, c := range commits {
+		if !c.Valid {
+			continue
+		}
+		entry := Entry{Scope: c.Scope, Subject: c.Subject, SHA: c.SHA, PRNumber: c.PRNumber, Author: c.Author}
+		if c.Breaking {
+			b := entry
+			b.Subject = c.BreakingNote
+			cl.Breaking = append(cl.Breaking, b)
+		}
+		if opts.AllTypes || IsReleaseType(c.Type) {
+			grouped[c.Type] = append(grouped[c.Type], entry)
+		}
+	}
+
+	sortEntries(cl.Breaking)
+	for _, t := range Types {
+		entries := grouped[t]
+		if len(entries) == 0 {
+			continue
+		}
+		sortEntries(entries)
+		cl.Sections = append(cl.Sections, Section{Type: t, Title: TypeTitle(t), Entries: entries})
+	}
+	return cl
+}
+
+// ToMarkdown renders the changelog as a CHANGELOG.md section
+func ToMarkdown(cl *Changelog) string {
+	base := fmt.Sprintf("https://github.com/%s/%s", cl.Owner, cl.Repo)
+	var b strings.Builder
+
+	link := fmt.Sprintf("%s/compare/%s...%s", base, cl.From, cl.To)
+	if cl.From == "" {
+		link = fmt.Sprintf("%s/tree/%s", base, cl.To)
+	}
+	fmt.Fprintf(&b, "## [%s](%s) (%s)\n\n", cl.Version, link, cl.Date.Format("2006-01-02"))
+	if len(cl.Breaking) > 0 {
+		b.WriteString("### ⚠ BREAKING CHANGES\n\n")
+		for _, e := range cl.Breaking {
+			writeEntry(&b, base, e)
+		}
+		b.WriteString("\n")
+	}
+	for _, s := range cl.Sections {
+		fmt.Fprintf(&b, "### %s\n\n", s.Title)
+		for _, e := range s.Entries {
+			writeEntry(&b, base, e)
+		}
+		b.WriteString("\n")
+	}
+	if len(cl.Breaking) == 0 && len(cl.Sections) == 0 {
+		b.WriteString("_No notable changes._\n\n")
+	}
+	return b.String()
+}
+
+func writeEntry(b *strings.Builder, base string, e Entry) {
+	b.WriteString("* ")
+	if e.Scope != "" {
+		fmt.Fprintf(b, "**%s:** ", e.Scope)
+	}
+	b.WriteString(e.Subject)
+	if e.PRNumber > 0 {
+		fmt.Fprintf(b, " ([#%d](%s/pull/%d))", e.PRNumber, base, e.PRNumber)
+	}
+	if e.SHA != "" {
+		fmt.Fprintf(b, " ([%s](%s/commit/%s))", shortSHA(e.SHA), base, e.SHA)
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,135 @@
+package conventional
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Types lists the accepted commit types in changelog order
+var Types = []string{"feat", "fix", "perf", "revert", "docs", "refactor", "test", "build", "ci", "style", "chore"}
+
+// releaseTypes are the types shown in a changelog unless Options.AllTypes is set
+var releaseTypes = map[string]bool{"feat": true, "fix": true, "perf": true, "revert": true}
+
+var typeTitles = map[string]string{
+	"feat":     "Features",
+	"fix":      "Bug Fixes",
+	"perf":     "Performance Improvements",
+	"revert":   "Reverts",
+	"docs":     "Documentation",
+	"refactor": "Code Refactoring",
+	"test":     "Tests",
+	"build":    "Build System",
+	"ci":       "Continuous Integration",
+	"style":    "Styles",
+	"chore":    "Chores",
+}
+
+var (
+	headerRe      = regexp.MustCompile(`^(\w+)(?:\(([^()\r\n]*)\))?(!)?: (.*)$`)
+	footerRe      = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][\w-]*)(?:: | #)(.*)$`)
+	prSuffixRe    = regexp.MustCompile(`\(#(\d+)\)\s*$`)
+	mergePRRe     = regexp.MustCompile(`^Merge pull request #(\d+)`)
+	gitRevertRe   = regexp.MustCompile(`^Revert "(.*)"$`)
+	mergeHeaderRe = regexp.MustCompile(`^Merge (branch|remote-tracking branch|pull request|tag) `)
+)
+
+// Parse parses a commit message. Merge commits are flagged and never valid.
+func Parse(message string) Commit {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	header, rest, _ := strings.Cut(message, "\n")
+	header = strings.TrimSpace(header)
+	c := Commit{Header: header}
+
+	if m := mergePRRe.FindStringSubmatch(header); m != nil {
+		c.PRNumber, _ = strconv.Atoi(m[1])
+	}
+	if mergeHeaderRe.MatchString(header) {
+		c.Merge = true
+		c.Problem = "merge commit"
+		return c
+	}
+
+	if m := gitRevertRe.FindStringSubmatch(header); m != nil {
+		c.Type = "revert"
+		c.Subject = m[1]
+		c.Valid = true
+	} else if m := headerRe.FindStringSubmatch(header); m != nil {
+		c.Type = strings.ToLower(m[1])
+		c.Scope = strings.TrimSpace(m[2])
+		c.Breaking = m[3] == "!"
+		c.Subject = strings.TrimSpace(m[4])
+		c.Valid, c.Problem = validate(c)
+	} else {
+		c.Problem = "header is not \"type(scope): subject\""
+	}
+
+	if m := prSuffixRe.FindStringSubmatch(c.Subject); m != nil {
+		c.PRNumber, _ = strconv.Atoi(m[1])
+		c.Subject = strings.TrimSpace(prSuffixRe.ReplaceAllString(c.Subject, ""))
+	}
+
+	c.Body, c.Footers = splitFooters(strings.Trim(rest, "\n"))
+	for _, key := range []string{"BREAKING CHANGE", "BREAKING-CHANGE"} {
+		if notes := c.Footers[key]; len(notes) > 0 {
+			c.Breaking = true
+			c.BreakingNote = strings.Join(notes, "\n")
+		}
+	}
+	if c.Breaking && c.BreakingNote == "" {
+		c.BreakingNote = c.Subject
+	}
+	return c
+}
+
+// IsReleaseType reports whether commits of this type appear in a default changelog
+func IsReleaseType(t string) bool {
+	return releaseTypes[t]
+}
+
+// TypeTitle returns the changelog heading of a commit type
+func TypeTitle(t string) string {
+	if title, ok := typeTitles[t]; ok {
+		return title
+	}
+	return strings.ToUpper(t[:1]) + t[1:]
+}
+
+func validate(c Commit) (bool, string) {
+	if _, ok := typeTitles[c.Type]; !ok {
+		return false, "unknown type \"" + c.Type + "\""
+	}
+	if c.Subject == "" {
+		return false, "empty subject"
+	}
+	return true, ""
+}
+
+// splitFooters separates the trailing footer paragraph (key: value lines) from the body
+func splitFooters(text string) (string, map[string][]string) {
+	if text == "" {
+		return "", nil
+	}
+	paragraphs := strings.Split(text, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+
+	footers := make(map[string][]string)
+	var key string
+	for _, line := range strings.Split(last, "\n") {
+		if m := footerRe.FindStringSubmatch(line); m != nil {
+			key = m[1]
+			footers[key] = append(footers[key], strings.TrimSpace(m[2]))
+			continue
+		}
+		if key == "" {
+			// the last paragraph is not a footer block
+			return strings.TrimSpace(text), nil
+		}
+		// continuation of a multi-line footer value
+		values := footers[key]
+		values[len(values)-1] = strings.TrimSpace(values[len(values)-1] + "\n" + line)
+	}
+	body := strings.TrimSpace(strings.Join(paragraphs[:len(paragraphs)-1], "\n\n"))
+	return body, footers
+}
@@ -0,0 +1,101 @@
+package conventional
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v61/github"
+)
+
+func resolveHead(ctx context.Context, cli *github.Client, owner, repo, to string) (string, error) {
+	if to != "" && !strings.EqualFold(to, "HEAD") {
+		return to, nil
+	}
+	r, _, err := cli.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch of %s/%s: %w", owner, repo, err)
+	}
+	return r.GetDefaultBranch(), nil
+}
+
+func listHistory(ctx context.Context, cli *github.Client, owner, repo, ref string) ([]Commit, error) {
+	var commits []Commit
+	opt := &github.CommitsListOptions{SHA: ref, ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := cli.Repositories.ListCommits(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of %s: %w", ref, err)
+		}
+		for _, rc := range page {
+			commits = append(commits, fromRepositoryCommit(rc))
+		}
+		if resp.NextPage == 0 || len(commits) >= maxCompareCommits {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return commits, nil
+}
+
+func fromRepositoryCommit(rc *github.RepositoryCommit) Commit {
+	c := Parse(rc.GetCommit().GetMessage())
+	c.SHA = rc.GetSHA()
+	c.AuthorName = rc.GetCommit().GetAuthor().GetName()
+	c.AuthorEmail = rc.GetCommit().GetAuthor().GetEmail()
+	c.AuthorLogin = rc.GetAuthor().GetLogin()
+	c.Author = c.AuthorLogin
+	if c.Author == "" {
+		c.Author = c.AuthorName
+	}
+	c.Date = rc.GetCommit().GetAuthor().GetDate().Time
+	if len(rc.Parents) > 1 {
+		c.Merge = true
+		c.Valid = false
+		c.Problem = "merge commit"
+	}
+	return c
+}
+
+// resolvePullRequests fills the pull request of squash/rebase commits without a (#N) suffix
+func resolvePullRequests(ctx context.Context, cli *github.Client, owner, repo string, commits []Commit, opts Options) {
+	lookups := 0
+	for i := range commits {
+		c := &commits[i]
+		if c.PRNumber > 0 || !c.Valid || c.SHA == "" {
+			continue
+		}
+		if opts.MaxLookups > 0 && lookups >= opts.MaxLookups {
+			return
+		}
+		lookups++
+		prs, _, err := cli.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, c.SHA, &github.ListOptions{PerPage: 5})
+		if err != nil {
+			continue
+		}
+		for _, pr := range prs {
+			if pr.MergedAt != nil {
+				c.PRNumber = pr.GetNumber()
+				break
+			}
+		}
+	}
+}
+
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		// unscoped entries last, like conventional-changelog
+		if (entries[i].Scope == "") != (entries[j].Scope == "") {
+			return entries[j].Scope == ""
+		}
+		return entries[i].Scope < entries[j].Scope
+	})
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
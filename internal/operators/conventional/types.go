@@ -0,0 +1,83 @@
+package conventional
+
+import "time"
+
+// Commit is a commit message parsed according to the Conventional Commits specification
+type Commit struct {
+	SHA          string              `json:"sha"`
+	Author       string              `json:"author"` // Login, or commit author name when unknown
+	AuthorLogin  string              `json:"-"`
+	AuthorName   string              `json:"-"`
+	AuthorEmail  string              `json:"-"`
+	Date         time.Time           `json:"date"`
+	Header       string              `json:"header"`
+	Type         string              `json:"type,omitempty"`
+	Scope        string              `json:"scope,omitempty"`
+	Subject      string              `json:"subject,omitempty"`
+	Body         string              `json:"body,omitempty"`
+	Footers      map[string][]string `json:"footers,omitempty"`
+	Breaking     bool                `json:"breaking"`
+	BreakingNote string              `json:"breaking_note,omitempty"`
+	PRNumber     int                 `json:"pr_number,omitempty"`
+	Merge        bool                `json:"merge"` // Merge commits are not required to be conventional
+	Valid        bool                `json:"valid"`
+	Problem      string              `json:"problem,omitempty"`
+}
+
+// Offense is a commit that does not follow the convention
+type Offense struct {
+	SHA     string `json:"sha"`
+	Author  string `json:"author"`
+	Header  string `json:"header"`
+	Problem string `json:"problem"`
+}
+
+// Compliance is the Conventional Commits compliance of a set of commits
+type Compliance struct {
+	Total            int            `json:"total"` // Merge commits excluded
+	Compliant        int            `json:"compliant"`
+	Percentage       float64        `json:"percentage"`
+	ByType           map[string]int `json:"by_type"`
+	Breaking         int            `json:"breaking"`
+	Offenses         []Offense      `json:"offenses"`
+	OffendingAuthors map[string]int `json:"offending_authors"`
+}
+
+// Options controls which commits appear in a changelog
+type Options struct {
+	// AllTypes includes docs, style, refactor, test, build, ci and chore sections
+	AllTypes bool
+	// ResolvePullRequests looks up the pull request of commits that do not reference one
+	ResolvePullRequests bool
+	// MaxLookups bounds the pull request lookups (one API call each)
+	MaxLookups int
+}
+
+// Entry is one line of a changelog section
+type Entry struct {
+	Scope    string `json:"scope,omitempty"`
+	Subject  string `json:"subject"`
+	SHA      string `json:"sha"`
+	PRNumber int    `json:"pr_number,omitempty"`
+	Author   string `json:"author,omitempty"`
+}
+
+// Section groups the entries of one commit type
+type Section struct {
+	Type    string  `json:"type"`
+	Title   string  `json:"title"`
+	Entries []Entry `json:"entries"`
+}
+
+// Changelog is the list of changes between two refs
+type Changelog struct {
+	Owner      string     `json:"owner"`
+	Repo       string     `json:"repo"`
+	From       string     `json:"from"`
+	To         string     `json:"to"`
+	Version    string     `json:"version"` // Heading of the section, the tag when known
+	Date       time.Time  `json:"date"`
+	Breaking   []Entry    `json:"breaking"`
+	Sections   []Section  `json:"sections"`
+	Compliance Compliance `json:"compliance"`
+}
@@ -0,0 +1,245 @@
+// Package deps parses dependency manifests and lockfiles into a normalized inventory
+// and matches it against an offline OSV vulnerability database.
+package deps
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v61/github"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// maxManifests caps the manifest files fetched per repository
+const maxManifests = 50
+
+// sourcePriority ranks manifests: resolved lockfiles win over declarations
+var sourcePriority = map[string]int{
+	"go.mod":            3,
+	"go.sum":            1,
+	"package-lock.json": 3,
+	"package.json":      1,
+	"poetry.lock":       3,
+	"requirements.txt":  2,
+	"pyproject.toml":    0,
+	"Cargo.lock":        3,
+	"Cargo.toml":        0,
+	"pom.xml":           3,
+}
+
+// AnalyzeRepository fetches the supported manifests from the default branch,
+// builds the dependency inventory and matches it against db (which may be nil)
+func AnalyzeRepository(ctx context.Context, cli *github.Client, owner, repo string, db *Database) (*Report, error) {
+	files, err := FetchManifests(ctx, cli, owner, repo, "")
+	if err != nil {
+		return nil, err
+	}
+	report := Analyze(files, db)
+	report.Owner = owner
+	report.Repo = repo
+	return report, nil
+}
+
+// FetchManifests downloads every supported manifest in the repository tree at ref
+// (default branch when empty), skipping vendored and test fixture directories. Manifests are
+// read as git blobs, so lockfiles over the 1 MB limit of the contents API are included.
+func FetchManifests(ctx context.Context, cli *github.Client, owner, repo, ref string) (map[string][]byte, error) {
+	if cli == nil {
+		return nil, fmt.Errorf("GitHub client is nil")
+	}
+	if ref == "" {
+		repository, _, err := cli.Repositories.Get(ctx, owner, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository: %w", err)
+		}
+		ref = repository.GetDefaultBranch()
+	}
+
+	tree, _, err := cli.Git.GetTree(ctx, owner, repo, ref, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository tree: %w", err)
+	}
+	if tree.GetTruncated() {
+		gl.Log("warn", fmt.Sprintf("The tree of %s/%s at %s is too large to list in full, some manifests may be missing", owner, repo, ref))
+	}
+
+	files := make(map[string][]byte)
+	for _, entry := range tree.Entries {
+		p := entry.GetPath()
+		if entry.GetType() != "blob" || !IsManifest(p) || isVendored(p) {
+			continue
+		}
+		if len(files) >= maxManifests {
+			gl.Log("warn", fmt.Sprintf("More than %d manifests in %s/%s, ignoring the rest", maxManifests, owner, repo))
+			break
+		}
+		data, _, err := cli.Git.GetBlobRaw(ctx, owner, repo, entry.GetSHA())
+		if err != nil {
+			gl.Log("warn", fmt.Sprintf("Skipping manifest %s of %s/%s: %v", p, owner, repo, err))
+			continue
+		}
+		files[p] = data
+	}
+	return files, nil
+}
+
+// Analyze builds the dependency inventory from manifest contents keyed by path
+func Analyze(files map[string][]byte, db *Database) *Report {
+	report := &Report{
+		Manifests:      []string{},
+		Dependencies:   []Dependency{},
+		ByEcosystem:    make(map[string]int),
+		Vulnerable:     []VulnerableDependency{},
+		BySeverity:     make(map[string]int),
+		DatabaseLoaded: db != nil && db.Len() > 0,
+	}
+
+	var all []Dependency
+	for file, data := range files {
+		found, err := ParseManifest(file, data)
+		if err != nil {
+			report.Notes = append(report.Notes, err.Error())
+			continue
+		}
+		report.Manifests = append(report.Manifests, file)
+		all = append(all, found...)
+	}
+	sort.Strings(report.Manifests)
+
+	report.Dependencies = mergeDependencies(all)
+	for _, d := range report.Dependencies {
+		report.ByEcosystem[d.Ecosystem]++
+		if d.Direct {
+			report.Direct++
+		} else {
+			report.Transitive++
+		}
+
+		vulns := db.Match(d)
+		if len(vulns) == 0 {
+			continue
+		}
+		for _, v := range vulns {
+			report.BySeverity[v.Severity]++
+		}
+		report.Vulnerable = append(report.Vulnerable, VulnerableDependency{
+			Dependency:      d,
+			Vulnerabilities: vulns,
+			FixedVersion:    minimalFix(d.Version, vulns),
+		})
+	}
+
+	if !report.DatabaseLoaded {
+		report.Notes = append(report.Notes, "no OSV database loaded; vulnerability matching skipped")
+	}
+	return report
+}
+
+// CriticalUpdates returns "name@version" upgrades that fix critical or high advisories
+func (r *Report) CriticalUpdates() []string {
+	var updates []string
+	for _, v := range r.Vulnerable {
+		if v.FixedVersion == "" {
+			continue
+		}
+		for _, vuln := range v.Vulnerabilities {
+			if vuln.Severity == "critical" || vuln.Severity == "high" {
+				updates = append(updates, fmt.Sprintf("%s@%s", v.Dependency.Name, v.FixedVersion))
+				break
+			}
+		}
+	}
+	return updates
+}
+
+// mergeDependencies collapses duplicates across manifests.
+// For each package the versions of the highest-priority source are kept, and the
+// package is direct when any manifest declares it directly.
+func mergeDependencies(all []Dependency) []Dependency {
+	type group struct {
+		direct   bool
+		priority int
+		versions map[string]Dependency
+	}
+	groups := make(map[string]*group)
+	var order []string
+
+	for _, d := range all {
+		key := d.Ecosystem + "|" + packageKey(d.Ecosystem, d.Name)
+		g := groups[key]
+		if g == nil {
+			g = &group{priority: -1, versions: make(map[string]Dependency)}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.direct = g.direct || d.Direct
+
+		prio := sourcePriority[path.Base(d.Manifest)]
+		if d.Version == "" {
+			prio = -1 // declarations without a version only contribute the direct flag
+		}
+		switch {
+		case prio > g.priority:
+			g.priority = prio
+			g.versions = map[string]Dependency{d.Version: d}
+		case prio == g.priority:
+			if _, ok := g.versions[d.Version]; !ok {
+				g.versions[d.Version] = d
+			}
+		}
+	}
+
+	var merged []Dependency
+	for _, key := range order {
+		g := groups[key]
+		for _, d := range g.versions {
+			d.Direct = g.direct
+			merged = append(merged, d)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Ecosystem != merged[j].Ecosystem {
+			return merged[i].Ecosystem < merged[j].Ecosystem
+		}
+		if merged[i].Name != merged[j].Name {
+			return merged[i].Name < merged[j].Name
+		}
+		return compareVersions(merged[i].Version, merged[j].Version) < 0
+	})
+	return merged
+}
+
+// minimalFix returns the lowest fixed version above current that resolves every advisory
+func minimalFix(current string, vulns []Vulnerability) string {
+	best := ""
+	for _, v := range vulns {
+		fix := ""
+		for _, f := range v.Fixed {
+			if compareVersions(f, current) > 0 && (fix == "" || compareVersions(f, fix) < 0) {
+				fix = f
+			}
+		}
+		if fix == "" {
+			return "" // at least one advisory has no fix yet
+		}
+		if best == "" || compareVersions(fix, best) > 0 {
+			best = fix
+		}
+	}
+	return best
+}
+
+// isVendored reports whether a path lives in a vendored or fixture directory
+func isVendored(p string) bool {
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		switch dir {
+		case "vendor", "node_modules", "testdata", "third_party":
+			return true
+		}
+	}
+	return false
+}
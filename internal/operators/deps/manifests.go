@@ -0,0 +1,496 @@
+package deps
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// manifestParsers maps a manifest file name to its parser
+var manifestParsers = map[string]func(file string, data []byte) ([]Dependency, error){
+	"go.mod":            parseGoMod,
+	"go.sum":            parseGoSum,
+	"package.json":      parsePackageJSON,
+	"package-lock.json": parsePackageLock,
+	"requirements.txt":  parseRequirements,
+	"poetry.lock":       parsePoetryLock,
+	"pyproject.toml":    parsePyProject,
+	"Cargo.toml":        parseCargoToml,
+	"Cargo.lock":        parseCargoLock,
+	"pom.xml":           parsePom,
+}
+
+// IsManifest reports whether a file name is a supported manifest or lockfile
+func IsManifest(name string) bool {
+	_, ok := manifestParsers[path.Base(name)]
+	return ok
+}
+
+// ParseManifest parses a single manifest or lockfile by its file name
+func ParseManifest(file string, data []byte) ([]Dependency, error) {
+	parser, ok := manifestParsers[path.Base(file)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported manifest: %s", file)
+	}
+	return parser(file, data)
+}
+
+var (
+	goRequireLine = regexp.MustCompile(`^([^\s]+)\s+([^\s]+)(\s*//\s*indirect)?`)
+	requirement   = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*(==|>=|~=|<=|>|<|!=)?\s*([^\s;,#]*)`)
+	tomlKeyValue  = regexp.MustCompile(`^([A-Za-z0-9_.-]+)\s*=\s*"([^"]*)"`)
+	tomlDepKey    = regexp.MustCompile(`^"?([A-Za-z0-9_.-]+)"?\s*=`)
+	tomlFileHash  = regexp.MustCompile(`hash\s*=\s*"([a-z0-9]+:[0-9a-fA-F]+)"`)
+	npmExact      = regexp.MustCompile(`^=?\s*v?(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?)$`)
+)
+
+// parseGoMod reads require directives; "// indirect" marks transitive modules
+func parseGoMod(file string, data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	inRequire := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "require (":
+			inRequire = true
+			continue
+		case inRequire && line == ")":
+			inRequire = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inRequire:
+			continue
+		}
+
+		m := goRequireLine.FindStringSubmatch(line)
+		if m == nil || strings.HasPrefix(m[1], "//") {
+			continue
+		}
+		deps = append(deps, Dependency{
+			Name:      m[1],
+			Version:   m[2],
+			Ecosystem: EcosystemGo,
+			Direct:    m[3] == "",
+			Manifest:  file,
+		})
+	}
+	return deps, scanner.Err()
+}
+
+// parseGoSum lists the modules whose content is checksummed in go.sum as transitive.
+// "/go.mod"-only lines belong to versions that took part in resolution but are not built.
+func parseGoSum(file string, data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		version := fields[1]
+		key := fields[0] + "@" + version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deps = append(deps, Dependency{
+			Name:      fields[0],
+			Version:   version,
+			Ecosystem: EcosystemGo,
+			Manifest:  file,
+		})
+	}
+	return deps, scanner.Err()
+}
+
+// parsePackageJSON reads direct npm dependencies. Only exact specs carry a version; ranges,
+// tags and URLs are left empty so they never pass as a pinned version
+func parsePackageJSON(file string, data []byte) ([]Dependency, error) {
+	var pkg struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	var deps []Dependency
+	for _, set := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.OptionalDependencies} {
+		for name, spec := range set {
+			version := ""
+			if m := npmExact.FindStringSubmatch(strings.TrimSpace(spec)); m != nil {
+				version = m[1]
+			}
+			deps = append(deps, Dependency{
+				Name:      name,
+				Version:   version,
+				Ecosystem: EcosystemNPM,
+				Direct:    true,
+				Manifest:  file,
+			})
+		}
+	}
+	return deps, nil
+}
+
+// parsePackageLock reads resolved npm versions (lockfile v1, v2 and v3)
+func parsePackageLock(file string, data []byte) ([]Dependency, error) {
+	type lockDep struct {
+		Version      string             `json:"version"`
+		Integrity    string             `json:"integrity"`
+		Dependencies map[string]lockDep `json:"dependencies"`
+	}
+	var lock struct {
+		Packages map[string]struct {
+			Version   string          `json:"version"`
+			Dev       bool            `json:"dev"`
+			Integrity string          `json:"integrity"`
+			License   json.RawMessage `json:"license"`
+			// Declared by the root package ("") only
+			Dependencies         map[string]string `json:"dependencies"`
+			DevDependencies      map[string]string `json:"devDependencies"`
+			OptionalDependencies map[string]string `json:"optionalDependencies"`
+		} `json:"packages"`
+		Dependencies map[string]lockDep `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	var deps []Dependency
+	if len(lock.Packages) > 0 {
+		// npm hoists transitive packages to the top-level node_modules too, so only the
+		// packages the root package declares are direct
+		root := lock.Packages[""]
+		declared := make(map[string]bool)
+		for _, set := range []map[string]string{root.Dependencies, root.DevDependencies, root.OptionalDependencies} {
+			for name := range set {
+				declared[name] = true
+			}
+		}
+		for key, p := range lock.Packages {
+			idx := strings.LastIndex(key, "node_modules/")
+			if idx < 0 || p.Version == "" {
+				continue // root package or link
+			}
+			name := key[idx+len("node_modules/"):]
+			deps = append(deps, Dependency{
+				Name:      name,
+				Version:   p.Version,
+				Ecosystem: EcosystemNPM,
+				Direct:    declared[name] && key == "node_modules/"+name,
+				Manifest:  file,
+				License:   npmLicense(p.License),
+				Hashes:    sriHashes(p.Integrity),
+			})
+		}
+		return deps, nil
+	}
+
+	var walk func(set map[string]lockDep, direct bool)
+	walk = func(set map[string]lockDep, direct bool) {
+		for name, d := range set {
+			deps = append(deps, Dependency{
+				Name:      name,
+				Version:   d.Version,
+				Ecosystem: EcosystemNPM,
+				Direct:    direct,
+				Manifest:  file,
+				Hashes:    sriHashes(d.Integrity),
+			})
+			walk(d.Dependencies, false)
+		}
+	}
+	walk(lock.Dependencies, true)
+	return deps, nil
+}
+
+// parseRequirements reads pip requirement specifiers; only "==" pins carry a version
+func parseRequirements(file string, data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		m := requirement.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		version := ""
+		if m[3] == "==" {
+			version = m[4]
+		}
+		deps = append(deps, Dependency{
+			Name:      normalizePyPIName(m[1]),
+			Version:   version,
+			Ecosystem: EcosystemPyPI,
+			Direct:    true,
+			Manifest:  file,
+		})
+	}
+	return deps, scanner.Err()
+}
+
+// parsePoetryLock reads [[package]] tables; poetry does not record which are direct
+func parsePoetryLock(file string, data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	for _, pkg := range tomlPackages(data) {
+		deps = append(deps, Dependency{
+			Name:      normalizePyPIName(pkg["name"]),
+			Version:   pkg["version"],
+			Ecosystem: EcosystemPyPI,
+			Manifest:  file,
+			Hashes:    prefixedHash(pkg["hash"]),
+		})
+	}
+	return deps, nil
+}
+
+// parsePyProject reads [tool.poetry.dependencies] names so poetry.lock entries can be flagged direct
+func parsePyProject(file string, data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	for _, name := range tomlTableKeys(data, "tool.poetry.dependencies", "tool.poetry.dev-dependencies", "tool.poetry.group.dev.dependencies") {
+		if name == "python" {
+			continue
+		}
+		deps = append(deps, Dependency{Name: normalizePyPIName(name), Ecosystem: EcosystemPyPI, Direct: true, Manifest: file})
+	}
+	return deps, nil
+}
+
+// parseCargoToml reads dependency names so Cargo.lock entries can be flagged direct
+func parseCargoToml(file string, data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	for _, name := range tomlTableKeys(data, "dependencies", "dev-dependencies", "build-dependencies") {
+		deps = append(deps, Dependency{Name: name, Ecosystem: EcosystemCrates, Direct: true, Manifest: file})
+	}
+	return deps, nil
+}
+
+// parseCargoLock reads [[package]] tables, skipping local workspace crates
+func parseCargoLock(file string, data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	for _, pkg := range tomlPackages(data) {
+		if pkg["source"] == "" {
+			continue // workspace member
+		}
+		deps = append(deps, Dependency{
+			Name:      pkg["name"],
+			Version:   pkg["version"],
+			Ecosystem: EcosystemCrates,
+			Manifest:  file,
+			Hashes:    hexHash("SHA-256", pkg["checksum"]),
+		})
+	}
+	return deps, nil
+}
+
+// parsePom reads <dependencies>, resolving ${property} versions from <properties>
+func parsePom(file string, data []byte) ([]Dependency, error) {
+	var pom struct {
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Version    string `xml:"version"`
+		} `xml:"dependencies>dependency"`
+		DependencyManagement []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Version    string `xml:"version"`
+		} `xml:"dependencyManagement>dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	props := make(map[string]string)
+	for _, p := range pom.Properties.Entries {
+		props[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+	managed := make(map[string]string)
+	for _, d := range pom.DependencyManagement {
+		managed[d.GroupID+":"+d.ArtifactID] = resolvePomProperty(d.Version, props)
+	}
+
+	var deps []Dependency
+	for _, d := range pom.Dependencies {
+		name := d.GroupID + ":" + d.ArtifactID
+		version := resolvePomProperty(d.Version, props)
+		if version == "" {
+			version = managed[name]
+		}
+		deps = append(deps, Dependency{
+			Name:      name,
+			Version:   version,
+			Ecosystem: EcosystemMaven,
+			Direct:    true,
+			Manifest:  file,
+		})
+	}
+	return deps, nil
+}
+
+// resolvePomProperty expands a ${name} reference; unresolved references become ""
+func resolvePomProperty(value string, props map[string]string) string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
+		return props[value[2:len(value)-1]]
+	}
+	return value
+}
+
+// tomlPackages extracts the string fields of every [[package]] table
+func tomlPackages(data []byte) []map[string]string {
+	var packages []map[string]string
+	var current map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			if line == "[[package]]" {
+				current = make(map[string]string)
+				packages = append(packages, current)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if m := tomlKeyValue.FindStringSubmatch(line); m != nil {
+			current[m[1]] = m[2]
+			continue
+		}
+		// poetry lists one hash per distribution file; the first one identifies the package
+		if m := tomlFileHash.FindStringSubmatch(line); m != nil && current["hash"] == "" {
+			current["hash"] = m[1]
+		}
+	}
+	return packages
+}
+
+// npmLicense reads the "license" field, which is a string or a legacy {"type": ...} object
+func npmLicense(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var obj struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(raw, &obj) == nil {
+		return obj.Type
+	}
+	return ""
+}
+
+// sriHashes decodes a Subresource Integrity string ("sha512-<base64>") into hex digests
+func sriHashes(integrity string) map[string]string {
+	hashes := make(map[string]string)
+	for _, sri := range strings.Fields(integrity) {
+		alg, b64, ok := strings.Cut(sri, "-")
+		if !ok {
+			continue
+		}
+		sum, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			continue
+		}
+		if name := hashAlgorithm(alg); name != "" {
+			hashes[name] = hex.EncodeToString(sum)
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	return hashes
+}
+
+// prefixedHash parses "sha256:<hex>" digests
+func prefixedHash(value string) map[string]string {
+	alg, digest, ok := strings.Cut(value, ":")
+	if !ok {
+		return nil
+	}
+	return hexHash(hashAlgorithm(alg), digest)
+}
+
+// hexHash builds a single-entry hash map, ignoring empty values
+func hexHash(alg, digest string) map[string]string {
+	if alg == "" || digest == "" {
+		return nil
+	}
+	return map[string]string{alg: strings.ToLower(digest)}
+}
+
+// hashAlgorithm maps lowercase algorithm names to their CycloneDX names
+func hashAlgorithm(alg string) string {
+	switch strings.ToLower(alg) {
+	case "sha1":
+		return "SHA-1"
+	case "sha256":
+		return "SHA-256"
+	case "sha384":
+		return "SHA-384"
+	case "sha512":
+		return "SHA-512"
+	}
+	return ""
+}
+
+// tomlTableKeys returns the keys declared inside the named TOML tables
+func tomlTableKeys(data []byte, tables ...string) []string {
+	wanted := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		wanted["["+t+"]"] = true
+	}
+
+	var keys []string
+	inTable := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inTable = wanted[line]
+			continue
+		}
+		if !inTable || line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := tomlDepKey.FindStringSubmatch(line); m != nil {
+			keys = append(keys, m[1])
+		}
+	}
+	return keys
+}
+
+// normalizePyPIName applies PEP 503 normalization
+func normalizePyPIName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+}
@@ -0,0 +1,504 @@
+package deps
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// osvEntry is the subset of the OSV schema used for matching
+type osvEntry struct {
+	ID        string   `json:"id"`
+	Aliases   []string `json:"aliases"`
+	Summary   string   `json:"summary"`
+	Details   string   `json:"details"`
+	Withdrawn string   `json:"withdrawn"`
+	Severity  []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	Affected []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Type   string `json:"type"`
+			Events []struct {
+				Introduced   string `json:"introduced"`
+				Fixed        string `json:"fixed"`
+				LastAffected string `json:"last_affected"`
+			} `json:"events"`
+		} `json:"ranges"`
+		Versions          []string        `json:"versions"`
+		EcosystemSpecific json.RawMessage `json:"ecosystem_specific"`
+	} `json:"affected"`
+	DatabaseSpecific json.RawMessage `json:"database_specific"`
+}
+
+// Database is an in-memory OSV vulnerability database indexed by ecosystem and package
+type Database struct {
+	entries map[string]map[string][]*osvEntry
+	count   int
+}
+
+var (
+	defaultDB     *Database
+	defaultDBErr  error
+	defaultDBOnce sync.Once
+)
+
+// DefaultDatabasePath returns GHBEX_OSV_DB or ~/.kubex/ghbex/osv
+func DefaultDatabasePath() string {
+	return config.GetEnvOrDefault("GHBEX_OSV_DB", filepath.Join(config.GetBaseFilesPath(), "osv"))
+}
+
+// DefaultDatabase loads the database at DefaultDatabasePath once per process.
+// It returns nil without error when no database is installed.
+func DefaultDatabase() (*Database, error) {
+	defaultDBOnce.Do(func() {
+		path := DefaultDatabasePath()
+		if _, err := os.Stat(path); err != nil {
+			gl.Log("debug", fmt.Sprintf("No OSV database at %s, vulnerability matching disabled", path))
+			return
+		}
+		defaultDB, defaultDBErr = LoadDatabase(path)
+	})
+	return defaultDB, defaultDBErr
+}
+
+// LoadDatabase loads OSV JSON records from a directory, a .zip archive (as published by
+// osv.dev) or a .tar.gz archive, so matching works without network access.
+func LoadDatabase(path string) (*Database, error) {
+	db := &Database{entries: make(map[string]map[string][]*osvEntry)}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OSV database: %w", err)
+	}
+
+	switch {
+	case info.IsDir():
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(p, ".json") {
+				return nil
+			}
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			return db.add(p, data)
+		})
+	case strings.HasSuffix(path, ".zip"):
+		err = db.loadZip(path)
+	case strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		err = db.loadTarGz(path)
+	default:
+		err = fmt.Errorf("unsupported OSV database format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OSV database: %w", err)
+	}
+
+	gl.Log("debug", fmt.Sprintf("Loaded %d OSV records from %s", db.count, path))
+	return db, nil
+}
+
+// Len returns the number of loaded advisories
+func (db *Database) Len() int {
+	if db == nil {
+		return 0
+	}
+	return db.count
+}
+
+// Match returns the advisories affecting a dependency version
+func (db *Database) Match(dep Dependency) []Vulnerability {
+	if db == nil || dep.Version == "" {
+		return nil
+	}
+	var vulns []Vulnerability
+	for _, entry := range db.entries[dep.Ecosystem][packageKey(dep.Ecosystem, dep.Name)] {
+		affected, fixed := entry.affects(dep)
+		if !affected {
+			continue
+		}
+		vulns = append(vulns, Vulnerability{
+			ID:       entry.ID,
+			Aliases:  entry.Aliases,
+			Summary:  entry.summary(),
+			Severity: entry.severity(),
+			Fixed:    fixed,
+		})
+	}
+	return vulns
+}
+
+func (db *Database) loadZip(path string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".json") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		if err := db.add(f.Name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *Database) loadTarGz(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg || !strings.HasSuffix(hdr.Name, ".json") {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := db.add(hdr.Name, data); err != nil {
+			return err
+		}
+	}
+}
+
+// add indexes one OSV record; malformed records are skipped
+func (db *Database) add(name string, data []byte) error {
+	var entry osvEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		gl.Log("debug", fmt.Sprintf("Skipping invalid OSV record %s: %v", name, err))
+		return nil
+	}
+	if entry.ID == "" || entry.Withdrawn != "" {
+		return nil
+	}
+	db.count++
+	for _, a := range entry.Affected {
+		// "Debian:11" style ecosystems are indexed by their base name
+		eco, _, _ := strings.Cut(a.Package.Ecosystem, ":")
+		if db.entries[eco] == nil {
+			db.entries[eco] = make(map[string][]*osvEntry)
+		}
+		key := packageKey(eco, a.Package.Name)
+		db.entries[eco][key] = appendUnique(db.entries[eco][key], &entry)
+	}
+	return nil
+}
+
+// affects evaluates the OSV ranges and version lists for a dependency
+func (e *osvEntry) affects(dep Dependency) (bool, []string) {
+	for _, a := range e.Affected {
+		eco, _, _ := strings.Cut(a.Package.Ecosystem, ":")
+		if eco != dep.Ecosystem || packageKey(eco, a.Package.Name) != packageKey(dep.Ecosystem, dep.Name) {
+			continue
+		}
+
+		var fixed []string
+		for _, r := range a.Ranges {
+			for _, ev := range r.Events {
+				if ev.Fixed != "" {
+					fixed = append(fixed, ev.Fixed)
+				}
+			}
+		}
+
+		for _, v := range a.Versions {
+			if compareVersions(v, dep.Version) == 0 {
+				return true, fixed
+			}
+		}
+		for _, r := range a.Ranges {
+			if r.Type == "GIT" {
+				continue
+			}
+			// events must be walked in version order, which the database does not guarantee
+			events := append(r.Events[:0:0], r.Events...)
+			at := func(i int) string {
+				v := events[i].Introduced + events[i].Fixed + events[i].LastAffected
+				if v == "0" {
+					return ""
+				}
+				return v
+			}
+			sort.SliceStable(events, func(i, j int) bool {
+				a, b := at(i), at(j)
+				if a == "" || b == "" {
+					return a == "" && b != ""
+				}
+				return compareVersions(a, b) < 0
+			})
+			affected := false
+			for _, ev := range events {
+				switch {
+				case ev.Introduced != "":
+					if ev.Introduced == "0" || compareVersions(dep.Version, ev.Introduced) >= 0 {
+						affected = true
+					}
+				case ev.Fixed != "":
+					if compareVersions(dep.Version, ev.Fixed) >= 0 {
+						affected = false
+					}
+				case ev.LastAffected != "":
+					if compareVersions(dep.Version, ev.LastAffected) > 0 {
+						affected = false
+					}
+				}
+			}
+			if affected {
+				return true, fixed
+			}
+		}
+	}
+	return false, nil
+}
+
+// summary returns the summary, or the first line of the details
+func (e *osvEntry) summary() string {
+	if e.Summary != "" {
+		return e.Summary
+	}
+	first, _, _ := strings.Cut(e.Details, "\n")
+	return first
+}
+
+// severity returns the advisory severity from database-specific data or the CVSS vector
+func (e *osvEntry) severity() string {
+	var specific struct {
+		Severity string `json:"severity"`
+	}
+	if len(e.DatabaseSpecific) > 0 && json.Unmarshal(e.DatabaseSpecific, &specific) == nil && specific.Severity != "" {
+		return normalizeSeverity(specific.Severity)
+	}
+	for _, a := range e.Affected {
+		if len(a.EcosystemSpecific) > 0 && json.Unmarshal(a.EcosystemSpecific, &specific) == nil && specific.Severity != "" {
+			return normalizeSeverity(specific.Severity)
+		}
+	}
+	for _, s := range e.Severity {
+		if s.Type == "CVSS_V3" || s.Type == "CVSS_V4" {
+			if score, ok := cvss3BaseScore(s.Score); ok {
+				return severityFromScore(score)
+			}
+		}
+	}
+	return "unknown"
+}
+
+// packageKey normalizes package names per ecosystem
+func packageKey(ecosystem, name string) string {
+	if ecosystem == EcosystemPyPI {
+		return normalizePyPIName(name)
+	}
+	return name
+}
+
+// normalizeSeverity maps advisory severities to critical/high/medium/low
+func normalizeSeverity(s string) string {
+	switch strings.ToLower(s) {
+	case "critical":
+		return "critical"
+	case "high":
+		return "high"
+	case "moderate", "medium":
+		return "medium"
+	case "low":
+		return "low"
+	}
+	return "unknown"
+}
+
+// severityFromScore applies the CVSS qualitative rating scale
+func severityFromScore(score float64) string {
+	switch {
+	case score >= 9:
+		return "critical"
+	case score >= 7:
+		return "high"
+	case score >= 4:
+		return "medium"
+	case score > 0:
+		return "low"
+	}
+	return "unknown"
+}
+
+// cvss3BaseScore computes the CVSS v3.x base score from a vector string
+func cvss3BaseScore(vector string) (float64, bool) {
+	if !strings.HasPrefix(vector, "CVSS:3") {
+		return 0, false
+	}
+	m := make(map[string]string)
+	for _, part := range strings.Split(vector, "/")[1:] {
+		if k, v, ok := strings.Cut(part, ":"); ok {
+			m[k] = v
+		}
+	}
+
+	av := map[string]float64{"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2}[m["AV"]]
+	ac := map[string]float64{"L": 0.77, "H": 0.44}[m["AC"]]
+	ui := map[string]float64{"N": 0.85, "R": 0.62}[m["UI"]]
+	cia := map[string]float64{"H": 0.56, "L": 0.22, "N": 0}
+	scopeChanged := m["S"] == "C"
+	pr := map[string]float64{"N": 0.85, "L": 0.62, "H": 0.27}[m["PR"]]
+	if scopeChanged {
+		pr = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}[m["PR"]]
+	}
+	if av == 0 || ac == 0 || ui == 0 || pr == 0 {
+		return 0, false
+	}
+
+	iss := 1 - (1-cia[m["C"]])*(1-cia[m["I"]])*(1-cia[m["A"]])
+	impact := 6.42 * iss
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * av * ac * pr * ui
+
+	score := impact + exploitability
+	if scopeChanged {
+		score *= 1.08
+	}
+	return roundUp(math.Min(score, 10)), true
+}
+
+// roundUp is the CVSS v3.1 round-up to one decimal
+func roundUp(x float64) float64 {
+	i := int(math.Round(x * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return (math.Floor(float64(i)/10000) + 1) / 10
+}
+
+// compareVersions compares dotted versions numerically, treating pre-releases as lower.
+// It handles semver ("v1.2.3-rc.1"), Go pseudo-versions, PEP 440-ish and Maven versions well enough for matching.
+func compareVersions(a, b string) int {
+	a = strings.TrimPrefix(strings.TrimSpace(a), "v")
+	b = strings.TrimPrefix(strings.TrimSpace(b), "v")
+	a, _, _ = strings.Cut(a, "+")
+	b, _, _ = strings.Cut(b, "+")
+
+	mainA, preA, _ := strings.Cut(a, "-")
+	mainB, preB, _ := strings.Cut(b, "-")
+
+	if c := compareParts(strings.Split(mainA, "."), strings.Split(mainB, ".")); c != 0 {
+		return c
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return compareParts(strings.Split(preA, "."), strings.Split(preB, "."))
+}
+
+// compareParts compares version segments; numeric prefixes compare as numbers
+func compareParts(a, b []string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var pa, pb string
+		if i < len(a) {
+			pa = a[i]
+		}
+		if i < len(b) {
+			pb = b[i]
+		}
+		na, restA := splitNumeric(pa)
+		nb, restB := splitNumeric(pb)
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+		// "1.0rc1" < "1.0": a suffix after the number is a pre-release
+		switch {
+		case restA == restB:
+			continue
+		case restA == "":
+			return 1
+		case restB == "":
+			return -1
+		case restA < restB:
+			return -1
+		default:
+			return 1
+		}
+	}
+	return 0
+}
+
+// splitNumeric splits "12rc1" into 12 and "rc1"
+func splitNumeric(s string) (int, string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	n, _ := strconv.Atoi(s[:i])
+	return n, s[i:]
+}
+
+// appendUnique appends an entry unless the same record is already indexed
+func appendUnique(entries []*osvEntry, e *osvEntry) []*osvEntry {
+	for _, existing := range entries {
+		if existing.ID == e.ID {
+			return entries
+		}
+	}
+	return append(entries, e)
+}
@@ -0,0 +1,56 @@
+package deps
+
+// OSV ecosystem names
+const (
+	EcosystemGo     = "Go"
+	EcosystemNPM    = "npm"
+	EcosystemPyPI   = "PyPI"
+	EcosystemCrates = "crates.io"
+	EcosystemMaven  = "Maven"
+)
+
+// Dependency is a normalized package reference found in a manifest or lockfile
+type Dependency struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Ecosystem string `json:"ecosystem"`
+	Direct    bool   `json:"direct"`
+	Manifest  string `json:"manifest"`
+	// License is the license expression declared in the lockfile, when present
+	License string `json:"license,omitempty"`
+	// Hashes maps a CycloneDX algorithm name ("SHA-256", "SHA-512", ...) to the hex digest
+	Hashes map[string]string `json:"hashes,omitempty"`
+}
+
+// Vulnerability is a single OSV advisory matched against a dependency
+type Vulnerability struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Summary  string   `json:"summary"`
+	Severity string   `json:"severity"` // "critical", "high", "medium", "low", "unknown"
+	Fixed    []string `json:"fixed,omitempty"`
+}
+
+// VulnerableDependency groups the advisories affecting one dependency
+type VulnerableDependency struct {
+	Dependency      Dependency      `json:"dependency"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	// FixedVersion is the lowest version that fixes every advisory, when known
+	FixedVersion string `json:"fixed_version,omitempty"`
+}
+
+// Report is the dependency inventory of a repository
+type Report struct {
+	Owner        string                 `json:"owner,omitempty"`
+	Repo         string                 `json:"repo,omitempty"`
+	Manifests    []string               `json:"manifests"`
+	Dependencies []Dependency           `json:"dependencies"`
+	Direct       int                    `json:"direct"`
+	Transitive   int                    `json:"transitive"`
+	ByEcosystem  map[string]int         `json:"by_ecosystem"`
+	Vulnerable   []VulnerableDependency `json:"vulnerable"`
+	BySeverity   map[string]int         `json:"by_severity"`
+	// DatabaseLoaded is false when no offline vulnerability database was available
+	DatabaseLoaded bool     `json:"database_loaded"`
+	Notes          []string `json:"notes,omitempty"`
+}
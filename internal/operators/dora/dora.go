@@ -0,0 +1,139 @@
+// Package dora computes DORA metrics (deployment frequency, lead time, change failure
+// rate and time to restore) from a repository's deployments, releases and pull requests.
+package dora
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+)
+
+// DefaultOptions returns the options used when none are provided
+func DefaultOptions() Options {
+	return Options{
+		PeriodDays:      60,
+		PeriodUnit:      "week",
+		FailureWindow:   72 * time.Hour,
+		MaxPullRequests: 100,
+	}
+}
+
+// ComputeMetrics derives DORA metrics for a repository.
+// Deploys come from the configured deploy workflow, the deployments API or, as a last
+// resort, published releases. Lead time goes from a PR's first commit to the first deploy
+// containing its merge commit; change failure rate and MTTR come from failed deploys,
+// reverts and hotfixes on the default branch.
+func ComputeMetrics(ctx context.Context, cli *github.Client, owner, repo string, opts Options) (*Report, error) {
+	if cli == nil {
+		return nil, fmt.Errorf("GitHub client is nil")
+	}
+	opts = normalizeOptions(opts)
+
+	repository, _, err := cli.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	until := time.Now()
+	report := &Report{
+		Owner:         owner,
+		Repo:          repo,
+		DefaultBranch: repository.GetDefaultBranch(),
+		Since:         until.AddDate(0, 0, -opts.PeriodDays),
+		Until:         until,
+		Deploys:       []Deploy{},
+		Failures:      []FailureEvent{},
+		LeadTimes:     []float64{},
+	}
+
+	deploys, source, err := collectDeploys(ctx, cli, report, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect deploys: %w", err)
+	}
+	sort.Slice(deploys, func(i, j int) bool { return deploys[i].At.Before(deploys[j].At) })
+	report.Deploys = deploys
+	report.Source = source
+	if len(deploys) == 0 {
+		report.Notes = append(report.Notes, "no deploys found in the period; metrics are empty")
+	}
+
+	pulls, err := listMergedPullRequests(ctx, cli, report, opts.MaxPullRequests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged pull requests: %w", err)
+	}
+
+	failures, err := collectFailures(ctx, cli, report, pulls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect failure events: %w", err)
+	}
+	report.Failures = failures
+
+	attributeFailures(report.Deploys, report.Failures, opts.FailureWindow)
+
+	report.Changes = computeLeadTimes(ctx, cli, report, pulls)
+	for _, lt := range report.Changes {
+		report.LeadTimes = append(report.LeadTimes, lt.Hours)
+	}
+
+	report.Metrics, report.FailedDeploys = report.metricsBetween(report.Since, report.Until, opts.PeriodUnit)
+	report.Grade = metrics.DoraGrade(report.Metrics)
+
+	return report, nil
+}
+
+// MetricsBetween computes the DORA metrics of the deploys, lead times and failures of the
+// report that fall in [from, to), e.g. one week of a longer report
+func (r *Report) MetricsBetween(from, to time.Time, unit string) metrics.DoraMetrics {
+	m, _ := r.metricsBetween(from, to, unit)
+	return m
+}
+
+func (r *Report) metricsBetween(from, to time.Time, unit string) (metrics.DoraMetrics, int) {
+	in := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+
+	// A failed deploy attempt is itself a failed change; a successful one fails when a revert
+	// or hotfix followed it. Only successful deploys count towards the frequency.
+	changes, succeeded, failedDeploys := 0, 0, 0
+	for _, d := range r.Deploys {
+		if !in(d.At) {
+			continue
+		}
+		changes++
+		if d.Failed || d.CausedFailure {
+			failedDeploys++
+		}
+		if !d.Failed {
+			succeeded++
+		}
+	}
+	var leadTimes []float64
+	for _, lt := range r.Changes {
+		if in(lt.DeployedAt) {
+			leadTimes = append(leadTimes, lt.Hours)
+		}
+	}
+	var failures []FailureEvent
+	for _, f := range r.Failures {
+		if in(f.At) {
+			failures = append(failures, f)
+		}
+	}
+
+	periodDays := int(to.Sub(from).Hours()/24 + 0.5)
+	m := metrics.DoraMetrics{
+		DeploymentFrequency: deploymentFrequency(succeeded, periodDays, unit),
+		PeriodUnit:          unit,
+		LeadTimeP50:         metrics.Percentile(leadTimes, 50),
+		LeadTimeP95:         metrics.Percentile(leadTimes, 95),
+		// Recovery may land after the window, so it is searched among all deploys
+		MTTR: metrics.Mean(recoveryTimes(r.Deploys, failures)),
+	}
+	if changes > 0 {
+		m.ChangeFailRate = float64(failedDeploys) / float64(changes) * 100
+	}
+	return m, failedDeploys
+}
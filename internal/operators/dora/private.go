@@ -0,0 +1,414 @@
+package dora
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// maxContainmentChecks caps the compare calls made per pull request
+const maxContainmentChecks = 3
+
+// normalizeOptions fills unset options with the defaults
+func normalizeOptions(opts Options) Options {
+	def := DefaultOptions()
+	if opts.PeriodDays <= 0 {
+		opts.PeriodDays = def.PeriodDays
+	}
+	switch opts.PeriodUnit {
+	case "day", "week", "month":
+	default:
+		opts.PeriodUnit = def.PeriodUnit
+	}
+	if opts.FailureWindow <= 0 {
+		opts.FailureWindow = def.FailureWindow
+	}
+	if opts.MaxPullRequests <= 0 {
+		opts.MaxPullRequests = def.MaxPullRequests
+	}
+	return opts
+}
+
+// collectDeploys picks the first deploy source that yields data
+func collectDeploys(ctx context.Context, cli *github.Client, report *Report, opts Options) ([]Deploy, string, error) {
+	if opts.DeployWorkflow != "" {
+		deploys, err := listWorkflowDeploys(ctx, cli, report, opts.DeployWorkflow)
+		return deploys, SourceWorkflow, err
+	}
+
+	deploys, err := listDeployments(ctx, cli, report, opts.Environment)
+	if err != nil {
+		return nil, SourceDeployments, err
+	}
+	if len(deploys) > 0 {
+		return deploys, SourceDeployments, nil
+	}
+
+	deploys, err = listReleaseDeploys(ctx, cli, report)
+	return deploys, SourceReleases, err
+}
+
+// listWorkflowDeploys turns completed runs of the deploy workflow on the default branch into deploys
+func listWorkflowDeploys(ctx context.Context, cli *github.Client, report *Report, workflow string) ([]Deploy, error) {
+	var deploys []Deploy
+	opts := &github.ListWorkflowRunsOptions{
+		Branch:      report.DefaultBranch,
+		Status:      "completed",
+		Created:     ">=" + report.Since.Format("2006-01-02"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		runs, resp, err := cli.Actions.ListWorkflowRunsByFileName(ctx, report.Owner, report.Repo, workflow, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs.WorkflowRuns {
+			deploy := Deploy{
+				SHA:    run.GetHeadSHA(),
+				At:     run.GetUpdatedAt().Time,
+				Source: SourceWorkflow,
+				Ref:    fmt.Sprintf("run #%d", run.GetRunNumber()),
+			}
+			switch run.GetConclusion() {
+			case "success":
+			case "failure", "timed_out":
+				deploy.Failed = true
+			default:
+				continue
+			}
+			deploys = append(deploys, deploy)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return deploys, nil
+}
+
+// listDeployments reads deployments and their latest status from the deployments API
+func listDeployments(ctx context.Context, cli *github.Client, report *Report, environment string) ([]Deploy, error) {
+	var deploys []Deploy
+	opts := &github.DeploymentsListOptions{
+		Environment: environment,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		page, resp, err := cli.Repositories.ListDeployments(ctx, report.Owner, report.Repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		reachedSince := false
+		for _, d := range page {
+			if d.GetCreatedAt().Time.Before(report.Since) {
+				reachedSince = true
+				continue
+			}
+			statuses, _, err := cli.Repositories.ListDeploymentStatuses(ctx, report.Owner, report.Repo, d.GetID(), &github.ListOptions{PerPage: 1})
+			if err != nil {
+				gl.Log("debug", fmt.Sprintf("Failed to get status of deployment %d: %v", d.GetID(), err))
+				continue
+			}
+			if len(statuses) == 0 {
+				continue
+			}
+			// statuses come newest first
+			status := statuses[0]
+			deploy := Deploy{
+				SHA:    d.GetSHA(),
+				At:     status.GetCreatedAt().Time,
+				Source: SourceDeployments,
+				Ref:    d.GetEnvironment(),
+			}
+			switch status.GetState() {
+			case "success":
+			case "failure", "error":
+				deploy.Failed = true
+			default:
+				continue
+			}
+			deploys = append(deploys, deploy)
+		}
+		if reachedSince || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return deploys, nil
+}
+
+// listReleaseDeploys treats every published, non-prerelease release as a deploy
+func listReleaseDeploys(ctx context.Context, cli *github.Client, report *Report) ([]Deploy, error) {
+	var deploys []Deploy
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := cli.Repositories.ListReleases(ctx, report.Owner, report.Repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range releases {
+			if r.GetDraft() || r.GetPrerelease() || r.GetPublishedAt().Time.Before(report.Since) {
+				continue
+			}
+			sha, _, err := cli.Repositories.GetCommitSHA1(ctx, report.Owner, report.Repo, r.GetTagName(), "")
+			if err != nil {
+				gl.Log("debug", fmt.Sprintf("Failed to resolve tag %s: %v", r.GetTagName(), err))
+			}
+			deploys = append(deploys, Deploy{
+				SHA:    sha,
+				At:     r.GetPublishedAt().Time,
+				Source: SourceReleases,
+				Ref:    r.GetTagName(),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return deploys, nil
+}
+
+// listMergedPullRequests lists PRs merged into the default branch during the period
+func listMergedPullRequests(ctx context.Context, cli *github.Client, report *Report, limit int) ([]*github.PullRequest, error) {
+	var pulls []*github.PullRequest
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Base:        report.DefaultBranch,
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		page, resp, err := cli.PullRequests.List(ctx, report.Owner, report.Repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range page {
+			if pr.MergedAt == nil || pr.GetMergedAt().Time.Before(report.Since) {
+				continue
+			}
+			pulls = append(pulls, pr)
+			if len(pulls) >= limit {
+				return pulls, nil
+			}
+		}
+		// sorted by update time: once a page is older than the period, stop
+		if len(page) == 0 || page[len(page)-1].GetUpdatedAt().Time.Before(report.Since) || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return pulls, nil
+}
+
+// collectFailures gathers failed deploys plus revert and hotfix changes on the default branch
+func collectFailures(ctx context.Context, cli *github.Client, report *Report, pulls []*github.PullRequest) ([]FailureEvent, error) {
+	var failures []FailureEvent
+
+	for _, d := range report.Deploys {
+		if d.Failed {
+			failures = append(failures, FailureEvent{At: d.At, Reason: "failed_deploy", Ref: d.Ref})
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, pr := range pulls {
+		if reason := classifyChange(pr.GetTitle(), pr.GetHead().GetRef()); reason != "" {
+			seen[pr.GetMergeCommitSHA()] = true
+			failures = append(failures, FailureEvent{
+				At:     pr.GetMergedAt().Time,
+				Reason: reason,
+				Ref:    fmt.Sprintf("#%d", pr.GetNumber()),
+			})
+		}
+	}
+
+	opts := &github.CommitsListOptions{
+		SHA:         report.DefaultBranch,
+		Since:       report.Since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		commits, resp, err := cli.Repositories.ListCommits(ctx, report.Owner, report.Repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range commits {
+			if seen[c.GetSHA()] {
+				continue
+			}
+			title := strings.SplitN(c.GetCommit().GetMessage(), "\n", 2)[0]
+			if reason := classifyChange(title, ""); reason != "" {
+				failures = append(failures, FailureEvent{
+					At:     c.GetCommit().GetCommitter().GetDate().Time,
+					Reason: reason,
+					Ref:    shortSHA(c.GetSHA()),
+				})
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	sortFailures(failures)
+	return failures, nil
+}
+
+// classifyChange tells whether a change is a revert or a hotfix from its title or branch
+func classifyChange(title, branch string) string {
+	title = strings.ToLower(title)
+	branch = strings.ToLower(branch)
+	switch {
+	case strings.HasPrefix(title, "revert"), strings.HasPrefix(branch, "revert-"):
+		return "revert"
+	case strings.Contains(title, "hotfix"), strings.HasPrefix(branch, "hotfix"):
+		return "hotfix"
+	}
+	return ""
+}
+
+// attributeFailures flags the last successful deploy before each revert or hotfix, when it
+// happened within the failure window. Failed deploys are failed changes of their own and
+// are not pinned on an earlier deploy.
+func attributeFailures(deploys []Deploy, failures []FailureEvent, window time.Duration) {
+	for _, f := range failures {
+		if f.Reason == "failed_deploy" {
+			continue
+		}
+		for i := len(deploys) - 1; i >= 0; i-- {
+			d := &deploys[i]
+			if d.Failed || !d.At.Before(f.At) {
+				continue
+			}
+			if f.At.Sub(d.At) <= window {
+				d.CausedFailure = true
+			}
+			break
+		}
+	}
+}
+
+// recoveryTimes returns, in hours, the time from each failure to the next successful deploy,
+// whether or not that deploy was later blamed for a failure of its own. Failures that happen
+// before the same recovery are counted once, from the first one. A chained failure, one that
+// follows the recovering deploy, starts a new incident measured from its own time. Failures
+// with no successful deploy after them are still open and are not counted.
+func recoveryTimes(deploys []Deploy, failures []FailureEvent) []float64 {
+	var hours []float64
+	var lastRecovery time.Time
+	for _, f := range failures {
+		if !f.At.After(lastRecovery) {
+			continue
+		}
+		for _, d := range deploys {
+			if d.Failed || !d.At.After(f.At) {
+				continue
+			}
+			hours = append(hours, d.At.Sub(f.At).Hours())
+			lastRecovery = d.At
+			break
+		}
+	}
+	return hours
+}
+
+// computeLeadTimes measures first commit to deploy for each merged PR, in hours
+func computeLeadTimes(ctx context.Context, cli *github.Client, report *Report, pulls []*github.PullRequest) []LeadTime {
+	leadTimes := []LeadTime{}
+	contains := make(map[string]bool)
+
+	for _, pr := range pulls {
+		commits, _, err := cli.PullRequests.ListCommits(ctx, report.Owner, report.Repo, pr.GetNumber(), &github.ListOptions{PerPage: 1})
+		if err != nil || len(commits) == 0 {
+			continue
+		}
+		firstCommit := commits[0].GetCommit().GetAuthor().GetDate().Time
+		if firstCommit.IsZero() {
+			continue
+		}
+
+		deploy := findContainingDeploy(ctx, cli, report, pr, contains)
+		if deploy == nil {
+			continue
+		}
+		leadTimes = append(leadTimes, LeadTime{PR: pr.GetNumber(), DeployedAt: deploy.At, Hours: deploy.At.Sub(firstCommit).Hours()})
+	}
+	return leadTimes
+}
+
+// findContainingDeploy returns the first healthy deploy after the merge that contains the merge commit.
+// Containment is checked with the compare API for a few candidates. A candidate that cannot be
+// checked (no SHA or an API error) is assumed to contain the merge; one shown not to contain it is
+// skipped. It returns nil when every checked candidate misses the merge, so the PR has no lead time.
+func findContainingDeploy(ctx context.Context, cli *github.Client, report *Report, pr *github.PullRequest, contains map[string]bool) *Deploy {
+	mergedAt := pr.GetMergedAt().Time
+	mergeSHA := pr.GetMergeCommitSHA()
+
+	checks := 0
+	for i := range report.Deploys {
+		d := &report.Deploys[i]
+		if d.Failed || d.At.Before(mergedAt) {
+			continue
+		}
+		if mergeSHA == "" || d.SHA == "" {
+			return d
+		}
+
+		key := mergeSHA + ".." + d.SHA
+		ok, cached := contains[key]
+		if !cached {
+			if checks >= maxContainmentChecks {
+				return nil
+			}
+			checks++
+			cmp, _, err := cli.Repositories.CompareCommits(ctx, report.Owner, report.Repo, mergeSHA, d.SHA, &github.ListOptions{PerPage: 1})
+			if err != nil {
+				return d
+			}
+			status := cmp.GetStatus()
+			ok = status == "ahead" || status == "identical"
+			contains[key] = ok
+		}
+		if ok {
+			return d
+		}
+	}
+	return nil
+}
+
+// deploymentFrequency converts a deploy count into deploys per period unit
+func deploymentFrequency(deploys, periodDays int, unit string) float64 {
+	if periodDays <= 0 {
+		return 0
+	}
+	unitDays := 7.0
+	switch unit {
+	case "day":
+		unitDays = 1
+	case "month":
+		unitDays = 30
+	}
+	return float64(deploys) / (float64(periodDays) / unitDays)
+}
+
+// sortFailures orders failure events chronologically
+func sortFailures(failures []FailureEvent) {
+	sort.Slice(failures, func(i, j int) bool { return failures[i].At.Before(failures[j].At) })
+}
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,75 @@
+package dora
+
+import (
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+)
+
+// Deploy sources
+const (
+	SourceDeployments = "deployments"
+	SourceWorkflow    = "workflow"
+	SourceReleases    = "releases"
+)
+
+// Options controls how DORA metrics are derived from a repository
+type Options struct {
+	// PeriodDays is the analysis window, counted back from now
+	PeriodDays int `json:"period_days"`
+	// PeriodUnit is the unit of the deployment frequency ("day", "week", "month")
+	PeriodUnit string `json:"period_unit"`
+	// DeployWorkflow is the workflow file name (e.g. "deploy.yml") whose successful runs
+	// on the default branch count as deploys. Empty means deployments API, then releases.
+	DeployWorkflow string `json:"deploy_workflow,omitempty"`
+	// Environment filters the deployments API (e.g. "production")
+	Environment string `json:"environment,omitempty"`
+	// FailureWindow is how long after a deploy a failure, revert or hotfix is attributed to it
+	FailureWindow time.Duration `json:"failure_window"`
+	// MaxPullRequests caps the merged PRs inspected for lead time
+	MaxPullRequests int `json:"max_pull_requests"`
+}
+
+// Deploy is a single production change, regardless of its source
+type Deploy struct {
+	SHA    string    `json:"sha"`
+	At     time.Time `json:"at"`
+	Source string    `json:"source"`
+	Ref    string    `json:"ref,omitempty"`
+	// Failed is set when the deploy attempt itself failed
+	Failed bool `json:"failed"`
+	// CausedFailure is set when a failure, revert or hotfix followed it within the failure window
+	CausedFailure bool `json:"caused_failure"`
+}
+
+// FailureEvent is a signal that a deploy broke something
+type FailureEvent struct {
+	At     time.Time `json:"at"`
+	Reason string    `json:"reason"` // "failed_deploy", "revert", "hotfix"
+	Ref    string    `json:"ref"`
+}
+
+// LeadTime is the lead time of one merged pull request
+type LeadTime struct {
+	PR         int       `json:"pr"`
+	DeployedAt time.Time `json:"deployed_at"`
+	Hours      float64   `json:"hours"`
+}
+
+// Report is the result of a DORA computation for a repository
+type Report struct {
+	Owner         string              `json:"owner"`
+	Repo          string              `json:"repo"`
+	DefaultBranch string              `json:"default_branch"`
+	Since         time.Time           `json:"since"`
+	Until         time.Time           `json:"until"`
+	Source        string              `json:"source"`
+	Deploys       []Deploy            `json:"deploys"`
+	Failures      []FailureEvent      `json:"failures"`
+	LeadTimes     []float64           `json:"lead_times_hours"`
+	Changes       []LeadTime          `json:"changes,omitempty"` // Lead times with their deploy, for bucketing
+	FailedDeploys int                 `json:"failed_deploys"`    // Failed changes: failed attempts plus deploys followed by a revert or hotfix
+	Metrics       metrics.DoraMetrics `json:"metrics"`
+	Grade         string              `json:"grade"`
+	Notes         []string            `json:"notes,omitempty"`
+}
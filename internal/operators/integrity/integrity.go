@@ -0,0 +1,135 @@
+// Package integrity audits tag hygiene and release integrity: semver naming, annotated and
+// signed tags, signed commits, tags unreachable from the default branch and release assets
+// checked against their published checksums.
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
+	"github.com/kubex-ecosystem/ghbex/internal/module/version"
+)
+
+var semverRe = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// DefaultOptions returns the bounds used by the sanitize audit
+func DefaultOptions() Options {
+	return Options{
+		Prefix:         "v",
+		MaxTags:        50,
+		MaxReleases:    30,
+		VerifyReleases: 1,
+		MaxAssetBytes:  50 << 20,
+		ChecksumFiles:  []string{"checksums.txt", "sha256sums", "sha256sums.txt", "sha512sums", "sha512sums.txt"},
+	}
+}
+
+// IsSemver reports whether a tag is a semantic version with the optional prefix
+func IsSemver(tag, prefix string) bool {
+	return semverRe.MatchString(strings.TrimPrefix(tag, prefix))
+}
+
+// Audit inspects the tags and published releases of a repository
+func Audit(ctx context.Context, cli *github.Client, owner, repo string, opts Options) (*Report, error) {
+	r, _, err := cli.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	report := &Report{
+		Owner:         owner,
+		Repo:          repo,
+		DefaultBranch: r.GetDefaultBranch(),
+		Tags:          []Tag{},
+		Releases:      []Release{},
+		Findings:      []Finding{},
+	}
+
+	refs, err := listTagRefs(ctx, cli, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	report.TagsTotal = len(refs)
+	sortTagRefs(refs, opts.Prefix)
+	if opts.MaxTags > 0 && len(refs) > opts.MaxTags {
+		refs = refs[:opts.MaxTags]
+	}
+
+	a := &auditor{cli: cli, owner: owner, repo: repo, opts: opts, report: report, signed: map[string]bool{}, reachable: map[string]bool{}}
+	for _, ref := range refs {
+		a.auditTag(ctx, ref)
+	}
+
+	rels, err := listPublishedReleases(ctx, cli, owner, repo, opts.MaxReleases)
+	if err != nil {
+		return nil, err
+	}
+	for i, rel := range rels {
+		a.auditRelease(ctx, rel, i < opts.VerifyReleases)
+	}
+	return report, nil
+}
+
+// ToReleaseAudit summarizes the report for the sanitize report, keeping at most limit findings
+// (most severe first)
+func (r *Report) ToReleaseAudit(limit int) gitz.ReleaseAudit {
+	audit := gitz.ReleaseAudit{
+		Audited:                  true,
+		TagsChecked:              r.TagsChecked,
+		ReleasesChecked:          r.ReleasesChecked,
+		NonSemverTags:            r.NonSemverTags,
+		LightweightTags:          r.LightweightTags,
+		UnsignedTags:             r.UnsignedTags,
+		UnsignedCommits:          r.UnsignedCommits,
+		UnreachableTags:          r.UnreachableTags,
+		ReleasesWithoutAssets:    r.ReleasesWithoutAssets,
+		ReleasesWithoutChecksums: r.ReleasesWithoutChecksums,
+		ChecksumMismatches:       r.ChecksumMismatches,
+		Findings:                 []gitz.ReleaseFinding{},
+	}
+
+	findings := append([]Finding(nil), r.Findings...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank(findings[i].Severity) < severityRank(findings[j].Severity)
+	})
+	for i, f := range findings {
+		if limit > 0 && i >= limit {
+			break
+		}
+		audit.Findings = append(audit.Findings, gitz.ReleaseFinding{
+			Kind:     f.Kind,
+			Severity: f.Severity,
+			Tag:      f.Tag,
+			Asset:    f.Asset,
+			Message:  f.Message,
+			Status:   f.Status,
+		})
+	}
+	return audit
+}
+
+// sortTagRefs orders version tags newest first, followed by the other tags by name
+func sortTagRefs(refs []*github.Reference, prefix string) {
+	name := func(ref *github.Reference) string { return strings.TrimPrefix(ref.GetRef(), "refs/tags/") }
+	sort.SliceStable(refs, func(i, j int) bool {
+		vi := version.ParseVersion(strings.TrimPrefix(name(refs[i]), prefix))
+		vj := version.ParseVersion(strings.TrimPrefix(name(refs[j]), prefix))
+		switch {
+		case vi != nil && vj != nil:
+			if c := version.CompareVersions(vi, vj); c != 0 {
+				return c > 0
+			}
+			return name(refs[i]) > name(refs[j])
+		case vi != nil:
+			return true
+		case vj != nil:
+			return false
+		default:
+			return name(refs[i]) < name(refs[j])
+		}
+	})
+}
@@ -0,0 +1,309 @@
+package integrity
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// signatureExts are release assets that sign other assets and are not expected in checksums
+var signatureExts = []string{".sig", ".asc", ".pem", ".cert", ".sigstore", ".bundle"}
+
+type auditor struct {
+	cli         *github.Client
+	owner, repo string
+	opts        Options
+	report      *Report
+	signed      map[string]bool // commit SHA → verified signature
+	reachable   map[string]bool // commit SHA → ancestor of the default branch
+}
+
+func (a *auditor) add(kind, severity, tag, asset, message string) {
+	a.report.Findings = append(a.report.Findings, Finding{Kind: kind, Severity: severity, Tag: tag, Asset: asset, Message: message})
+}
+
+// unknown records a check that could not be completed, so it is not mistaken for a pass
+func (a *auditor) unknown(kind, severity, tag, message string) {
+	a.report.Findings = append(a.report.Findings, Finding{Kind: kind, Severity: severity, Tag: tag, Message: message, Status: StatusUnknown})
+}
+
+func (a *auditor) auditTag(ctx context.Context, ref *github.Reference) {
+	t := Tag{Name: strings.TrimPrefix(ref.GetRef(), "refs/tags/")}
+	t.Semver = IsSemver(t.Name, a.opts.Prefix)
+	if !t.Semver {
+		a.report.NonSemverTags++
+		a.add(KindNonSemverTag, SeverityLow, t.Name, "", "tag is not a semantic version")
+	}
+
+	switch ref.GetObject().GetType() {
+	case "tag":
+		t.Annotated = true
+		tag, _, err := a.cli.Git.GetTag(ctx, a.owner, a.repo, ref.GetObject().GetSHA())
+		if err != nil {
+			// Without the tag object neither the signature nor the tagged commit is known
+			a.unknown(KindUnsignedTag, SeverityLow, t.Name, fmt.Sprintf("could not read the annotated tag: %v", err))
+			a.report.TagsChecked++
+			a.report.Tags = append(a.report.Tags, t)
+			return
+		}
+		t.CommitSHA = tag.GetObject().GetSHA()
+		t.TagSigned = tag.GetVerification().GetVerified()
+		if !t.TagSigned {
+			a.report.UnsignedTags++
+			a.add(KindUnsignedTag, SeverityLow, t.Name, "", "annotated tag has no verified signature")
+		}
+	default:
+		t.CommitSHA = ref.GetObject().GetSHA()
+		a.report.LightweightTags++
+		a.add(KindLightweightTag, SeverityLow, t.Name, "", "lightweight tag carries no tagger, date or signature")
+	}
+
+	signed, err := a.commitSigned(ctx, t.CommitSHA)
+	t.CommitSigned = signed
+	switch {
+	case err != nil:
+		a.unknown(KindUnsignedCommit, SeverityLow, t.Name, fmt.Sprintf("could not read tagged commit %.7s: %v", t.CommitSHA, err))
+	case !signed:
+		a.report.UnsignedCommits++
+		a.add(KindUnsignedCommit, SeverityLow, t.Name, "", fmt.Sprintf("tagged commit %.7s has no verified signature", t.CommitSHA))
+	}
+	reachable, err := a.commitReachable(ctx, t.CommitSHA)
+	t.Reachable = reachable
+	switch {
+	case err != nil:
+		a.unknown(KindUnreachableTag, SeverityMedium, t.Name, fmt.Sprintf("could not check whether tagged commit %.7s is reachable from %s: %v", t.CommitSHA, a.report.DefaultBranch, err))
+	case !reachable:
+		a.report.UnreachableTags++
+		a.add(KindUnreachableTag, SeverityMedium, t.Name, "", fmt.Sprintf("tagged commit %.7s is not reachable from %s", t.CommitSHA, a.report.DefaultBranch))
+	}
+
+	a.report.TagsChecked++
+	a.report.Tags = append(a.report.Tags, t)
+}
+
+// commitSigned reports whether the commit has a verified signature. Errors are not cached.
+func (a *auditor) commitSigned(ctx context.Context, sha string) (bool, error) {
+	if signed, ok := a.signed[sha]; ok {
+		return signed, nil
+	}
+	commit, _, err := a.cli.Git.GetCommit(ctx, a.owner, a.repo, sha)
+	if err != nil {
+		return false, err
+	}
+	signed := commit.GetVerification().GetVerified()
+	a.signed[sha] = signed
+	return signed, nil
+}
+
+// commitReachable reports whether the commit is in the default branch history. Errors are not cached.
+func (a *auditor) commitReachable(ctx context.Context, sha string) (bool, error) {
+	if reachable, ok := a.reachable[sha]; ok {
+		return reachable, nil
+	}
+	cmp, _, err := a.cli.Repositories.CompareCommits(ctx, a.owner, a.repo, a.report.DefaultBranch, sha, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return false, err
+	}
+	// behind or identical: the tagged commit is already in the default branch history
+	reachable := cmp.GetStatus() == "behind" || cmp.GetStatus() == "identical"
+	a.reachable[sha] = reachable
+	return reachable, nil
+}
+
+func (a *auditor) auditRelease(ctx context.Context, rel *github.RepositoryRelease, verify bool) {
+	r := Release{Tag: rel.GetTagName(), Name: rel.GetName(), Prerelease: rel.GetPrerelease(), Assets: len(rel.Assets)}
+	defer func() {
+		a.report.ReleasesChecked++
+		a.report.Releases = append(a.report.Releases, r)
+	}()
+
+	if len(rel.Assets) == 0 {
+		a.report.ReleasesWithoutAssets++
+		a.add(KindReleaseWithoutAssets, SeverityLow, r.Tag, "", "release has no assets")
+		return
+	}
+
+	var checksums *github.ReleaseAsset
+	for _, asset := range rel.Assets {
+		if a.isChecksumFile(asset.GetName()) {
+			checksums = asset
+			break
+		}
+	}
+	if checksums == nil {
+		a.report.ReleasesWithoutChecksums++
+		a.add(KindReleaseWithoutChecksums, SeverityMedium, r.Tag, "", "release has no published checksums file")
+		return
+	}
+	r.ChecksumsFile = checksums.GetName()
+	if !verify {
+		return
+	}
+
+	data, err := a.download(ctx, checksums)
+	if err != nil {
+		return
+	}
+	sums := parseChecksums(data)
+	for _, asset := range rel.Assets {
+		name := asset.GetName()
+		if asset.GetID() == checksums.GetID() || isSignature(name) {
+			continue
+		}
+		expected, ok := sums[name]
+		if !ok {
+			a.add(KindAssetMissingFromChecksum, SeverityLow, r.Tag, name, "asset is not listed in "+r.ChecksumsFile)
+			continue
+		}
+		if a.opts.MaxAssetBytes > 0 && asset.GetSize() > a.opts.MaxAssetBytes {
+			continue
+		}
+		actual, err := a.hashAsset(ctx, asset, len(expected))
+		if err != nil {
+			continue
+		}
+		if !strings.EqualFold(actual, expected) {
+			r.Mismatches = append(r.Mismatches, name)
+			a.report.ChecksumMismatches++
+			a.add(KindChecksumMismatch, SeverityHigh, r.Tag, name, fmt.Sprintf("hash %s does not match %s from %s", shortHash(actual), shortHash(expected), r.ChecksumsFile))
+			continue
+		}
+		r.Verified++
+	}
+}
+
+func (a *auditor) isChecksumFile(name string) bool {
+	lower := strings.ToLower(name)
+	for _, candidate := range a.opts.ChecksumFiles {
+		if lower == strings.ToLower(candidate) {
+			return true
+		}
+	}
+	return strings.HasSuffix(lower, "checksums.txt")
+}
+
+func (a *auditor) download(ctx context.Context, asset *github.ReleaseAsset) ([]byte, error) {
+	rc, _, err := a.cli.Repositories.DownloadReleaseAsset(ctx, a.owner, a.repo, asset.GetID(), http.DefaultClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", asset.GetName(), err)
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// hashAsset streams an asset through the hash matching the expected hex length
+func (a *auditor) hashAsset(ctx context.Context, asset *github.ReleaseAsset, hexLen int) (string, error) {
+	var h hash.Hash
+	switch hexLen {
+	case sha256.Size * 2:
+		h = sha256.New()
+	case sha512.Size * 2:
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("unsupported checksum length %d", hexLen)
+	}
+	rc, _, err := a.cli.Repositories.DownloadReleaseAsset(ctx, a.owner, a.repo, asset.GetID(), http.DefaultClient)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", asset.GetName(), err)
+	}
+	defer rc.Close()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", asset.GetName(), err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// parseChecksums reads "<hex>  <name>" lines (sha256sum/goreleaser format, "*" binary marker allowed)
+func parseChecksums(data []byte) map[string]string {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		name := path.Base(strings.TrimPrefix(fields[1], "*"))
+		sums[name] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+func listTagRefs(ctx context.Context, cli *github.Client, owner, repo string) ([]*github.Reference, error) {
+	var refs []*github.Reference
+	opt := &github.ReferenceListOptions{Ref: "tags", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := cli.Git.ListMatchingRefs(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		refs = append(refs, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return refs, nil
+}
+
+func listPublishedReleases(ctx context.Context, cli *github.Client, owner, repo string, max int) ([]*github.RepositoryRelease, error) {
+	var rels []*github.RepositoryRelease
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := cli.Repositories.ListReleases(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list releases: %w", err)
+		}
+		for _, rel := range page {
+			if rel.GetDraft() {
+				continue
+			}
+			rels = append(rels, rel)
+			if max > 0 && len(rels) >= max {
+				return rels, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return rels, nil
+}
+
+func isSignature(name string) bool {
+	lower := strings.ToLower(name)
+	for _, ext := range signatureExts {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityHigh:
+		return 0
+	case SeverityMedium:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func shortHash(h string) string {
+	if len(h) > 12 {
+		return h[:12]
+	}
+	return h
+}
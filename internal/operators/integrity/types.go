@@ -0,0 +1,93 @@
+package integrity
+
+// Finding kinds
+const (
+	KindNonSemverTag             = "non-semver-tag"
+	KindLightweightTag           = "lightweight-tag"
+	KindUnsignedTag              = "unsigned-tag"
+	KindUnsignedCommit           = "unsigned-commit"
+	KindUnreachableTag           = "unreachable-tag"
+	KindReleaseWithoutAssets     = "release-without-assets"
+	KindReleaseWithoutChecksums  = "release-without-checksums"
+	KindChecksumMismatch         = "checksum-mismatch"
+	KindAssetMissingFromChecksum = "asset-not-in-checksums"
+)
+
+// Finding severities
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+)
+
+// StatusUnknown marks a finding whose check could not be completed, e.g. after an API error
+const StatusUnknown = "unknown"
+
+// Options bounds the API calls of an audit
+type Options struct {
+	// Prefix of version tags, "v" by default; tags without it are still audited
+	Prefix string
+	// MaxTags is the number of tags inspected, newest versions first (about three calls each)
+	MaxTags int
+	// MaxReleases is the number of releases inspected
+	MaxReleases int
+	// VerifyReleases is the number of latest releases whose assets are downloaded and hashed
+	VerifyReleases int
+	// MaxAssetBytes skips hashing assets larger than this
+	MaxAssetBytes int
+	// ChecksumFiles are the asset names recognized as published checksums (case-insensitive)
+	ChecksumFiles []string
+}
+
+// Tag is an inspected git tag
+type Tag struct {
+	Name         string `json:"name"`
+	CommitSHA    string `json:"commit_sha"`
+	Semver       bool   `json:"semver"`
+	Annotated    bool   `json:"annotated"`
+	TagSigned    bool   `json:"tag_signed"`
+	CommitSigned bool   `json:"commit_signed"`
+	Reachable    bool   `json:"reachable"` // Commit is an ancestor of the default branch
+}
+
+// Release is an inspected published release
+type Release struct {
+	Tag           string   `json:"tag"`
+	Name          string   `json:"name"`
+	Prerelease    bool     `json:"prerelease"`
+	Assets        int      `json:"assets"`
+	ChecksumsFile string   `json:"checksums_file,omitempty"`
+	Verified      int      `json:"verified"` // Assets whose hash matched the checksums file
+	Mismatches    []string `json:"mismatches,omitempty"`
+}
+
+// Finding is one integrity problem
+type Finding struct {
+	Kind     string `json:"kind"`
+	Severity string `json:"severity"`
+	Tag      string `json:"tag,omitempty"`
+	Asset    string `json:"asset,omitempty"`
+	Message  string `json:"message"`
+	Status   string `json:"status,omitempty"` // StatusUnknown when the check could not be completed
+}
+
+// Report is the tag and release integrity audit of a repository
+type Report struct {
+	Owner                    string    `json:"owner"`
+	Repo                     string    `json:"repo"`
+	DefaultBranch            string    `json:"default_branch"`
+	TagsTotal                int       `json:"tags_total"`
+	TagsChecked              int       `json:"tags_checked"`
+	ReleasesChecked          int       `json:"releases_checked"`
+	NonSemverTags            int       `json:"non_semver_tags"`
+	LightweightTags          int       `json:"lightweight_tags"`
+	UnsignedTags             int       `json:"unsigned_tags"`
+	UnsignedCommits          int       `json:"unsigned_commits"`
+	UnreachableTags          int       `json:"unreachable_tags"`
+	ReleasesWithoutAssets    int       `json:"releases_without_assets"`
+	ReleasesWithoutChecksums int       `json:"releases_without_checksums"`
+	ChecksumMismatches       int       `json:"checksum_mismatches"`
+	Tags                     []Tag     `json:"tags"`
+	Releases                 []Release `json:"releases"`
+	Findings                 []Finding `json:"findings"`
+}
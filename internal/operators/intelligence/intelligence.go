@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
 	"github.com/kubex-ecosystem/ghbex/internal/metrics"
 	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/analytics"
 	"github.com/kubex-ecosystem/ghbex/internal/render"
 
 	configLib "github.com/kubex-ecosystem/ghbex/internal/config"
@@ -822,6 +824,11 @@ func (o *IntelligenceOperator) getCachedHealthStatus(providerName string) (healt
 
 // GenerateEnhancedScorecard gera um scorecard aprimorado com CHI e badges
 func (o *IntelligenceOperator) GenerateEnhancedScorecard(ctx context.Context, client *github.Client, owner, repo string, analysisData map[string]interface{}) (*metrics.EnhancedScorecard, error) {
+	return BuildEnhancedScorecard(owner, repo, analysisData), nil
+}
+
+// BuildEnhancedScorecard converte os dados da análise em scorecard e calcula CHI, penalidades e bus factor
+func BuildEnhancedScorecard(owner, repo string, analysisData map[string]interface{}) *metrics.EnhancedScorecard {
 	// Convert from current GHbex model to enhanced scorecard
 	scorecard := metrics.ConvertFromGHbexModel(analysisData, owner, repo)
 
@@ -837,7 +844,23 @@ func (o *IntelligenceOperator) GenerateEnhancedScorecard(ctx context.Context, cl
 		scorecard.Health.Grade = metrics.GradeFromCHI(chi)
 	}
 
-	// Calculate bus factor from community data
+	// Open security alerts, from dependencies and from code and secret scanning, pull the CHI down
+	openBySeverity := make(map[string]int)
+	for _, bySeverity := range []map[string]int{scorecard.Deps.VulnerableBySeverity, scorecard.Deps.ScanningBySeverity} {
+		for sev, count := range bySeverity {
+			openBySeverity[sev] += count
+		}
+	}
+	if len(openBySeverity) > 0 && scorecard.Health.CHI > 0 {
+		chi := metrics.ApplySecurityPenalty(scorecard.Health.CHI, openBySeverity)
+		scorecard.Health.CHI = chi
+		scorecard.Health.Grade = metrics.GradeFromCHI(chi)
+	}
+
+	// Fall back to the lifetime contributors list when the ownership analysis is missing
+	if scorecard.Community.BusFactor > 0 {
+		return scorecard
+	}
 	if contributorsData, ok := analysisData["community_insights"].(map[string]interface{}); ok {
 		if contributors, ok := contributorsData["contributors"].(map[string]interface{}); ok {
 			if topContribs, ok := contributors["top_contributors"].([]interface{}); ok {
@@ -852,7 +875,54 @@ func (o *IntelligenceOperator) GenerateEnhancedScorecard(ctx context.Context, cl
 		}
 	}
 
-	return scorecard, nil
+	return scorecard
+}
+
+// ScorecardFromInsights monta o scorecard de um relatório de insights (ver BuildEnhancedScorecard)
+func ScorecardFromInsights(insights *analytics.InsightsReport) *metrics.EnhancedScorecard {
+	data, err := json.Marshal(insights)
+	if err != nil {
+		return nil
+	}
+	var analysisData map[string]interface{}
+	if err := json.Unmarshal(data, &analysisData); err != nil {
+		return nil
+	}
+	return BuildEnhancedScorecard(insights.Owner, insights.Repo, analysisData)
+}
+
+// NewOverallAssessment resume o health score dos insights. Cada componente do score vale até 25
+// pontos: 20 ou mais é ponto forte, abaixo de 15 é ponto fraco. trend vem do histórico de
+// métricas ("improving", "stable" ou "declining") e fica vazio quando não há histórico.
+func NewOverallAssessment(insights *analytics.InsightsReport, trend string) OverallAssessment {
+	assessment := OverallAssessment{KeyStrengths: []string{}, KeyWeaknesses: []string{}, Trend: trend}
+	if insights == nil || insights.HealthScore == nil {
+		assessment.Summary = "No health score available"
+		return assessment
+	}
+	health := insights.HealthScore
+	assessment.Grade = health.Grade
+	assessment.Score = health.Overall
+
+	parts := make([]string, 0, len(health.Breakdown))
+	for part := range health.Breakdown {
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	for _, part := range parts {
+		switch score := health.Breakdown[part]; {
+		case score >= 20:
+			assessment.KeyStrengths = append(assessment.KeyStrengths, part)
+		case score < 15:
+			assessment.KeyWeaknesses = append(assessment.KeyWeaknesses, part)
+		}
+	}
+
+	assessment.Summary = fmt.Sprintf("%s/%s scores %.0f/100 (grade %s)", insights.Owner, insights.Repo, health.Overall, health.Grade)
+	if trend != "" {
+		assessment.Summary += ", " + trend + " over the recorded runs"
+	}
+	return assessment
 }
 
 // GenerateBadgesMarkdown gera badges markdown baseado no scorecard
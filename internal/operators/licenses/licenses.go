@@ -0,0 +1,287 @@
+// Package licenses checks the declared licenses of a repository's dependencies
+// against an allow/deny/review policy and reports violations as SARIF and markdown.
+package licenses
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/deps"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// strongCopyleft licenses require releasing the combined work's source
+var strongCopyleft = []string{"GPL-", "AGPL-", "SSPL-", "OSL-", "EUPL-", "CC-BY-SA-"}
+
+// weakCopyleft licenses only reach modifications of the library itself
+var weakCopyleft = []string{"LGPL-", "MPL-", "EPL-", "CDDL-", "CPL-"}
+
+var (
+	licenseCache   = make(map[string]string)
+	licenseCacheMu sync.Mutex
+)
+
+// PolicyFromRule builds a policy from the repository licenses rule
+func PolicyFromRule(rule interfaces.ILicensesRule) Policy {
+	if rule == nil {
+		return Policy{UnknownPolicy: "review"}
+	}
+	return Policy{
+		Allow:         rule.GetAllow(),
+		Deny:          rule.GetDeny(),
+		Review:        rule.GetReview(),
+		Proprietary:   rule.GetProprietary(),
+		UnknownPolicy: rule.GetUnknownPolicy(),
+	}
+}
+
+// PolicyForRepository returns the licenses rule configured for the repository,
+// falling back to the environment defaults
+func PolicyForRepository(cfg interfaces.IMainConfig, owner, repo string) Policy {
+	if cfg != nil && cfg.GetGitHub() != nil {
+		for _, rc := range cfg.GetGitHub().GetRepos() {
+			if rc.GetOwner() == owner && rc.GetName() == repo && rc.GetRules() != nil {
+				return PolicyFromRule(rc.GetRules().GetLicensesRule())
+			}
+		}
+	}
+	return DefaultPolicy()
+}
+
+// DefaultPolicy reads the policy from the GITHUB_REPO_LICENSES_* environment variables,
+// the same ones used by the main configuration
+func DefaultPolicy() Policy {
+	unknown := os.Getenv("GITHUB_REPO_LICENSES_UNKNOWN")
+	if unknown == "" {
+		unknown = "review"
+	}
+	return Policy{
+		Allow:         splitEnv("GITHUB_REPO_LICENSES_ALLOW"),
+		Deny:          splitEnv("GITHUB_REPO_LICENSES_DENY"),
+		Review:        splitEnv("GITHUB_REPO_LICENSES_REVIEW"),
+		Proprietary:   strings.EqualFold(os.Getenv("GITHUB_REPO_PROPRIETARY"), "true"),
+		UnknownPolicy: unknown,
+	}
+}
+
+// CheckRepository builds the dependency inventory, resolves missing licenses and evaluates the policy
+func CheckRepository(ctx context.Context, cli *github.Client, owner, repo string, policy Policy) (*Report, error) {
+	inventory, err := deps.AnalyzeRepository(ctx, cli, owner, repo, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dependency inventory: %w", err)
+	}
+	ResolveLicenses(ctx, cli, inventory.Dependencies)
+
+	report := Evaluate(inventory.Dependencies, policy)
+	report.Owner = owner
+	report.Repo = repo
+	return report, nil
+}
+
+// ResolveLicenses fills missing licenses of Go modules hosted on GitHub using the
+// repository license API. Results are cached for the life of the process.
+func ResolveLicenses(ctx context.Context, cli *github.Client, dependencies []deps.Dependency) {
+	for i := range dependencies {
+		d := &dependencies[i]
+		if d.License != "" || d.Ecosystem != deps.EcosystemGo {
+			continue
+		}
+		owner, repo, ok := githubRepoOf(d.Name)
+		if !ok {
+			continue
+		}
+		key := owner + "/" + repo
+
+		licenseCacheMu.Lock()
+		spdx, cached := licenseCache[key]
+		licenseCacheMu.Unlock()
+		if !cached {
+			lic, _, err := cli.Repositories.License(ctx, owner, repo)
+			if err != nil {
+				gl.Log("debug", fmt.Sprintf("Failed to resolve license of %s: %v", key, err))
+			} else if lic.GetLicense() != nil {
+				spdx = lic.GetLicense().GetSPDXID()
+			}
+			licenseCacheMu.Lock()
+			licenseCache[key] = spdx
+			licenseCacheMu.Unlock()
+		}
+		if spdx != "" && spdx != unknownLicenseText {
+			d.License = spdx
+		}
+	}
+}
+
+// Evaluate applies the policy to every dependency
+func Evaluate(dependencies []deps.Dependency, policy Policy) *Report {
+	if policy.UnknownPolicy == "" {
+		policy.UnknownPolicy = "review"
+	}
+	report := &Report{
+		Policy:   policy,
+		ByRule:   make(map[string]int),
+		Findings: []Finding{},
+	}
+
+	for _, d := range dependencies {
+		report.Checked++
+		for _, f := range evaluateDependency(d, policy) {
+			report.ByRule[f.RuleID]++
+			report.Findings = append(report.Findings, f)
+		}
+		if d.License == "" || d.License == unknownLicenseText {
+			report.Unknown++
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return levelRank(report.Findings[i].Level) > levelRank(report.Findings[j].Level)
+	})
+	return report
+}
+
+// evaluateDependency checks one dependency. For "A OR B" expressions the most permissive
+// alternative wins; for "A AND B" every license must pass.
+func evaluateDependency(d deps.Dependency, policy Policy) []Finding {
+	license := strings.TrimSpace(d.License)
+	if license == "" || license == unknownLicenseText {
+		level := ""
+		switch policy.UnknownPolicy {
+		case "deny":
+			level = LevelError
+		case "allow":
+			return nil
+		default:
+			level = LevelWarning
+		}
+		return []Finding{{
+			RuleID:     RuleUnknown,
+			Level:      level,
+			License:    unknownLicenseText,
+			Message:    fmt.Sprintf("%s@%s has no declared license", d.Name, d.Version),
+			Dependency: d,
+		}}
+	}
+
+	var best []Finding
+	for i, alternative := range splitExpression(license, " OR ") {
+		var findings []Finding
+		for _, id := range splitExpression(alternative, " AND ") {
+			if f := evaluateLicense(d, id, policy); f != nil {
+				findings = append(findings, *f)
+			}
+		}
+		if i == 0 || worstLevel(findings) < worstLevel(best) {
+			best = findings
+		}
+		if len(best) == 0 {
+			break
+		}
+	}
+	return best
+}
+
+// evaluateLicense checks a single SPDX identifier
+func evaluateLicense(d deps.Dependency, id string, policy Policy) *Finding {
+	finding := func(rule, level, msg string) *Finding {
+		return &Finding{RuleID: rule, Level: level, License: id, Message: msg, Dependency: d}
+	}
+	pkg := fmt.Sprintf("%s@%s", d.Name, d.Version)
+
+	switch {
+	case containsID(policy.Deny, id):
+		return finding(RuleDenied, LevelError, fmt.Sprintf("%s is licensed under %s, which is denied by policy", pkg, id))
+	case containsID(policy.Allow, id):
+		return nil
+	case policy.Proprietary && hasPrefix(id, strongCopyleft):
+		return finding(RuleCopyleft, LevelError, fmt.Sprintf("%s is licensed under copyleft %s, not allowed in a proprietary service", pkg, id))
+	case policy.Proprietary && hasPrefix(id, weakCopyleft):
+		return finding(RuleWeakCopyleft, LevelWarning, fmt.Sprintf("%s is licensed under weak copyleft %s; check how it is linked and distributed", pkg, id))
+	case containsID(policy.Review, id):
+		return finding(RuleReview, LevelWarning, fmt.Sprintf("%s is licensed under %s, which requires legal review", pkg, id))
+	case len(policy.Allow) > 0:
+		return finding(RuleNotAllowed, LevelWarning, fmt.Sprintf("%s is licensed under %s, which is not in the allow list", pkg, id))
+	}
+	return nil
+}
+
+// githubRepoOf maps a Go module path to its GitHub repository
+func githubRepoOf(module string) (string, string, bool) {
+	parts := strings.Split(module, "/")
+	switch {
+	case len(parts) >= 3 && parts[0] == "github.com":
+		return parts[1], parts[2], true
+	case len(parts) >= 3 && parts[0] == "golang.org" && parts[1] == "x":
+		return "golang", parts[2], true
+	}
+	return "", "", false
+}
+
+func splitEnv(name string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitExpression splits an SPDX expression on an operator, stripping parentheses
+func splitExpression(expr, op string) []string {
+	var parts []string
+	for _, p := range strings.Split(expr, op) {
+		p = strings.Trim(strings.TrimSpace(p), "()")
+		if p != "" {
+			parts = append(parts, strings.TrimSpace(p))
+		}
+	}
+	return parts
+}
+
+func containsID(list []string, id string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefix(id string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(strings.ToUpper(id), strings.ToUpper(p)) {
+			return true
+		}
+	}
+	return false
+}
+
+func levelRank(level string) int {
+	switch level {
+	case LevelError:
+		return 3
+	case LevelWarning:
+		return 2
+	case LevelNote:
+		return 1
+	}
+	return 0
+}
+
+func worstLevel(findings []Finding) int {
+	worst := 0
+	for _, f := range findings {
+		if r := levelRank(f.Level); r > worst {
+			worst = r
+		}
+	}
+	return worst
+}
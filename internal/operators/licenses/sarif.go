@@ -0,0 +1,163 @@
+package licenses
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+var ruleDescriptions = map[string]string{
+	RuleDenied:       "Dependency license is explicitly denied by policy",
+	RuleCopyleft:     "Strong copyleft license in a proprietary service",
+	RuleWeakCopyleft: "Weak copyleft license in a proprietary service",
+	RuleReview:       "Dependency license requires legal review",
+	RuleNotAllowed:   "Dependency license is not in the allow list",
+	RuleUnknown:      "Dependency has no declared license",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+// ToSARIF encodes the findings as a SARIF 2.1.0 log with one run per repository
+func ToSARIF(reports []*Report) ([]byte, error) {
+	log := sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{}}
+
+	for _, report := range reports {
+		run := sarifRun{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "ghbex-licenses",
+				InformationURI: "https://github.com/kubex-ecosystem/ghbex",
+				Rules:          sarifRules(),
+			}},
+			Results: []sarifResult{},
+		}
+		for _, f := range report.Findings {
+			result := sarifResult{
+				RuleID:  f.RuleID,
+				Level:   f.Level,
+				Message: sarifMessage{Text: f.Message},
+				Properties: map[string]string{
+					"repository": report.Owner + "/" + report.Repo,
+					"package":    f.Dependency.Name,
+					"version":    f.Dependency.Version,
+					"ecosystem":  f.Dependency.Ecosystem,
+					"license":    f.License,
+				},
+			}
+			if f.Dependency.Manifest != "" {
+				result.Locations = []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: f.Dependency.Manifest}},
+				}}
+			}
+			run.Results = append(run.Results, result)
+		}
+		log.Runs = append(log.Runs, run)
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode SARIF log: %w", err)
+	}
+	return data, nil
+}
+
+// ToMarkdown renders a summary of the license findings
+func ToMarkdown(reports []*Report) string {
+	var sb strings.Builder
+	sb.WriteString("# Dependency License Compliance\n\n")
+	sb.WriteString("| Repository | Checked | Unknown | Errors | Warnings |\n")
+	sb.WriteString("|------------|---------|---------|--------|----------|\n")
+	for _, r := range reports {
+		errors, warnings := 0, 0
+		for _, f := range r.Findings {
+			switch f.Level {
+			case LevelError:
+				errors++
+			case LevelWarning:
+				warnings++
+			}
+		}
+		sb.WriteString(fmt.Sprintf("| %s/%s | %d | %d | %d | %d |\n", r.Owner, r.Repo, r.Checked, r.Unknown, errors, warnings))
+	}
+
+	for _, r := range reports {
+		if len(r.Findings) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n## %s/%s\n\n", r.Owner, r.Repo))
+		sb.WriteString("| Level | Rule | Package | License | Manifest |\n")
+		sb.WriteString("|-------|------|---------|---------|----------|\n")
+		for _, f := range r.Findings {
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %s@%s | %s | %s |\n",
+				f.Level, f.RuleID, f.Dependency.Name, f.Dependency.Version, f.License, f.Dependency.Manifest))
+		}
+	}
+	return sb.String()
+}
+
+func sarifRules() []sarifRule {
+	ids := make([]string, 0, len(ruleDescriptions))
+	for id := range ruleDescriptions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	rules := make([]sarifRule, 0, len(ids))
+	for _, id := range ids {
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: ruleDescriptions[id]}})
+	}
+	return rules
+}
@@ -0,0 +1,61 @@
+package licenses
+
+import "github.com/kubex-ecosystem/ghbex/internal/operators/deps"
+
+// Finding levels (SARIF levels)
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Rule ids reported in findings
+const (
+	RuleDenied         = "license/denied"
+	RuleCopyleft       = "license/copyleft-in-proprietary"
+	RuleWeakCopyleft   = "license/weak-copyleft-in-proprietary"
+	RuleReview         = "license/needs-review"
+	RuleNotAllowed     = "license/not-in-allow-list"
+	RuleUnknown        = "license/unknown"
+	unknownLicenseText = "NOASSERTION"
+)
+
+// Policy is the license compliance policy of a repository
+type Policy struct {
+	Allow         []string `json:"allow"`
+	Deny          []string `json:"deny"`
+	Review        []string `json:"review"`
+	Proprietary   bool     `json:"proprietary"`
+	UnknownPolicy string   `json:"unknown_policy"` // "allow", "review" or "deny"
+}
+
+// Finding is a single policy violation or review item
+type Finding struct {
+	RuleID     string          `json:"rule_id"`
+	Level      string          `json:"level"`
+	License    string          `json:"license"`
+	Message    string          `json:"message"`
+	Dependency deps.Dependency `json:"dependency"`
+}
+
+// Report is the license compliance result of a repository
+type Report struct {
+	Owner    string         `json:"owner"`
+	Repo     string         `json:"repo"`
+	Policy   Policy         `json:"policy"`
+	Checked  int            `json:"checked"`
+	Unknown  int            `json:"unknown"`
+	ByRule   map[string]int `json:"by_rule"`
+	Findings []Finding      `json:"findings"`
+}
+
+// Issues counts the findings that need action (errors and warnings)
+func (r *Report) Issues() int {
+	issues := 0
+	for _, f := range r.Findings {
+		if f.Level == LevelError || f.Level == LevelWarning {
+			issues++
+		}
+	}
+	return issues
+}
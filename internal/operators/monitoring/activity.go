@@ -7,10 +7,20 @@ import (
 	"time"
 
 	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/fanout"
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
 )
 
-// AnalyzeRepositoryActivity analyzes repository activity and generates a report
+// AnalyzeRepositoryActivity analyzes repository activity and generates a report,
+// listing the whole issue and pull request history
 func AnalyzeRepositoryActivity(ctx context.Context, cli *github.Client, owner, repo string, inactiveDaysThreshold int) (*ActivityReport, error) {
+	return AnalyzeRepositoryActivityIncremental(ctx, cli, nil, owner, repo, inactiveDaysThreshold, incremental.Options{})
+}
+
+// AnalyzeRepositoryActivityIncremental analyzes repository activity from the issues and pull
+// requests stored in the sync store, fetching only what changed since the previous run.
+// A nil store lists the whole history without persisting it.
+func AnalyzeRepositoryActivityIncremental(ctx context.Context, cli *github.Client, store *incremental.Store, owner, repo string, inactiveDaysThreshold int, opts incremental.Options) (*ActivityReport, error) {
 	report := &ActivityReport{
 		Owner:       owner,
 		Repo:        repo,
@@ -19,15 +29,24 @@ func AnalyzeRepositoryActivity(ctx context.Context, cli *github.Client, owner, r
 		CommitStats: &CommitStats{},
 	}
 
-	// Analyze Pull Requests
-	if err := analyzePullRequests(ctx, cli, owner, repo, report.PRStats); err != nil {
-		return nil, fmt.Errorf("failed to analyze pull requests: %w", err)
+	// Sync issues and pull requests
+	var snap *incremental.Snapshot
+	var result incremental.Result
+	var err error
+	if store != nil {
+		snap, result, err = store.SyncRepository(ctx, cli, owner, repo, opts)
+	} else {
+		snap = incremental.NewSnapshot(owner, repo)
+		result, err = incremental.Sync(ctx, cli, snap, incremental.Options{FullResync: true})
 	}
-
-	// Analyze Issues
-	if err := analyzeIssues(ctx, cli, owner, repo, report.IssueStats); err != nil {
-		return nil, fmt.Errorf("failed to analyze issues: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync issues and pull requests: %w", err)
 	}
+	report.Sync = &result
+
+	// Analyze Pull Requests and Issues
+	analyzePullRequests(snap, report.PRStats)
+	analyzeIssues(snap, report.IssueStats)
 
 	// Analyze Commits
 	if err := analyzeCommits(ctx, cli, owner, repo, report.CommitStats); err != nil {
@@ -45,18 +64,22 @@ func AnalyzeRepositoryActivity(ctx context.Context, cli *github.Client, owner, r
 	return report, nil
 }
 
-// CheckInactiveRepositories checks multiple repositories for inactivity
+// CheckInactiveRepositories checks multiple repositories for inactivity, concurrently with the
+// default fan-out options. Results may be partial: when some repositories fail, the reports of
+// the ones that succeeded are still returned, together with a non-nil *fanout.Errors listing
+// the failures, so callers should use the reports before giving up on the error.
 func CheckInactiveRepositories(ctx context.Context, cli *github.Client, repos []struct{ Owner, Name string }, inactiveDaysThreshold int) ([]*ActivityReport, error) {
-	var reports []*ActivityReport
-
+	targets := make([]fanout.Repo, 0, len(repos))
 	for _, repo := range repos {
-		report, err := AnalyzeRepositoryActivity(ctx, cli, repo.Owner, repo.Name, inactiveDaysThreshold)
-		if err != nil {
-			// Log error but continue with other repositories
-			continue
-		}
-		reports = append(reports, report)
+		targets = append(targets, fanout.Repo{Owner: repo.Owner, Name: repo.Name})
 	}
+	report := CheckRepositoriesActivity(ctx, cli, targets, inactiveDaysThreshold, fanout.DefaultOptions())
+	return report.Values(), report.Err()
+}
 
-	return reports, nil
+// CheckRepositoriesActivity analyzes the activity of repos with bounded concurrency
+func CheckRepositoriesActivity(ctx context.Context, cli *github.Client, repos []fanout.Repo, inactiveDaysThreshold int, opts fanout.Options) *fanout.Report[*ActivityReport] {
+	return fanout.Run(ctx, repos, opts, func(ctx context.Context, repo fanout.Repo) (*ActivityReport, error) {
+		return AnalyzeRepositoryActivity(ctx, cli, repo.Owner, repo.Name, inactiveDaysThreshold)
+	})
 }
@@ -0,0 +1,44 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
+	rt "github.com/kubex-ecosystem/ghbex/internal/runtime"
+)
+
+// OperatorInactivity is the runtime name of the inactivity check
+const OperatorInactivity = "monitoring.inactivity"
+
+// Register adds the inactivity check to a runtime registry. It reads threshold_days from the
+// params, syncs through the default incremental store and needs a *github.Client in the
+// GitHub client bundle. An inactive repository is reported as an insight.
+func Register(reg rt.Registry) {
+	reg.Register(rt.NewOperator(OperatorInactivity, "1.0.0", func(ctx context.Context, in rt.OpInput) (rt.OpOutput, error) {
+		cli, ok := rt.GitHubClient(in)
+		if !ok {
+			return rt.OpOutput{}, fmt.Errorf("%s needs a GitHub client", OperatorInactivity)
+		}
+		threshold := rt.ParamInt(in.Params, "threshold_days", 30)
+		report, err := AnalyzeRepositoryActivityIncremental(ctx, cli, incremental.NewStore(""), in.Repo.Owner, in.Repo.Name, threshold, incremental.Options{})
+		if err != nil {
+			return rt.OpOutput{}, err
+		}
+		data, _ := json.MarshalIndent(report, "", "  ")
+		out := rt.OpOutput{
+			Data:      report,
+			Metrics:   []rt.Metric{{Name: "days_inactive", Value: float64(report.DaysInactive), Unit: "days"}},
+			Artifacts: map[string][]byte{"activity.json": data},
+		}
+		if report.IsInactive {
+			out.Insights = append(out.Insights, rt.Insight{
+				Key:     "inactive",
+				Summary: fmt.Sprintf("%s/%s has been inactive for %d days (threshold %d)", in.Repo.Owner, in.Repo.Name, report.DaysInactive, threshold),
+				Score:   1,
+			})
+		}
+		return out, nil
+	}))
+}
@@ -5,97 +5,51 @@ import (
 	"time"
 
 	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
 )
 
-// analyzePullRequests analyzes pull request statistics
-func analyzePullRequests(ctx context.Context, cli *github.Client, owner, repo string, stats *PullRequestStats) error {
-	// List all PRs (open and closed)
-	allOpt := &github.PullRequestListOptions{
-		State:       "all",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-
-	for {
-		prs, resp, err := cli.PullRequests.List(ctx, owner, repo, allOpt)
-		if err != nil {
-			return err
-		}
-
-		for _, pr := range prs {
-			switch pr.GetState() {
-			case "open":
-				stats.Open++
-				if stats.OldestPR.IsZero() || pr.GetCreatedAt().Time.Before(stats.OldestPR) {
-					stats.OldestPR = pr.GetCreatedAt().Time
-				}
-			case "closed":
-				if pr.MergedAt != nil {
-					stats.Merged++
-				} else {
-					stats.Closed++
-				}
+// analyzePullRequests computes pull request statistics from the synced snapshot
+func analyzePullRequests(snap *incremental.Snapshot, stats *PullRequestStats) {
+	for _, pr := range snap.PullRequests() {
+		switch pr.State {
+		case "open":
+			stats.Open++
+			if stats.OldestPR.IsZero() || pr.CreatedAt.Before(stats.OldestPR) {
+				stats.OldestPR = pr.CreatedAt
 			}
-
-			// Track latest PR activity
-			prTime := pr.GetUpdatedAt().Time
-			if prTime.After(stats.LastPR) {
-				stats.LastPR = prTime
+		case "closed":
+			if pr.Merged {
+				stats.Merged++
+			} else {
+				stats.Closed++
 			}
 		}
 
-		if resp.NextPage == 0 {
-			break
+		// Track latest PR activity
+		if pr.UpdatedAt.After(stats.LastPR) {
+			stats.LastPR = pr.UpdatedAt
 		}
-		allOpt.Page = resp.NextPage
 	}
-
-	return nil
 }
 
-// analyzeIssues analyzes issue statistics
-func analyzeIssues(ctx context.Context, cli *github.Client, owner, repo string, stats *IssueStats) error {
-	// List all issues (open and closed)
-	allOpt := &github.IssueListByRepoOptions{
-		State:       "all",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-
-	for {
-		issues, resp, err := cli.Issues.ListByRepo(ctx, owner, repo, allOpt)
-		if err != nil {
-			return err
-		}
-
-		for _, issue := range issues {
-			// Skip pull requests (they appear in issues API)
-			if issue.IsPullRequest() {
-				continue
-			}
-
-			switch issue.GetState() {
-			case "open":
-				stats.Open++
-				if stats.OldestIssue.IsZero() || issue.GetCreatedAt().Time.Before(stats.OldestIssue) {
-					stats.OldestIssue = issue.GetCreatedAt().Time
-				}
-			case "closed":
-				stats.Closed++
-			}
-
-			// Track latest issue activity
-			issueTime := issue.GetUpdatedAt().Time
-			if issueTime.After(stats.LastIssue) {
-				stats.LastIssue = issueTime
+// analyzeIssues computes issue statistics from the synced snapshot
+func analyzeIssues(snap *incremental.Snapshot, stats *IssueStats) {
+	for _, issue := range snap.Issues() {
+		switch issue.State {
+		case "open":
+			stats.Open++
+			if stats.OldestIssue.IsZero() || issue.CreatedAt.Before(stats.OldestIssue) {
+				stats.OldestIssue = issue.CreatedAt
 			}
+		case "closed":
+			stats.Closed++
 		}
 
-		if resp.NextPage == 0 {
-			break
+		// Track latest issue activity
+		if issue.UpdatedAt.After(stats.LastIssue) {
+			stats.LastIssue = issue.UpdatedAt
 		}
-		allOpt.Page = resp.NextPage
 	}
-
-	return nil
 }
 
 // analyzeCommits analyzes commit statistics
@@ -1,6 +1,10 @@
 package monitoring
 
-import "time"
+import (
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/incremental"
+)
 
 // ActivityReport represents repository activity analysis
 type ActivityReport struct {
@@ -12,6 +16,9 @@ type ActivityReport struct {
 	PRStats      *PullRequestStats `json:"pr_stats"`
 	IssueStats   *IssueStats       `json:"issue_stats"`
 	CommitStats  *CommitStats      `json:"commit_stats"`
+
+	// Sync describes how the issues and pull requests were fetched
+	Sync *incremental.Result `json:"sync,omitempty"`
 }
 
 // PullRequestStats represents PR statistics
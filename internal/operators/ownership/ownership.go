@@ -0,0 +1,274 @@
+// Package ownership computes file-ownership based bus factor, knowledge silos and
+// departed-owner risk from the recent commit history of a repository.
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
+	"github.com/kubex-ecosystem/ghbex/internal/identity"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// DefaultOptions returns a 6 month window, 90 days inactivity and directory depth 2
+func DefaultOptions() Options {
+	return Options{
+		WindowDays:   180,
+		InactiveDays: 90,
+		MaxCommits:   300,
+		Depth:        2,
+		Threshold:    0.5,
+		MinChanges:   3,
+	}
+}
+
+// Analyze lists the commits of the window with their files and computes the ownership report
+func Analyze(ctx context.Context, cli *github.Client, owner, repo string, opts Options) (*Report, error) {
+	if cli == nil {
+		return nil, fmt.Errorf("GitHub client is nil")
+	}
+	opts = normalizeOptions(opts)
+	now := time.Now()
+	since := now.AddDate(0, 0, -opts.WindowDays)
+
+	people := identity.ForRepository(ctx, cli, owner, repo)
+	commits, truncated, err := listCommitChanges(ctx, cli, people, owner, repo, since, opts.MaxCommits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	recent, err := listActivity(ctx, cli, people, owner, repo, now.AddDate(0, 0, -opts.InactiveDays))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent activity: %w", err)
+	}
+	// Canonicalize once every alias seen by both listings is linked
+	for i := range commits {
+		commits[i].Author = people.Canonical(commits[i].Author)
+	}
+	active := make(map[string]time.Time, len(recent))
+	for author, at := range recent {
+		if key := people.Canonical(author); at.After(active[key]) {
+			active[key] = at
+		}
+	}
+
+	report := ComputeWithActivity(commits, active, opts, now)
+	report.Owner = owner
+	report.Repo = repo
+	report.Since = since
+	report.Truncated = truncated
+	return report, nil
+}
+
+// Compute builds the ownership report from already collected commits
+func Compute(commits []CommitChange, opts Options, now time.Time) *Report {
+	return ComputeWithActivity(commits, nil, opts, now)
+}
+
+// ComputeWithActivity builds the ownership report from already collected commits, taking
+// the last activity of each author from active as well, so that authors missing from a
+// truncated commit sample are not reported as departed
+func ComputeWithActivity(commits []CommitChange, active map[string]time.Time, opts Options, now time.Time) *Report {
+	opts = normalizeOptions(opts)
+	report := &Report{
+		CommitsAnalyzed: len(commits),
+		Silos:           []string{},
+		DepartedOwners:  []string{},
+		Paths:           []PathOwnership{},
+	}
+
+	lastCommit := make(map[string]time.Time, len(active))
+	for author, at := range active {
+		lastCommit[author] = at
+	}
+	repoChanges := make(map[string]int)
+	pathChanges := make(map[string]map[string]int)
+
+	for _, c := range commits {
+		if c.At.After(lastCommit[c.Author]) {
+			lastCommit[c.Author] = c.At
+		}
+		repoChanges[c.Author]++
+
+		seen := make(map[string]bool)
+		for _, file := range c.Files {
+			path := groupPath(file, opts.Depth)
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			if pathChanges[path] == nil {
+				pathChanges[path] = make(map[string]int)
+			}
+			pathChanges[path][c.Author]++
+		}
+	}
+
+	departed := func(author string) bool {
+		return now.Sub(lastCommit[author]) > time.Duration(opts.InactiveDays)*24*time.Hour
+	}
+
+	report.Authors = authorShares(repoChanges, lastCommit, departed)
+	report.BusFactor = metrics.BusFactorFromCounts(repoChanges, opts.Threshold)
+	report.Owners = ownerSet(report.Authors, opts.Threshold)
+
+	departedOwners := make(map[string]bool)
+	for path, changes := range pathChanges {
+		authors := authorShares(changes, lastCommit, departed)
+		p := PathOwnership{
+			Path:      path,
+			Changes:   sumCounts(changes),
+			BusFactor: metrics.BusFactorFromCounts(changes, opts.Threshold),
+			Owners:    ownerSet(authors, opts.Threshold),
+			Authors:   authors,
+		}
+		p.Silo = len(authors) == 1 && p.Changes >= opts.MinChanges
+
+		allDeparted := len(p.Owners) > 0
+		for _, o := range p.Owners {
+			if departed(o) {
+				p.DepartedOwners = append(p.DepartedOwners, o)
+				departedOwners[o] = true
+			} else {
+				allDeparted = false
+			}
+		}
+
+		switch {
+		case p.Silo || allDeparted:
+			p.Risk = RiskHigh
+		case p.BusFactor <= 1 || len(p.DepartedOwners) > 0:
+			p.Risk = RiskMedium
+		default:
+			p.Risk = RiskLow
+		}
+
+		if p.Silo {
+			report.Silos = append(report.Silos, path)
+		}
+		report.Paths = append(report.Paths, p)
+	}
+
+	for o := range departedOwners {
+		report.DepartedOwners = append(report.DepartedOwners, o)
+	}
+	sort.Strings(report.Silos)
+	sort.Strings(report.DepartedOwners)
+	sort.Slice(report.Paths, func(i, j int) bool {
+		ri, rj := riskRank(report.Paths[i].Risk), riskRank(report.Paths[j].Risk)
+		if ri != rj {
+			return ri > rj
+		}
+		if report.Paths[i].Changes != report.Paths[j].Changes {
+			return report.Paths[i].Changes > report.Paths[j].Changes
+		}
+		return report.Paths[i].Path < report.Paths[j].Path
+	})
+
+	return report
+}
+
+// ToRisk converts the report to the risk section of the sanitize report, keeping at most limit paths
+func (r *Report) ToRisk(limit int) gitz.Risk {
+	risk := gitz.Risk{
+		Analyzed:        true,
+		CommitsAnalyzed: r.CommitsAnalyzed,
+		BusFactor:       r.BusFactor,
+		Owners:          r.Owners,
+		Silos:           r.Silos,
+		DepartedOwners:  r.DepartedOwners,
+		Paths:           []gitz.RiskPath{},
+	}
+	if limit <= 0 || limit > len(r.Paths) {
+		limit = len(r.Paths)
+	}
+	for _, p := range r.Paths[:limit] {
+		risk.Paths = append(risk.Paths, gitz.RiskPath{
+			Path:           p.Path,
+			Risk:           p.Risk,
+			BusFactor:      p.BusFactor,
+			Owners:         p.Owners,
+			Changes:        p.Changes,
+			Silo:           p.Silo,
+			DepartedOwners: p.DepartedOwners,
+		})
+	}
+	return risk
+}
+
+// listActivity returns the last commit time of each person (see identity) with commits since
+// the given time. It pages through the whole window but only lists commits, so merge commits
+// and commits beyond MaxCommits still count as activity.
+func listActivity(ctx context.Context, cli *github.Client, people *identity.Resolver, owner, repo string, since time.Time) (map[string]time.Time, error) {
+	active := make(map[string]time.Time)
+	opts := &github.CommitsListOptions{Since: since, ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		commits, resp, err := cli.Repositories.ListCommits(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range commits {
+			author, counted := people.Key(c.GetAuthor().GetLogin(), c.GetCommit().GetAuthor().GetName(), c.GetCommit().GetAuthor().GetEmail())
+			if !counted {
+				continue
+			}
+			if at := c.GetCommit().GetAuthor().GetDate().Time; at.After(active[author]) {
+				active[author] = at
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return active, nil
+}
+
+// listCommitChanges lists the non-merge commits of people (see identity) since the given time with their files
+func listCommitChanges(ctx context.Context, cli *github.Client, people *identity.Resolver, owner, repo string, since time.Time, limit int) ([]CommitChange, bool, error) {
+	var changes []CommitChange
+	opts := &github.CommitsListOptions{Since: since, ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		commits, resp, err := cli.Repositories.ListCommits(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, c := range commits {
+			if len(c.Parents) > 1 {
+				continue
+			}
+			author, counted := people.Key(c.GetAuthor().GetLogin(), c.GetCommit().GetAuthor().GetName(), c.GetCommit().GetAuthor().GetEmail())
+			if !counted {
+				continue
+			}
+			if len(changes) >= limit {
+				return changes, true, nil
+			}
+
+			full, _, err := cli.Repositories.GetCommit(ctx, owner, repo, c.GetSHA(), nil)
+			if err != nil {
+				gl.Log("debug", fmt.Sprintf("Failed to get commit %s of %s/%s: %v", c.GetSHA(), owner, repo, err))
+				continue
+			}
+			change := CommitChange{
+				SHA:    c.GetSHA(),
+				Author: author,
+				At:     c.GetCommit().GetAuthor().GetDate().Time,
+			}
+			for _, f := range full.Files {
+				change.Files = append(change.Files, f.GetFilename())
+			}
+			changes = append(changes, change)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return changes, false, nil
+}
@@ -0,0 +1,104 @@
+package ownership
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+func normalizeOptions(opts Options) Options {
+	def := DefaultOptions()
+	if opts.WindowDays <= 0 {
+		opts.WindowDays = def.WindowDays
+	}
+	if opts.InactiveDays <= 0 {
+		opts.InactiveDays = def.InactiveDays
+	}
+	if opts.MaxCommits <= 0 {
+		opts.MaxCommits = def.MaxCommits
+	}
+	if opts.Depth <= 0 {
+		opts.Depth = def.Depth
+	}
+	if opts.Threshold <= 0 || opts.Threshold >= 1 {
+		opts.Threshold = def.Threshold
+	}
+	if opts.MinChanges <= 0 {
+		opts.MinChanges = def.MinChanges
+	}
+	return opts
+}
+
+// groupPath returns the directory of file truncated to depth; root-level files are their own path
+func groupPath(file string, depth int) string {
+	dir := path.Dir(file)
+	if dir == "." {
+		return file
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/") + "/"
+}
+
+// authorShares sorts the authors of a change map by changes, largest first
+func authorShares(changes map[string]int, lastCommit map[string]time.Time, departed func(string) bool) []AuthorShare {
+	total := sumCounts(changes)
+	shares := make([]AuthorShare, 0, len(changes))
+	for author, n := range changes {
+		share := 0.0
+		if total > 0 {
+			share = float64(n) / float64(total)
+		}
+		shares = append(shares, AuthorShare{
+			Author:     author,
+			Changes:    n,
+			Share:      share,
+			LastCommit: lastCommit[author],
+			Departed:   departed(author),
+		})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Changes != shares[j].Changes {
+			return shares[i].Changes > shares[j].Changes
+		}
+		return shares[i].Author < shares[j].Author
+	})
+	return shares
+}
+
+// ownerSet returns the smallest prefix of the sorted authors whose share exceeds threshold
+func ownerSet(shares []AuthorShare, threshold float64) []string {
+	owners := []string{}
+	covered := 0.0
+	for _, s := range shares {
+		owners = append(owners, s.Author)
+		covered += s.Share
+		if covered > threshold {
+			break
+		}
+	}
+	return owners
+}
+
+func sumCounts(counts map[string]int) int {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	return total
+}
+
+func riskRank(risk string) int {
+	switch risk {
+	case RiskHigh:
+		return 3
+	case RiskMedium:
+		return 2
+	case RiskLow:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,64 @@
+package ownership
+
+import "time"
+
+// Risk levels of a path
+const (
+	RiskHigh   = "high"
+	RiskMedium = "medium"
+	RiskLow    = "low"
+)
+
+// Options tunes the ownership analysis
+type Options struct {
+	WindowDays   int     // History window for ownership and silos (default 180)
+	InactiveDays int     // Days without commits after which an author counts as departed (default 90)
+	MaxCommits   int     // Upper bound of commits inspected, each costs one API call (default 300)
+	Depth        int     // Directory depth used to group files (default 2)
+	Threshold    float64 // Share of changes the owner set must exceed (default 0.5)
+	MinChanges   int     // Paths with fewer changes are not flagged as silos (default 3)
+}
+
+// CommitChange is one commit reduced to what the ownership analysis needs
+type CommitChange struct {
+	SHA    string    `json:"sha"`
+	Author string    `json:"author"`
+	At     time.Time `json:"at"`
+	Files  []string  `json:"files"`
+}
+
+// AuthorShare is the share of a path's changes made by one author
+type AuthorShare struct {
+	Author     string    `json:"author"`
+	Changes    int       `json:"changes"`
+	Share      float64   `json:"share"`
+	LastCommit time.Time `json:"last_commit"`
+	Departed   bool      `json:"departed"`
+}
+
+// PathOwnership is the ownership of a directory (or a root-level file)
+type PathOwnership struct {
+	Path           string        `json:"path"`
+	Changes        int           `json:"changes"`
+	BusFactor      int           `json:"bus_factor"`
+	Owners         []string      `json:"owners"`
+	Authors        []AuthorShare `json:"authors"`
+	Silo           bool          `json:"silo"`
+	DepartedOwners []string      `json:"departed_owners,omitempty"`
+	Risk           string        `json:"risk"`
+}
+
+// Report is the ownership and knowledge-risk analysis of a repository
+type Report struct {
+	Owner           string          `json:"owner"`
+	Repo            string          `json:"repo"`
+	Since           time.Time       `json:"since"`
+	CommitsAnalyzed int             `json:"commits_analyzed"`
+	Truncated       bool            `json:"truncated"`
+	BusFactor       int             `json:"bus_factor"`
+	Owners          []string        `json:"owners"`
+	Authors         []AuthorShare   `json:"authors"`
+	Paths           []PathOwnership `json:"paths"`
+	Silos           []string        `json:"silos"`
+	DepartedOwners  []string        `json:"departed_owners"`
+}
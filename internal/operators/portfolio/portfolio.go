@@ -0,0 +1,186 @@
+// Package portfolio rolls up the insights, automation and scorecard reports of many
+// repositories into one org-level scorecard with rankings, distributions and groups.
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/analytics"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/automation"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/intelligence"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/licenses"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/productivity"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/workflows"
+)
+
+// maxWorkflowRuns bounds the runs read for the workflow failure rate
+const maxWorkflowRuns = 500
+
+// metricDirections lists the rolled-up metrics and whether higher values are better
+var metricDirections = []struct {
+	name           string
+	higherIsBetter bool
+}{
+	{MetricHealth, true},
+	{MetricCHI, true},
+	{MetricAutomation, true},
+	{MetricBusFactor, true},
+	{MetricOpenPRs, false},
+	{MetricStalePRs, false},
+	{MetricStaleBranches, false},
+	{MetricVulnerableDeps, false},
+	{MetricFirstReview, false},
+}
+
+// DefaultOptions returns the options used by the portfolio command
+func DefaultOptions() Options {
+	return Options{Days: 30, WorstN: 5}
+}
+
+// Collect runs the analyses of one repository. Failures of optional analyses are
+// recorded in Result.Errors; only a failed insights analysis is returned as an error.
+func Collect(ctx context.Context, cli *github.Client, repo Repository, opts Options) (*Result, error) {
+	result := &Result{Repository: repo, Groups: []string{}}
+
+	insights, err := analytics.AnalyzeRepositoryWithPolicy(ctx, cli, repo.Owner, repo.Name, opts.Days, licenses.PolicyForRepository(opts.Config, repo.Owner, repo.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s/%s: %w", repo.Owner, repo.Name, err)
+	}
+	result.Insights = insights
+	result.Scorecard = intelligence.ScorecardFromInsights(insights)
+
+	if !opts.SkipAutomation {
+		if auto, err := automation.AnalyzeAutomation(ctx, cli, repo.Owner, repo.Name, opts.Days); err != nil {
+			result.Errors = append(result.Errors, "automation: "+err.Error())
+		} else {
+			result.Automation = auto
+		}
+		if rate, runs, err := workflows.FailureRate(ctx, cli, repo.Owner, repo.Name, opts.Days, maxWorkflowRuns); err != nil {
+			result.Errors = append(result.Errors, "workflows: "+err.Error())
+		} else if runs > 0 {
+			result.WorkflowFailureRate = &rate
+		}
+	}
+	if !opts.SkipBranches {
+		if branches, err := productivity.AnalyzeBranches(ctx, cli, repo.Owner, repo.Name); err != nil {
+			result.Errors = append(result.Errors, "branches: "+err.Error())
+		} else {
+			result.StaleBranches = len(branches.StaleBranches)
+		}
+	}
+	if groups, err := repositoryGroups(ctx, cli, repo, opts.GroupBy); err != nil {
+		result.Errors = append(result.Errors, "groups: "+err.Error())
+	} else {
+		result.Groups = groups
+	}
+	return result, nil
+}
+
+// Summarize extracts the rolled-up metrics of a repository
+func Summarize(r *Result) RepoSummary {
+	s := RepoSummary{
+		Repository:    r.Repository.Owner + "/" + r.Repository.Name,
+		Groups:        r.Groups,
+		StaleBranches: r.StaleBranches,
+		Percentiles:   map[string]float64{},
+		Errors:        r.Errors,
+	}
+	if in := r.Insights; in != nil {
+		if in.HealthScore != nil {
+			s.Health, s.HealthGrade = in.HealthScore.Overall, in.HealthScore.Grade
+		}
+		if in.CodeIntel != nil && in.CodeIntel.Dependencies != nil {
+			s.VulnerableDeps = in.CodeIntel.Dependencies.VulnerableCount
+		}
+		if in.Community != nil && in.Community.Collaboration != nil {
+			s.FirstReviewP50 = in.Community.Collaboration.FirstReviewP50
+		}
+	}
+	if sc := r.Scorecard; sc != nil {
+		s.CHI, s.CHIGrade = sc.Health.CHI, sc.Health.Grade
+		s.BusFactor = sc.Community.BusFactor
+	}
+	if a := r.Automation; a != nil {
+		s.Automation, s.AutomationGrade = a.AutomationScore, a.Grade
+		s.OpenPRs, s.StalePRs = a.PullRequests.OpenPRs, a.PullRequests.StalePRs
+		s.OpenIssues, s.StaleIssues = a.Issues.OpenIssues, a.Issues.StaleIssues
+	}
+	return s
+}
+
+// Snapshot is the set of metrics of a result recorded in the history store
+func Snapshot(r *Result) map[string]float64 {
+	values := history.FromScorecard(r.Scorecard)
+	if values == nil {
+		values = map[string]float64{}
+	}
+	s := Summarize(r)
+	for _, metric := range []string{MetricHealth, MetricAutomation, MetricOpenPRs, MetricStalePRs, MetricStaleBranches} {
+		if v, ok := metricValue(s, metric); ok {
+			values[metric] = v
+		}
+	}
+	if r.WorkflowFailureRate != nil {
+		values[history.MetricWorkflowFailureRate] = *r.WorkflowFailureRate
+	}
+	return values
+}
+
+// Build aggregates the results of a portfolio
+func Build(results []*Result, opts Options, now time.Time) *Rollup {
+	rollup := &Rollup{
+		GeneratedAt:       now,
+		PeriodDays:        opts.Days,
+		GroupBy:           opts.GroupBy,
+		Stats:             []MetricStats{},
+		GradeDistribution: map[string]map[string]int{MetricHealth: {}, MetricCHI: {}, MetricAutomation: {}},
+		Worst:             []Ranking{},
+		Repositories:      make([]RepoSummary, 0, len(results)),
+	}
+	for _, r := range results {
+		s := Summarize(r)
+		rollup.Repositories = append(rollup.Repositories, s)
+		addTotals(&rollup.Totals, s)
+		for metric, grade := range map[string]string{MetricHealth: s.HealthGrade, MetricCHI: s.CHIGrade, MetricAutomation: s.AutomationGrade} {
+			if grade != "" {
+				rollup.GradeDistribution[metric][grade]++
+			}
+		}
+	}
+	sort.Slice(rollup.Repositories, func(i, j int) bool {
+		return rollup.Repositories[i].Repository < rollup.Repositories[j].Repository
+	})
+
+	for _, m := range metricDirections {
+		// Repositories where the metric was not measured stay out of its statistics and rankings
+		var values []float64
+		var measured []int
+		for i, s := range rollup.Repositories {
+			if v, ok := metricValue(s, m.name); ok {
+				values = append(values, v)
+				measured = append(measured, i)
+			}
+		}
+		rollup.Stats = append(rollup.Stats, MetricStats{
+			Metric: m.name,
+			Min:    metrics.Percentile(values, 0),
+			P50:    metrics.Percentile(values, 50),
+			P90:    metrics.Percentile(values, 90),
+			Max:    metrics.Percentile(values, 100),
+			Mean:   metrics.Mean(values),
+		})
+		for k, i := range measured {
+			rollup.Repositories[i].Percentiles[m.name] = percentileRank(values, values[k], m.higherIsBetter)
+		}
+		rollup.Worst = append(rollup.Worst, worstN(rollup.Repositories, measured, values, m.name, m.higherIsBetter, opts.WorstN))
+	}
+
+	rollup.Groups = buildGroups(rollup.Repositories)
+	return rollup
+}
@@ -0,0 +1,159 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+)
+
+// ungrouped names the group of repositories without topic or team
+const ungrouped = "(none)"
+
+func repositoryGroups(ctx context.Context, cli *github.Client, repo Repository, groupBy string) ([]string, error) {
+	switch groupBy {
+	case GroupByTopic:
+		topics, _, err := cli.Repositories.ListAllTopics(ctx, repo.Owner, repo.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list topics: %w", err)
+		}
+		return topics, nil
+	case GroupByTeam:
+		teams, _, err := cli.Repositories.ListTeams(ctx, repo.Owner, repo.Name, &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams: %w", err)
+		}
+		groups := make([]string, 0, len(teams))
+		for _, t := range teams {
+			groups = append(groups, t.GetSlug())
+		}
+		return groups, nil
+	default:
+		return []string{}, nil
+	}
+}
+
+// metricValue returns a metric of a repository and whether it was measured
+func metricValue(s RepoSummary, metric string) (float64, bool) {
+	switch metric {
+	case MetricHealth:
+		return s.Health, s.HealthGrade != ""
+	case MetricCHI:
+		return s.CHI, s.CHI > 0
+	case MetricAutomation:
+		return s.Automation, s.AutomationGrade != ""
+	case MetricBusFactor:
+		return float64(s.BusFactor), s.BusFactor > 0
+	case MetricFirstReview:
+		return s.FirstReviewP50, s.FirstReviewP50 > 0
+	case MetricOpenPRs:
+		return float64(s.OpenPRs), s.AutomationGrade != ""
+	case MetricStalePRs:
+		return float64(s.StalePRs), s.AutomationGrade != ""
+	case MetricStaleBranches:
+		return float64(s.StaleBranches), true
+	case MetricVulnerableDeps:
+		return float64(s.VulnerableDeps), true
+	}
+	return 0, false
+}
+
+// percentileRank is the share of repositories doing no better than value, so 100 is the best
+func percentileRank(values []float64, value float64, higherIsBetter bool) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	notBetter := 0
+	for _, v := range values {
+		if (higherIsBetter && v <= value) || (!higherIsBetter && v >= value) {
+			notBetter++
+		}
+	}
+	return float64(notBetter) / float64(len(values)) * 100
+}
+
+func worstN(repos []RepoSummary, measured []int, values []float64, metric string, higherIsBetter bool, n int) Ranking {
+	order := make([]int, len(measured))
+	for k := range order {
+		order[k] = k
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if higherIsBetter {
+			return values[order[a]] < values[order[b]]
+		}
+		return values[order[a]] > values[order[b]]
+	})
+
+	ranking := Ranking{Metric: metric, HigherIsBetter: higherIsBetter, Worst: []RankEntry{}}
+	for _, k := range order {
+		if n > 0 && len(ranking.Worst) >= n {
+			break
+		}
+		// a zero count is not a problem worth listing
+		if !higherIsBetter && values[k] == 0 {
+			break
+		}
+		ranking.Worst = append(ranking.Worst, RankEntry{Repository: repos[measured[k]].Repository, Value: values[k]})
+	}
+	return ranking
+}
+
+func addTotals(t *Totals, s RepoSummary) {
+	t.Repositories++
+	t.OpenPRs += s.OpenPRs
+	t.StalePRs += s.StalePRs
+	t.OpenIssues += s.OpenIssues
+	t.StaleIssues += s.StaleIssues
+	t.StaleBranches += s.StaleBranches
+	t.VulnerableDeps += s.VulnerableDeps
+}
+
+// buildGroups aggregates repositories by group; a repository with several topics or teams
+// counts in each of them
+func buildGroups(repos []RepoSummary) []Group {
+	byName := make(map[string]*Group)
+	sums := make(map[string][3][]float64)
+	grouped := false
+	for _, s := range repos {
+		names := s.Groups
+		if len(names) > 0 {
+			grouped = true
+		} else {
+			names = []string{ungrouped}
+		}
+		for _, name := range names {
+			g, ok := byName[name]
+			if !ok {
+				g = &Group{Name: name, Repositories: []string{}}
+				byName[name] = g
+			}
+			g.Repositories = append(g.Repositories, s.Repository)
+			addTotals(&g.Totals, s)
+			acc := sums[name]
+			if s.HealthGrade != "" {
+				acc[0] = append(acc[0], s.Health)
+			}
+			if s.CHI > 0 {
+				acc[1] = append(acc[1], s.CHI)
+			}
+			if s.AutomationGrade != "" {
+				acc[2] = append(acc[2], s.Automation)
+			}
+			sums[name] = acc
+		}
+	}
+	if !grouped {
+		return nil
+	}
+
+	groups := make([]Group, 0, len(byName))
+	for name, g := range byName {
+		acc := sums[name]
+		g.AvgHealth, g.AvgCHI, g.AvgAutomation = metrics.Mean(acc[0]), metrics.Mean(acc[1]), metrics.Mean(acc[2])
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
@@ -0,0 +1,182 @@
+package portfolio
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+var metricTitles = map[string]string{
+	MetricHealth:         "Health score",
+	MetricCHI:            "Code health index",
+	MetricAutomation:     "Automation score",
+	MetricOpenPRs:        "Open PRs",
+	MetricStalePRs:       "Stale PRs",
+	MetricStaleBranches:  "Stale branches",
+	MetricVulnerableDeps: "Vulnerable dependencies",
+	MetricBusFactor:      "Bus factor",
+	MetricFirstReview:    "First review p50 (h)",
+}
+
+// MetricTitle returns the display name of a metric
+func MetricTitle(metric string) string {
+	if title, ok := metricTitles[metric]; ok {
+		return title
+	}
+	return metric
+}
+
+// ToMarkdown renders the rollup as a weekly portfolio summary
+func ToMarkdown(r *Rollup) string {
+	var b strings.Builder
+	b.WriteString("# Portfolio Scorecard\n\n")
+	b.WriteString(fmt.Sprintf("_Generated %s over the last %d days for %d repositories_\n\n",
+		r.GeneratedAt.Format("2006-01-02 15:04 MST"), r.PeriodDays, r.Totals.Repositories))
+
+	t := r.Totals
+	b.WriteString("## Totals\n\n")
+	b.WriteString("| Open PRs | Stale PRs | Open Issues | Stale Issues | Stale Branches | Vulnerable Deps |\n")
+	b.WriteString("|----------|-----------|-------------|--------------|----------------|-----------------|\n")
+	b.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %d | %d |\n\n",
+		t.OpenPRs, t.StalePRs, t.OpenIssues, t.StaleIssues, t.StaleBranches, t.VulnerableDeps))
+
+	b.WriteString("## Distribution\n\n")
+	b.WriteString("| Metric | Min | P50 | P90 | Max | Mean |\n")
+	b.WriteString("|--------|-----|-----|-----|-----|------|\n")
+	for _, s := range r.Stats {
+		b.WriteString(fmt.Sprintf("| %s | %.1f | %.1f | %.1f | %.1f | %.1f |\n", MetricTitle(s.Metric), s.Min, s.P50, s.P90, s.Max, s.Mean))
+	}
+
+	b.WriteString("\n## Grades\n\n")
+	for _, metric := range []string{MetricHealth, MetricCHI, MetricAutomation} {
+		b.WriteString(fmt.Sprintf("- **%s:** %s\n", MetricTitle(metric), gradeLine(r.GradeDistribution[metric])))
+	}
+
+	b.WriteString("\n## Needs Attention\n\n")
+	for _, w := range r.Worst {
+		if len(w.Worst) == 0 {
+			continue
+		}
+		entries := make([]string, 0, len(w.Worst))
+		for _, e := range w.Worst {
+			entries = append(entries, fmt.Sprintf("%s (%.1f)", e.Repository, e.Value))
+		}
+		b.WriteString(fmt.Sprintf("- **%s:** %s\n", MetricTitle(w.Metric), strings.Join(entries, ", ")))
+	}
+
+	if len(r.Groups) > 0 {
+		b.WriteString(fmt.Sprintf("\n## By %s\n\n", r.GroupBy))
+		b.WriteString("| Group | Repos | Avg Health | Avg CHI | Avg Automation | Open PRs | Stale Branches | Vulnerable Deps |\n")
+		b.WriteString("|-------|-------|------------|---------|----------------|----------|----------------|-----------------|\n")
+		for _, g := range r.Groups {
+			b.WriteString(fmt.Sprintf("| %s | %d | %.1f | %.1f | %.1f | %d | %d | %d |\n",
+				g.Name, len(g.Repositories), g.AvgHealth, g.AvgCHI, g.AvgAutomation, g.Totals.OpenPRs, g.Totals.StaleBranches, g.Totals.VulnerableDeps))
+		}
+	}
+
+	b.WriteString("\n## Repositories\n\n")
+	b.WriteString("| Repository | Health | CHI | Automation | Open PRs | Stale Branches | Vulnerable Deps | Bus Factor | Health Rank |\n")
+	b.WriteString("|------------|--------|-----|------------|----------|----------------|-----------------|------------|-------------|\n")
+	for _, s := range r.Repositories {
+		b.WriteString(fmt.Sprintf("| %s | %.1f %s | %.1f %s | %.1f %s | %d | %d | %d | %d | p%.0f |\n",
+			s.Repository, s.Health, s.HealthGrade, s.CHI, s.CHIGrade, s.Automation, s.AutomationGrade,
+			s.OpenPRs, s.StaleBranches, s.VulnerableDeps, s.BusFactor, s.Percentiles[MetricHealth]))
+	}
+	return b.String()
+}
+
+var htmlTemplate = template.Must(template.New("portfolio").Funcs(template.FuncMap{
+	"title":  MetricTitle,
+	"grades": gradeLine,
+	"f1":     func(v float64) string { return fmt.Sprintf("%.1f", v) },
+	"date":   func(r *Rollup) string { return r.GeneratedAt.Format("2006-01-02 15:04 MST") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Portfolio Scorecard</title>
+<style>
+body{font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif;margin:2rem;color:#24292f}
+table{border-collapse:collapse;margin:1rem 0}
+th,td{border:1px solid #d0d7de;padding:4px 10px;text-align:right}
+th:first-child,td:first-child{text-align:left}
+th{background:#f6f8fa}
+.totals td{font-size:1.4rem;font-weight:600;text-align:center}
+</style>
+</head>
+<body>
+<h1>Portfolio Scorecard</h1>
+<p>Generated {{date .}} over the last {{.PeriodDays}} days for {{.Totals.Repositories}} repositories.</p>
+<h2>Totals</h2>
+<table class="totals">
+<tr><th>Open PRs</th><th>Stale PRs</th><th>Open Issues</th><th>Stale Issues</th><th>Stale Branches</th><th>Vulnerable Deps</th></tr>
+<tr><td>{{.Totals.OpenPRs}}</td><td>{{.Totals.StalePRs}}</td><td>{{.Totals.OpenIssues}}</td><td>{{.Totals.StaleIssues}}</td><td>{{.Totals.StaleBranches}}</td><td>{{.Totals.VulnerableDeps}}</td></tr>
+</table>
+<h2>Distribution</h2>
+<table>
+<tr><th>Metric</th><th>Min</th><th>P50</th><th>P90</th><th>Max</th><th>Mean</th></tr>
+{{range .Stats}}<tr><td>{{title .Metric}}</td><td>{{f1 .Min}}</td><td>{{f1 .P50}}</td><td>{{f1 .P90}}</td><td>{{f1 .Max}}</td><td>{{f1 .Mean}}</td></tr>
+{{end}}</table>
+<h2>Grades</h2>
+<ul>
+{{range $metric, $dist := .GradeDistribution}}<li><b>{{title $metric}}:</b> {{grades $dist}}</li>
+{{end}}</ul>
+<h2>Needs Attention</h2>
+<ul>
+{{range .Worst}}{{if .Worst}}<li><b>{{title .Metric}}:</b> {{range $i, $e := .Worst}}{{if $i}}, {{end}}{{$e.Repository}} ({{f1 $e.Value}}){{end}}</li>
+{{end}}{{end}}</ul>
+{{if .Groups}}<h2>By {{.GroupBy}}</h2>
+<table>
+<tr><th>Group</th><th>Repos</th><th>Avg Health</th><th>Avg CHI</th><th>Avg Automation</th><th>Open PRs</th><th>Stale Branches</th><th>Vulnerable Deps</th></tr>
+{{range .Groups}}<tr><td>{{.Name}}</td><td>{{len .Repositories}}</td><td>{{f1 .AvgHealth}}</td><td>{{f1 .AvgCHI}}</td><td>{{f1 .AvgAutomation}}</td><td>{{.Totals.OpenPRs}}</td><td>{{.Totals.StaleBranches}}</td><td>{{.Totals.VulnerableDeps}}</td></tr>
+{{end}}</table>
+{{end}}<h2>Repositories</h2>
+<table>
+<tr><th>Repository</th><th>Health</th><th>CHI</th><th>Automation</th><th>Open PRs</th><th>Stale Branches</th><th>Vulnerable Deps</th><th>Bus Factor</th></tr>
+{{range .Repositories}}<tr><td>{{.Repository}}</td><td>{{f1 .Health}} {{.HealthGrade}}</td><td>{{f1 .CHI}} {{.CHIGrade}}</td><td>{{f1 .Automation}} {{.AutomationGrade}}</td><td>{{.OpenPRs}}</td><td>{{.StaleBranches}}</td><td>{{.VulnerableDeps}}</td><td>{{.BusFactor}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// ToHTML renders the rollup as a standalone HTML page
+func ToHTML(r *Rollup) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("failed to render portfolio HTML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func gradeLine(dist map[string]int) string {
+	if len(dist) == 0 {
+		return "n/a"
+	}
+	grades := make([]string, 0, len(dist))
+	for g := range dist {
+		grades = append(grades, g)
+	}
+	sort.Slice(grades, func(i, j int) bool { return gradeOrder(grades[i]) < gradeOrder(grades[j]) })
+	parts := make([]string, 0, len(grades))
+	for _, g := range grades {
+		parts = append(parts, fmt.Sprintf("%s: %d", g, dist[g]))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// gradeOrder sorts letter grades best first: A+ A A- B+ ...
+func gradeOrder(grade string) string {
+	if grade == "" {
+		return "~"
+	}
+	suffix := "1"
+	switch {
+	case strings.HasSuffix(grade, "+"):
+		suffix = "0"
+	case strings.HasSuffix(grade, "-"):
+		suffix = "2"
+	}
+	return grade[:1] + suffix
+}
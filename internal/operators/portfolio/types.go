@@ -0,0 +1,144 @@
+package portfolio
+
+import (
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/analytics"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/automation"
+)
+
+// Grouping modes
+const (
+	GroupByNone  = ""
+	GroupByTopic = "topic"
+	GroupByTeam  = "team"
+)
+
+// Metric names used in statistics and rankings
+const (
+	MetricHealth         = "health"
+	MetricCHI            = "chi"
+	MetricAutomation     = "automation"
+	MetricOpenPRs        = "open_prs"
+	MetricStalePRs       = "stale_prs"
+	MetricStaleBranches  = "stale_branches"
+	MetricVulnerableDeps = "vulnerable_deps"
+	MetricBusFactor      = "bus_factor"
+	MetricFirstReview    = "first_review_p50"
+)
+
+// Options controls the collection and the rollup
+type Options struct {
+	Days int
+	// GroupBy groups repositories by GitHub topic or by team with access; empty disables grouping
+	GroupBy string
+	// WorstN is the length of each worst-N list
+	WorstN int
+	// SkipAutomation skips the automation analysis (labels, issues, PRs, workflows)
+	SkipAutomation bool
+	// SkipBranches skips the stale branch scan (one call per branch)
+	SkipBranches bool
+	// Config supplies each repository's licenses rule; nil uses the environment defaults
+	Config interfaces.IMainConfig
+}
+
+// Repository identifies a repository of the portfolio
+type Repository struct {
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+}
+
+// Result is everything collected for one repository
+type Result struct {
+	Repository    Repository                   `json:"repository"`
+	Groups        []string                     `json:"groups"`
+	Insights      *analytics.InsightsReport    `json:"insights,omitempty"`
+	Automation    *automation.AutomationReport `json:"automation,omitempty"`
+	Scorecard     *metrics.EnhancedScorecard   `json:"scorecard,omitempty"`
+	StaleBranches int                          `json:"stale_branches"`
+	Errors        []string                     `json:"errors,omitempty"`
+
+	// WorkflowFailureRate is the percentage of failed workflow runs in the period, nil when not measured
+	WorkflowFailureRate *float64 `json:"workflow_failure_rate,omitempty"`
+}
+
+// RepoSummary holds the metrics of one repository used by the rollup
+type RepoSummary struct {
+	Repository      string             `json:"repository"`
+	Groups          []string           `json:"groups"`
+	Health          float64            `json:"health"`
+	HealthGrade     string             `json:"health_grade"`
+	CHI             float64            `json:"chi"`
+	CHIGrade        string             `json:"chi_grade"`
+	Automation      float64            `json:"automation"`
+	AutomationGrade string             `json:"automation_grade"`
+	OpenPRs         int                `json:"open_prs"`
+	StalePRs        int                `json:"stale_prs"`
+	OpenIssues      int                `json:"open_issues"`
+	StaleIssues     int                `json:"stale_issues"`
+	StaleBranches   int                `json:"stale_branches"`
+	VulnerableDeps  int                `json:"vulnerable_deps"`
+	BusFactor       int                `json:"bus_factor"`
+	FirstReviewP50  float64            `json:"first_review_p50"`
+	Percentiles     map[string]float64 `json:"percentiles"` // Percentile rank per metric, 100 is best
+	Errors          []string           `json:"errors,omitempty"`
+}
+
+// Totals sums counters across repositories
+type Totals struct {
+	Repositories   int `json:"repositories"`
+	OpenPRs        int `json:"open_prs"`
+	StalePRs       int `json:"stale_prs"`
+	OpenIssues     int `json:"open_issues"`
+	StaleIssues    int `json:"stale_issues"`
+	StaleBranches  int `json:"stale_branches"`
+	VulnerableDeps int `json:"vulnerable_deps"`
+}
+
+// MetricStats is the distribution of one metric across the portfolio
+type MetricStats struct {
+	Metric string  `json:"metric"`
+	Min    float64 `json:"min"`
+	P50    float64 `json:"p50"`
+	P90    float64 `json:"p90"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+}
+
+// RankEntry is one repository of a worst-N list
+type RankEntry struct {
+	Repository string  `json:"repository"`
+	Value      float64 `json:"value"`
+}
+
+// Ranking lists the worst repositories for a metric
+type Ranking struct {
+	Metric         string      `json:"metric"`
+	HigherIsBetter bool        `json:"higher_is_better"`
+	Worst          []RankEntry `json:"worst"`
+}
+
+// Group aggregates the repositories sharing a topic or team
+type Group struct {
+	Name          string   `json:"name"`
+	Repositories  []string `json:"repositories"`
+	AvgHealth     float64  `json:"avg_health"`
+	AvgCHI        float64  `json:"avg_chi"`
+	AvgAutomation float64  `json:"avg_automation"`
+	Totals        Totals   `json:"totals"`
+}
+
+// Rollup is the org-level scorecard of a portfolio
+type Rollup struct {
+	GeneratedAt       time.Time                 `json:"generated_at"`
+	PeriodDays        int                       `json:"period_days"`
+	GroupBy           string                    `json:"group_by,omitempty"`
+	Totals            Totals                    `json:"totals"`
+	Stats             []MetricStats             `json:"stats"`
+	GradeDistribution map[string]map[string]int `json:"grade_distribution"` // health/chi/automation → grade → repositories
+	Worst             []Ranking                 `json:"worst"`
+	Groups            []Group                   `json:"groups,omitempty"`
+	Repositories      []RepoSummary             `json:"repositories"`
+}
@@ -9,6 +9,17 @@ import (
 	"github.com/google/go-github/v61/github"
 )
 
+// AnalyzeBranches lists the branches of a repository and classifies them as stale or active
+func AnalyzeBranches(ctx context.Context, client *github.Client, owner, repo string) (*BranchAnalysis, error) {
+	branches, _, err := client.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	return analyzeBranches(ctx, client, owner, repo, branches), nil
+}
+
 // AnalyzeProductivity performs comprehensive productivity analysis
 func AnalyzeProductivity(ctx context.Context, client *github.Client, owner, repo string) (*ProductivityReport, error) {
 	report := &ProductivityReport{
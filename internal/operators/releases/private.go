@@ -2,11 +2,116 @@ package releases
 
 import (
 	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/identity"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/conventional"
 )
 
 func deleteRelease(ctx context.Context, cli *github.Client, owner, repo string, id int64) error {
 	_, err := cli.Repositories.DeleteRelease(ctx, owner, repo, id)
 	return err
 }
+
+func listVersionTags(ctx context.Context, cli *github.Client, owner, repo, prefix string) (map[string]Version, error) {
+	tags := make(map[string]Version)
+	opt := &github.ListOptions{PerPage: 100}
+	read := 0
+	for {
+		page, resp, err := cli.Repositories.ListTags(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		for _, t := range page {
+			if v, ok := ParseVersion(t.GetName(), prefix); ok {
+				tags[t.GetName()] = v
+			}
+		}
+		read += len(page)
+		if resp.NextPage == 0 || read >= maxTags {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return tags, nil
+}
+
+func nextPrereleaseNumber(tags map[string]Version, next Version) int {
+	number := 0
+	for _, v := range tags {
+		if v.Major == next.Major && v.Minor == next.Minor && v.Patch == next.Patch && v.Channel == next.Channel && v.Number > number {
+			number = v.Number
+		}
+	}
+	return number + 1
+}
+
+// contributors credits the people behind the commits, bots excluded
+func contributors(ctx context.Context, cli *github.Client, owner, repo string, commits []conventional.Commit) []string {
+	people := identity.ForRepository(ctx, cli, owner, repo)
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, c := range commits {
+		id := people.Resolve(c.AuthorLogin, c.AuthorName, c.AuthorEmail)
+		if id.Bot || seen[id.Key] {
+			continue
+		}
+		seen[id.Key] = true
+		if len(id.Logins) > 0 {
+			names = append(names, "@"+id.Logins[0])
+		} else {
+			names = append(names, id.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func releaseNotes(cl *conventional.Changelog, credits []string) string {
+	notes := conventional.ToMarkdown(cl)
+	if len(credits) == 0 {
+		return notes
+	}
+	return notes + "### Contributors\n\n" + strings.Join(credits, ", ") + "\n"
+}
+
+func listAssets(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read assets dir: %w", err)
+	}
+	var assets []string
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			assets = append(assets, filepath.Join(dir, e.Name()))
+		}
+	}
+	return assets, nil
+}
+
+func uploadAsset(ctx context.Context, cli *github.Client, owner, repo string, id int64, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open asset %s: %w", path, err)
+	}
+	defer f.Close()
+
+	mediaType := mime.TypeByExtension(filepath.Ext(path))
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+	opts := &github.UploadOptions{Name: filepath.Base(path), MediaType: mediaType}
+	if _, _, err := cli.Repositories.UploadReleaseAsset(ctx, owner, repo, id, opts, f); err != nil {
+		return fmt.Errorf("failed to upload asset %s: %w", opts.Name, err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package releases
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/go-github/v61/github"
 	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
@@ -45,3 +46,25 @@ func CleanReleases(ctx context.Context, cli *github.Client, owner, repo string,
 
 	return
 }
+
+// CreateDraft creates a draft release for tag with body as its notes. In dry-run
+// mode nothing is created and a nil release is returned.
+func CreateDraft(ctx context.Context, cli *github.Client, owner, repo, tag, target, name, body string, dry bool) (*github.RepositoryRelease, error) {
+	if dry {
+		return nil, nil
+	}
+	rel := &github.RepositoryRelease{
+		TagName: github.String(tag),
+		Name:    github.String(name),
+		Body:    github.String(body),
+		Draft:   github.Bool(true),
+	}
+	if target != "" {
+		rel.TargetCommitish = github.String(target)
+	}
+	created, _, err := cli.Repositories.CreateRelease(ctx, owner, repo, rel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create draft release %s: %w", tag, err)
+	}
+	return created, nil
+}
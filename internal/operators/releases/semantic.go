@@ -0,0 +1,204 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/module/version"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/conventional"
+)
+
+// maxTags bounds the tags read when looking for the latest release
+const maxTags = 500
+
+// DefaultPlanOptions returns the options used by the release command
+func DefaultPlanOptions() PlanOptions {
+	return PlanOptions{Prefix: "v", Initial: "0.1.0"}
+}
+
+// ParseVersion parses "v1.2.3" or "v1.2.3-rc.4" with the given tag prefix
+func ParseVersion(tag, prefix string) (Version, bool) {
+	if !strings.HasPrefix(tag, prefix) {
+		return Version{}, false
+	}
+	raw := strings.TrimPrefix(tag, prefix)
+	parts := version.ParseVersion(raw)
+	if len(parts) != 3 {
+		return Version{}, false
+	}
+	v := Version{Major: parts[0], Minor: parts[1], Patch: parts[2]}
+	if _, pre, ok := strings.Cut(raw, "-"); ok {
+		channel, number, _ := strings.Cut(pre, ".")
+		n, err := strconv.Atoi(number)
+		if channel == "" || err != nil {
+			return Version{}, false
+		}
+		v.Channel, v.Number = channel, n
+	}
+	return v, true
+}
+
+// String formats the version without prefix
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Channel != "" {
+		s += fmt.Sprintf("-%s.%d", v.Channel, v.Number)
+	}
+	return s
+}
+
+// Compare orders versions by semver precedence: a prerelease sorts before its release
+func (v Version) Compare(o Version) int {
+	if c := version.CompareVersions([]int{v.Major, v.Minor, v.Patch}, []int{o.Major, o.Minor, o.Patch}); c != 0 {
+		return c
+	}
+	switch {
+	case v.Channel == o.Channel:
+		return version.CompareVersions([]int{v.Number}, []int{o.Number})
+	case v.Channel == "":
+		return 1
+	case o.Channel == "":
+		return -1
+	case v.Channel < o.Channel:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Next returns the version after v for the bump. Before 1.0.0 breaking changes bump the minor.
+func (v Version) Next(b Bump) Version {
+	n := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if b == BumpMajor && v.Major == 0 {
+		b = BumpMinor
+	}
+	switch b {
+	case BumpMajor:
+		n.Major, n.Minor, n.Patch = n.Major+1, 0, 0
+	case BumpMinor:
+		n.Minor, n.Patch = n.Minor+1, 0
+	case BumpPatch:
+		n.Patch++
+	}
+	return n
+}
+
+// BumpFor returns the increment required by commits: breaking → major, feat → minor,
+// fix, perf and revert → patch
+func BumpFor(commits []conventional.Commit) Bump {
+	bump := BumpNone
+	for _, c := range commits {
+		if !c.Valid {
+			continue
+		}
+		switch {
+		case c.Breaking:
+			return BumpMajor
+		case c.Type == "feat":
+			bump = BumpMinor
+		case bump == BumpNone && (c.Type == "fix" || c.Type == "perf" || c.Type == "revert"):
+			bump = BumpPatch
+		}
+	}
+	return bump
+}
+
+// PlanRelease computes the next version from the conventional commits since the latest
+// stable tag and renders its notes. Nothing is created; see Publish.
+func PlanRelease(ctx context.Context, cli *github.Client, owner, repo string, opts PlanOptions) (*Plan, error) {
+	tags, err := listVersionTags(ctx, cli, owner, repo, opts.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Owner: owner, Repo: repo, Prerelease: opts.Channel != "", Contributors: []string{}}
+	var previous Version
+	hasPrevious := false
+	for tag, v := range tags {
+		if v.Channel == "" && (!hasPrevious || v.Compare(previous) > 0) {
+			previous, plan.PreviousTag, hasPrevious = v, tag, true
+		}
+	}
+
+	commits, head, err := conventional.ListRange(ctx, cli, owner, repo, plan.PreviousTag, opts.Target)
+	if err != nil {
+		return nil, err
+	}
+	plan.Target = head
+	plan.Commits = len(commits)
+	plan.Bump = BumpFor(commits)
+	if plan.Bump == BumpNone && opts.Force {
+		plan.Bump = BumpPatch
+	}
+
+	switch {
+	case !hasPrevious:
+		initial, ok := ParseVersion(opts.Prefix+opts.Initial, opts.Prefix)
+		if !ok {
+			return nil, fmt.Errorf("invalid initial version %q", opts.Initial)
+		}
+		plan.Version = initial
+	case plan.Bump == BumpNone:
+		return plan, nil
+	default:
+		plan.Version = previous.Next(plan.Bump)
+	}
+	if opts.Channel != "" {
+		plan.Version.Channel = opts.Channel
+		plan.Version.Number = nextPrereleaseNumber(tags, plan.Version)
+	}
+	plan.Tag = opts.Prefix + plan.Version.String()
+
+	if plan.TargetSHA, _, err = cli.Repositories.GetCommitSHA1(ctx, owner, repo, head, ""); err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", head, err)
+	}
+	if plan.Assets, err = listAssets(opts.AssetsDir); err != nil {
+		return nil, err
+	}
+
+	changelog := conventional.Build(owner, repo, plan.PreviousTag, plan.Tag, commits, conventional.Options{}, time.Now())
+	plan.Contributors = contributors(ctx, cli, owner, repo, commits)
+	plan.Notes = releaseNotes(changelog, plan.Contributors)
+	return plan, nil
+}
+
+// Publish creates the tag and a draft release for the plan and uploads its assets.
+// In dry-run mode nothing is created and a nil release is returned.
+func Publish(ctx context.Context, cli *github.Client, plan *Plan, dry bool) (*github.RepositoryRelease, error) {
+	if plan.Tag == "" {
+		return nil, fmt.Errorf("nothing to release in %s/%s", plan.Owner, plan.Repo)
+	}
+	if dry {
+		return nil, nil
+	}
+
+	ref := &github.Reference{
+		Ref:    github.String("refs/tags/" + plan.Tag),
+		Object: &github.GitObject{SHA: github.String(plan.TargetSHA)},
+	}
+	if _, _, err := cli.Git.CreateRef(ctx, plan.Owner, plan.Repo, ref); err != nil {
+		return nil, fmt.Errorf("failed to create tag %s: %w", plan.Tag, err)
+	}
+
+	rel, _, err := cli.Repositories.CreateRelease(ctx, plan.Owner, plan.Repo, &github.RepositoryRelease{
+		TagName:    github.String(plan.Tag),
+		Name:       github.String(plan.Tag),
+		Body:       github.String(plan.Notes),
+		Draft:      github.Bool(true),
+		Prerelease: github.Bool(plan.Prerelease),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create draft release %s: %w", plan.Tag, err)
+	}
+
+	for _, path := range plan.Assets {
+		if err := uploadAsset(ctx, cli, plan.Owner, plan.Repo, rel.GetID(), path); err != nil {
+			return rel, err
+		}
+	}
+	return rel, nil
+}
@@ -0,0 +1,53 @@
+package releases
+
+// Bump is the semver increment implied by a set of commits
+type Bump string
+
+const (
+	BumpNone  Bump = "none"
+	BumpPatch Bump = "patch"
+	BumpMinor Bump = "minor"
+	BumpMajor Bump = "major"
+)
+
+// Version is a parsed semantic version with an optional prerelease channel (-rc.N)
+type Version struct {
+	Major   int    `json:"major"`
+	Minor   int    `json:"minor"`
+	Patch   int    `json:"patch"`
+	Channel string `json:"channel,omitempty"`
+	Number  int    `json:"number,omitempty"`
+}
+
+// PlanOptions controls how the next release is computed
+type PlanOptions struct {
+	// Prefix of release tags, "v" by default
+	Prefix string
+	// Channel makes the release a prerelease such as "rc" (-rc.N)
+	Channel string
+	// Target is the branch or commit released, the default branch when empty
+	Target string
+	// Initial is the version of the first release when the repository has no tags
+	Initial string
+	// Force releases a patch even when no commit requires one
+	Force bool
+	// AssetsDir holds build artifacts uploaded to the release
+	AssetsDir string
+}
+
+// Plan is the release about to be created
+type Plan struct {
+	Owner        string   `json:"owner"`
+	Repo         string   `json:"repo"`
+	PreviousTag  string   `json:"previous_tag,omitempty"`
+	Bump         Bump     `json:"bump"`
+	Version      Version  `json:"version"`
+	Tag          string   `json:"tag"`
+	Target       string   `json:"target"`
+	TargetSHA    string   `json:"target_sha"`
+	Prerelease   bool     `json:"prerelease"`
+	Commits      int      `json:"commits"`
+	Contributors []string `json:"contributors"`
+	Assets       []string `json:"assets,omitempty"`
+	Notes        string   `json:"notes"`
+}
@@ -1,5 +1,12 @@
 package sanitize
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
+)
+
 // calculateReleaseHealth computes realistic release health score based on actual actions
 func calculateReleaseHealth(action *SanitizationAction) float64 {
 	if action == nil {
@@ -30,3 +37,67 @@ func calculateHealthScore(runs, artifacts, security int) float64 {
 
 	return min(baseScore+runImpact+artifactImpact+securityImpact, 98.0)
 }
+
+// riskSection renders the knowledge-risk section; empty when ownership was not analyzed
+func riskSection(risk gitz.Risk) string {
+	if !risk.Analyzed {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n## ⚠️ Risk\n")
+	sb.WriteString(fmt.Sprintf("- **Bus Factor:** %d (%s)\n", risk.BusFactor, strings.Join(risk.Owners, ", ")))
+	sb.WriteString(fmt.Sprintf("- **Commits Analyzed:** %d\n", risk.CommitsAnalyzed))
+	sb.WriteString(fmt.Sprintf("- **Knowledge Silos:** %d\n", len(risk.Silos)))
+	sb.WriteString(fmt.Sprintf("- **Departed Owners:** %d", len(risk.DepartedOwners)))
+	if len(risk.DepartedOwners) > 0 {
+		sb.WriteString(" (" + strings.Join(risk.DepartedOwners, ", ") + ")")
+	}
+	sb.WriteString("\n")
+
+	if len(risk.Paths) > 0 {
+		sb.WriteString("\n| Path | Risk | Bus Factor | Owners | Changes | Notes |\n")
+		sb.WriteString("|------|------|------------|--------|---------|-------|\n")
+		for _, p := range risk.Paths {
+			var notes []string
+			if p.Silo {
+				notes = append(notes, "single author")
+			}
+			if len(p.DepartedOwners) > 0 {
+				notes = append(notes, "departed: "+strings.Join(p.DepartedOwners, ", "))
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | %s | %d | %s | %d | %s |\n",
+				p.Path, p.Risk, p.BusFactor, strings.Join(p.Owners, ", "), p.Changes, strings.Join(notes, "; ")))
+		}
+	}
+	return sb.String()
+}
+
+// releaseAuditSection renders the tag and release integrity audit; empty when not audited
+func releaseAuditSection(audit gitz.ReleaseAudit) string {
+	if !audit.Audited {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n### 🏷️ Tag & Release Integrity\n")
+	sb.WriteString(fmt.Sprintf("- **Tags Checked:** %d (%d non-semver, %d lightweight, %d unsigned, %d unsigned commits)\n",
+		audit.TagsChecked, audit.NonSemverTags, audit.LightweightTags, audit.UnsignedTags, audit.UnsignedCommits))
+	sb.WriteString(fmt.Sprintf("- **Tags Unreachable from Default Branch:** %d\n", audit.UnreachableTags))
+	sb.WriteString(fmt.Sprintf("- **Releases Checked:** %d (%d without assets, %d without checksums)\n",
+		audit.ReleasesChecked, audit.ReleasesWithoutAssets, audit.ReleasesWithoutChecksums))
+	sb.WriteString(fmt.Sprintf("- **Checksum Mismatches:** %d\n", audit.ChecksumMismatches))
+
+	if len(audit.Findings) > 0 {
+		sb.WriteString("\n| Severity | Kind | Tag | Asset | Details |\n")
+		sb.WriteString("|----------|------|-----|-------|---------|\n")
+		for _, f := range audit.Findings {
+			kind := f.Kind
+			if f.Status != "" {
+				kind += " (" + f.Status + ")"
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | `%s` | %s | %s |\n", f.Severity, kind, f.Tag, f.Asset, f.Message))
+		}
+	}
+	return sb.String()
+}
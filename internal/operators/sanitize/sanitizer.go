@@ -323,7 +323,7 @@ func ToMarkdown(r *gitz.Report) string {
 - **Draft Releases Cleaned:** %d old drafts removed
 - **Active Tags:** %v
 - **Impact:** Simplified release timeline and improved organization
-
+%s
 ## 🔐 Security Enhancements
 - **SSH Keys Rotated:** %d keys updated
 - **Old Keys Removed:** %d deprecated keys
@@ -336,7 +336,7 @@ func ToMarkdown(r *gitz.Report) string {
 - **Open Pull Requests:** %d
 - **Open Issues:** %d
 - **Recent Commits (30 days):** %d
-
+%s
 ## 💡 Intelligent Recommendations
 %s
 
@@ -355,6 +355,7 @@ func ToMarkdown(r *gitz.Report) string {
 		r.Runs.Deleted, r.Runs.Kept,
 		r.Artifacts.Deleted, float64(r.Artifacts.Deleted)*50,
 		r.Releases.DeletedDrafts, r.Releases.Tags,
+		releaseAuditSection(r.Releases.Audit),
 		r.Security.SSHKeysRotated, r.Security.OldKeysRemoved, r.Security.NewKeyID,
 		func() string {
 			if r.Monitoring.IsInactive {
@@ -364,6 +365,7 @@ func ToMarkdown(r *gitz.Report) string {
 			}
 		}(),
 		r.Monitoring.DaysInactive, r.Monitoring.OpenPRs, r.Monitoring.OpenIssues, r.Monitoring.CommitsLast30,
+		riskSection(r.Risk),
 		func() string {
 			if len(r.Notes) > 0 {
 				return "• " + strings.Join(r.Notes, "\n• ")
@@ -0,0 +1,313 @@
+// Package sbom renders software bills of materials (SPDX and CycloneDX JSON)
+// from the parsed dependency manifests and publishes them as release assets.
+package sbom
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/operators/deps"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// Generate renders the dependencies in the requested format
+func Generate(format string, meta Meta, dependencies []deps.Dependency) ([]byte, error) {
+	if meta.Created.IsZero() {
+		meta.Created = time.Now().UTC()
+	}
+	switch format {
+	case FormatSPDXJSON:
+		return json.MarshalIndent(buildSPDX(meta, dependencies), "", "  ")
+	case FormatCycloneDXJSON:
+		return json.MarshalIndent(buildCycloneDX(meta, dependencies), "", "  ")
+	}
+	return nil, fmt.Errorf("unsupported SBOM format %q (use %s or %s)", format, FormatSPDXJSON, FormatCycloneDXJSON)
+}
+
+// FileName returns the conventional asset name for an SBOM
+func FileName(repo, format string) string {
+	if format == FormatCycloneDXJSON {
+		return repo + ".cdx.json"
+	}
+	return repo + ".spdx.json"
+}
+
+// LatestReleaseTag returns the tag of the latest published release
+func LatestReleaseTag(ctx context.Context, cli *github.Client, owner, repo string) (string, error) {
+	release, _, err := cli.Repositories.GetLatestRelease(ctx, owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get latest release: %w", err)
+	}
+	return release.GetTagName(), nil
+}
+
+// AttachToRelease uploads the SBOM as an asset of the release with the given tag,
+// or of the latest release when tag is empty. An existing asset with the same name is
+// replaced: the new one is uploaded under a temporary name and only takes the name once
+// the old one is gone, so a failed upload leaves the release as it was. In dry-run mode
+// nothing is changed and the target release is only reported.
+func AttachToRelease(ctx context.Context, cli *github.Client, owner, repo, tag, name string, data []byte, dry bool) (*github.RepositoryRelease, error) {
+	var release *github.RepositoryRelease
+	var err error
+	if tag == "" {
+		release, _, err = cli.Repositories.GetLatestRelease(ctx, owner, repo)
+	} else {
+		release, _, err = cli.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+	if dry {
+		return release, nil
+	}
+
+	tmp, err := os.CreateTemp("", "ghbex-sbom-*"+filepath.Ext(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	if _, err := tmp.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		return nil, fmt.Errorf("failed to rewind temp file: %w", err)
+	}
+
+	var existing []*github.ReleaseAsset
+	for _, asset := range release.Assets {
+		if asset.GetName() == name {
+			existing = append(existing, asset)
+		}
+	}
+	uploadName := name
+	if len(existing) > 0 {
+		uploadName = fmt.Sprintf("%s.%d.tmp", name, time.Now().Unix())
+	}
+
+	opts := &github.UploadOptions{Name: uploadName, MediaType: "application/json"}
+	uploaded, _, err := cli.Repositories.UploadReleaseAsset(ctx, owner, repo, release.GetID(), opts, tmp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload asset %s: %w", name, err)
+	}
+	if len(existing) == 0 {
+		return release, nil
+	}
+
+	for _, asset := range existing {
+		if _, err := cli.Repositories.DeleteReleaseAsset(ctx, owner, repo, asset.GetID()); err != nil {
+			if _, cleanupErr := cli.Repositories.DeleteReleaseAsset(ctx, owner, repo, uploaded.GetID()); cleanupErr != nil {
+				gl.Log("warn", fmt.Sprintf("Failed to remove temporary asset %s: %v", uploadName, cleanupErr))
+			}
+			return nil, fmt.Errorf("failed to replace existing asset %s: %w", name, err)
+		}
+	}
+	if _, _, err := cli.Repositories.EditReleaseAsset(ctx, owner, repo, uploaded.GetID(), &github.ReleaseAsset{Name: github.String(name)}); err != nil {
+		return nil, fmt.Errorf("failed to rename asset %s to %s: %w", uploadName, name, err)
+	}
+	return release, nil
+}
+
+func buildSPDX(meta Meta, dependencies []deps.Dependency) *spdxDocument {
+	rootID := "SPDXRef-Package-root"
+	doc := &spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              fmt.Sprintf("%s/%s", meta.Owner, meta.Repo),
+		DocumentNamespace: fmt.Sprintf("https://github.com/%s/%s/sbom/%s-%s", meta.Owner, meta.Repo, refOrHead(meta.Ref), newUUID()),
+		CreationInfo: spdxCreationInfo{
+			Created:  meta.Created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: ghbex-" + meta.ToolVersion},
+		},
+		Packages: []spdxPackage{{
+			Name:             meta.Repo,
+			SPDXID:           rootID,
+			VersionInfo:      meta.Ref,
+			DownloadLocation: fmt.Sprintf("git+https://github.com/%s/%s", meta.Owner, meta.Repo),
+			LicenseConcluded: "NOASSERTION",
+			LicenseDeclared:  "NOASSERTION",
+			CopyrightText:    "NOASSERTION",
+		}},
+		Relationships: []spdxRelationship{{
+			SPDXElementID:      "SPDXRef-DOCUMENT",
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: rootID,
+		}},
+	}
+
+	for i, d := range dependencies {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		pkg := spdxPackage{
+			Name:             d.Name,
+			SPDXID:           id,
+			VersionInfo:      d.Version,
+			DownloadLocation: "NOASSERTION",
+			LicenseConcluded: "NOASSERTION",
+			LicenseDeclared:  orNoAssertion(d.License),
+			CopyrightText:    "NOASSERTION",
+		}
+		if purl := PackageURL(d); purl != "" {
+			pkg.ExternalRefs = []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  purl,
+			}}
+		}
+		for _, alg := range sortedKeys(d.Hashes) {
+			pkg.Checksums = append(pkg.Checksums, spdxChecksum{
+				Algorithm:     strings.ReplaceAll(alg, "-", ""),
+				ChecksumValue: d.Hashes[alg],
+			})
+		}
+		if !d.Direct {
+			pkg.Comment = "transitive dependency"
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      rootID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+	return doc
+}
+
+func buildCycloneDX(meta Meta, dependencies []deps.Dependency) *cdxDocument {
+	rootRef := fmt.Sprintf("pkg:github/%s/%s", strings.ToLower(meta.Owner), strings.ToLower(meta.Repo))
+	if meta.Ref != "" {
+		rootRef += "@" + meta.Ref
+	}
+	doc := &cdxDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + newUUID(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: meta.Created.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []cdxComponent{{
+				Type:    "application",
+				Name:    "ghbex",
+				Version: meta.ToolVersion,
+			}}},
+			Component: cdxComponent{
+				Type:    "application",
+				BOMRef:  rootRef,
+				Name:    meta.Repo,
+				Version: meta.Ref,
+				PURL:    rootRef,
+			},
+		},
+		Components: []cdxComponent{},
+	}
+
+	root := cdxDependency{Ref: rootRef, DependsOn: []string{}}
+	seen := make(map[string]bool)
+	for _, d := range dependencies {
+		ref := PackageURL(d)
+		if ref == "" {
+			ref = d.Ecosystem + "/" + d.Name + "@" + d.Version
+		}
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+
+		c := cdxComponent{
+			Type:       "library",
+			BOMRef:     ref,
+			Name:       d.Name,
+			Version:    d.Version,
+			PURL:       PackageURL(d),
+			Properties: []cdxProperty{{Name: "ghbex:direct", Value: fmt.Sprintf("%t", d.Direct)}, {Name: "ghbex:manifest", Value: d.Manifest}},
+		}
+		if d.License != "" {
+			if strings.ContainsAny(d.License, " ()") {
+				c.Licenses = []cdxLicense{{Expression: d.License}}
+			} else {
+				c.Licenses = []cdxLicense{{License: &cdxLicenseID{ID: d.License}}}
+			}
+		}
+		for _, alg := range sortedKeys(d.Hashes) {
+			c.Hashes = append(c.Hashes, cdxHash{Alg: alg, Content: d.Hashes[alg]})
+		}
+		doc.Components = append(doc.Components, c)
+		if d.Direct {
+			root.DependsOn = append(root.DependsOn, ref)
+		}
+	}
+	doc.Dependencies = []cdxDependency{root}
+	return doc
+}
+
+// PackageURL returns the purl (https://github.com/package-url/purl-spec) of a dependency
+func PackageURL(d deps.Dependency) string {
+	version := ""
+	if d.Version != "" {
+		version = "@" + purlEscape(d.Version)
+	}
+	switch d.Ecosystem {
+	case deps.EcosystemGo:
+		return "pkg:golang/" + d.Name + version
+	case deps.EcosystemNPM:
+		if scope, name, ok := strings.Cut(d.Name, "/"); ok && strings.HasPrefix(scope, "@") {
+			return "pkg:npm/%40" + scope[1:] + "/" + name + version
+		}
+		return "pkg:npm/" + d.Name + version
+	case deps.EcosystemPyPI:
+		return "pkg:pypi/" + strings.ToLower(d.Name) + version
+	case deps.EcosystemCrates:
+		return "pkg:cargo/" + d.Name + version
+	case deps.EcosystemMaven:
+		if group, artifact, ok := strings.Cut(d.Name, ":"); ok {
+			return "pkg:maven/" + group + "/" + artifact + version
+		}
+	}
+	return ""
+}
+
+// purlEscape percent-encodes the characters purl reserves in versions
+func purlEscape(s string) string {
+	return strings.NewReplacer("%", "%25", "@", "%40", "?", "%3F", "#", "%23", "+", "%2B").Replace(s)
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func refOrHead(ref string) string {
+	if ref == "" {
+		return "HEAD"
+	}
+	return ref
+}
+
+func orNoAssertion(s string) string {
+	if s == "" {
+		return "NOASSERTION"
+	}
+	return s
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
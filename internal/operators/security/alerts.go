@@ -0,0 +1,199 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// Alert sources
+const (
+	SourceDependabot     = "dependabot"
+	SourceCodeScanning   = "code_scanning"
+	SourceSecretScanning = "secret_scanning"
+)
+
+// CollectSecurityAlerts pulls open and closed Dependabot, code-scanning and secret-scanning
+// alerts for a repository and aggregates them by severity and age.
+// Sources that are disabled or not accessible with the current token are reported in Notes
+// and marked as unavailable instead of failing the whole collection.
+func CollectSecurityAlerts(ctx context.Context, cli *github.Client, owner, repo string) (*AlertsReport, error) {
+	if cli == nil {
+		return nil, fmt.Errorf("GitHub client is nil")
+	}
+
+	report := &AlertsReport{
+		Owner:       owner,
+		Repo:        repo,
+		GeneratedAt: time.Now(),
+		BySeverity:  make(map[string]int),
+		OpenAlerts:  []SecurityAlert{},
+	}
+
+	var all []SecurityAlert
+
+	dependabot, fixes, err := listDependabotAlerts(ctx, cli, owner, repo)
+	report.Dependabot, err = summarizeSource(dependabot, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependabot alerts: %w", err)
+	}
+	if !report.Dependabot.Available {
+		report.Notes = append(report.Notes, "dependabot: alerts not available for this repository or token")
+	}
+	report.CriticalFixes = fixes
+	all = append(all, dependabot...)
+
+	codeScanning, err := listCodeScanningAlerts(ctx, cli, owner, repo)
+	report.CodeScanning, err = summarizeSource(codeScanning, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list code scanning alerts: %w", err)
+	}
+	if !report.CodeScanning.Available {
+		report.Notes = append(report.Notes, "code_scanning: alerts not available for this repository or token")
+	}
+	all = append(all, codeScanning...)
+
+	secrets, err := listSecretScanningAlerts(ctx, cli, owner, repo)
+	report.SecretScanning, err = summarizeSource(secrets, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret scanning alerts: %w", err)
+	}
+	if !report.SecretScanning.Available {
+		report.Notes = append(report.Notes, "secret_scanning: alerts not available for this repository or token")
+	}
+	all = append(all, secrets...)
+
+	var remediation []time.Duration
+	for _, a := range all {
+		if a.ClosedAt.IsZero() {
+			report.OpenTotal++
+			report.BySeverity[a.Severity]++
+			report.OpenAlerts = append(report.OpenAlerts, a)
+			continue
+		}
+		remediation = append(remediation, a.ClosedAt.Sub(a.CreatedAt))
+	}
+	report.MTTRHours = meanHours(remediation)
+
+	sortOldestFirst(report.OpenAlerts)
+
+	return report, nil
+}
+
+// OldestCriticalAlerts returns the oldest open critical alerts across all reports, oldest first.
+func OldestCriticalAlerts(reports []*AlertsReport, limit int) []SecurityAlert {
+	var critical []SecurityAlert
+	for _, r := range reports {
+		if r == nil {
+			continue
+		}
+		for _, a := range r.OpenAlerts {
+			if a.Severity == "critical" {
+				critical = append(critical, a)
+			}
+		}
+	}
+	sortOldestFirst(critical)
+	if limit > 0 && len(critical) > limit {
+		critical = critical[:limit]
+	}
+	return critical
+}
+
+// AlertsToMarkdown renders an org-wide alert summary with the oldest critical alerts table.
+func AlertsToMarkdown(reports []*AlertsReport, limit int) string {
+	var b strings.Builder
+
+	b.WriteString("# 🔐 Security Alerts Overview\n\n")
+	b.WriteString("| Repository | Open | Critical | High | Medium | Low | MTTR (h) |\n")
+	b.WriteString("|---|---:|---:|---:|---:|---:|---:|\n")
+	for _, r := range reports {
+		if r == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "| %s/%s | %d | %d | %d | %d | %d | %.1f |\n",
+			r.Owner, r.Repo, r.OpenTotal,
+			r.BySeverity["critical"], r.BySeverity["high"], r.BySeverity["medium"], r.BySeverity["low"],
+			r.MTTRHours,
+		)
+	}
+
+	b.WriteString("\n## 🚨 Oldest Critical Alerts\n\n")
+	oldest := OldestCriticalAlerts(reports, limit)
+	if len(oldest) == 0 {
+		b.WriteString("No open critical alerts. 🎉\n")
+	} else {
+		b.WriteString("| Age (days) | Repository | Source | Alert | Opened |\n")
+		b.WriteString("|---:|---|---|---|---|\n")
+		for _, a := range oldest {
+			fmt.Fprintf(&b, "| %d | %s/%s | %s | [#%d %s](%s) | %s |\n",
+				a.AgeDays, a.Owner, a.Repo, a.Source, a.Number,
+				strings.ReplaceAll(a.Title, "|", "\\|"), a.URL,
+				a.CreatedAt.Format("2006-01-02"),
+			)
+		}
+	}
+
+	var notes []string
+	for _, r := range reports {
+		if r == nil {
+			continue
+		}
+		for _, n := range r.Notes {
+			notes = append(notes, fmt.Sprintf("%s/%s - %s", r.Owner, r.Repo, n))
+		}
+	}
+	if len(notes) > 0 {
+		b.WriteString("\n## ℹ️ Notes\n\n• " + strings.Join(notes, "\n• ") + "\n")
+	}
+
+	return b.String()
+}
+
+// summarizeSource turns the alerts of one source into an AlertSummary.
+// Permission and "feature disabled" errors mark the source as unavailable.
+func summarizeSource(alerts []SecurityAlert, err error) (*AlertSummary, error) {
+	summary := &AlertSummary{
+		BySeverity: make(map[string]int),
+		ByAge:      make(map[string]int),
+	}
+	if err != nil {
+		if isUnavailable(err) {
+			return summary, nil
+		}
+		return summary, err
+	}
+	summary.Available = true
+
+	var remediation []time.Duration
+	for _, a := range alerts {
+		if a.ClosedAt.IsZero() {
+			summary.Open++
+			summary.BySeverity[a.Severity]++
+			summary.ByAge[ageBucket(a.AgeDays)]++
+			continue
+		}
+		summary.Closed++
+		remediation = append(remediation, a.ClosedAt.Sub(a.CreatedAt))
+	}
+	summary.MTTRHours = meanHours(remediation)
+
+	return summary, nil
+}
+
+// isUnavailable reports whether an API error means the alerts endpoint is disabled or forbidden
+func isUnavailable(err error) bool {
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		switch errResp.Response.StatusCode {
+		case http.StatusForbidden, http.StatusNotFound, http.StatusUnauthorized:
+			return true
+		}
+	}
+	return false
+}
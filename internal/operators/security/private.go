@@ -1,11 +1,17 @@
 package security
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
 )
 
 // generateSSHKeyPair creates a new RSA SSH key pair
@@ -57,3 +63,208 @@ func indexOfSubstring(s, substr string) int {
 	}
 	return -1
 }
+
+// listDependabotAlerts lists all Dependabot alerts of a repository (cursor paginated).
+// It also returns the "package@version" fixes available for open critical/high alerts.
+func listDependabotAlerts(ctx context.Context, cli *github.Client, owner, repo string) ([]SecurityAlert, []string, error) {
+	var alerts []SecurityAlert
+	var fixes []string
+	now := time.Now()
+
+	opts := &github.ListAlertsOptions{
+		ListCursorOptions: github.ListCursorOptions{PerPage: 100},
+	}
+	for {
+		page, resp, err := cli.Dependabot.ListRepoAlerts(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, a := range page {
+			alert := SecurityAlert{
+				Source:    SourceDependabot,
+				Owner:     owner,
+				Repo:      repo,
+				Number:    a.GetNumber(),
+				State:     a.GetState(),
+				URL:       a.GetHTMLURL(),
+				CreatedAt: a.GetCreatedAt().Time,
+				ClosedAt:  firstTime(a.FixedAt, a.DismissedAt, a.AutoDismissedAt),
+			}
+			if adv := a.GetSecurityAdvisory(); adv != nil {
+				alert.Severity = normalizeSeverity(adv.GetSeverity())
+				alert.Title = fmt.Sprintf("%s: %s", adv.GetGHSAID(), adv.GetSummary())
+			}
+			alert.AgeDays = ageDays(alert.CreatedAt, alert.ClosedAt, now)
+			alerts = append(alerts, alert)
+
+			if alert.ClosedAt.IsZero() && (alert.Severity == "critical" || alert.Severity == "high") {
+				if vuln := a.GetSecurityVulnerability(); vuln != nil && vuln.GetFirstPatchedVersion() != nil {
+					fixes = append(fixes, fmt.Sprintf("%s@%s",
+						vuln.GetPackage().GetName(), vuln.GetFirstPatchedVersion().GetIdentifier()))
+				}
+			}
+		}
+		if resp == nil || resp.After == "" {
+			break
+		}
+		opts.ListCursorOptions.After = resp.After
+	}
+
+	return alerts, fixes, nil
+}
+
+// listCodeScanningAlerts lists all code-scanning alerts of a repository
+func listCodeScanningAlerts(ctx context.Context, cli *github.Client, owner, repo string) ([]SecurityAlert, error) {
+	var alerts []SecurityAlert
+	now := time.Now()
+
+	opts := &github.AlertListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := cli.CodeScanning.ListAlertsForRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range page {
+			severity := ""
+			title := ""
+			if rule := a.GetRule(); rule != nil {
+				severity = rule.GetSecuritySeverityLevel()
+				if severity == "" {
+					severity = rule.GetSeverity()
+				}
+				title = rule.GetDescription()
+			}
+			if severity == "" {
+				severity = a.GetRuleSeverity()
+			}
+			alert := SecurityAlert{
+				Source:    SourceCodeScanning,
+				Owner:     owner,
+				Repo:      repo,
+				Number:    a.GetNumber(),
+				Severity:  normalizeSeverity(severity),
+				Title:     title,
+				URL:       a.GetHTMLURL(),
+				State:     a.GetState(),
+				CreatedAt: a.GetCreatedAt().Time,
+				ClosedAt:  firstTime(a.FixedAt, a.DismissedAt, a.ClosedAt),
+			}
+			alert.AgeDays = ageDays(alert.CreatedAt, alert.ClosedAt, now)
+			alerts = append(alerts, alert)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return alerts, nil
+}
+
+// listSecretScanningAlerts lists all secret-scanning alerts of a repository.
+// A leaked secret is always treated as critical.
+func listSecretScanningAlerts(ctx context.Context, cli *github.Client, owner, repo string) ([]SecurityAlert, error) {
+	var alerts []SecurityAlert
+	now := time.Now()
+
+	opts := &github.SecretScanningAlertListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := cli.SecretScanning.ListAlertsForRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range page {
+			alert := SecurityAlert{
+				Source:    SourceSecretScanning,
+				Owner:     owner,
+				Repo:      repo,
+				Number:    a.GetNumber(),
+				Severity:  "critical",
+				Title:     a.GetSecretTypeDisplayName(),
+				URL:       a.GetHTMLURL(),
+				State:     a.GetState(),
+				CreatedAt: a.GetCreatedAt().Time,
+			}
+			if a.GetState() == "resolved" {
+				alert.ClosedAt = firstTime(a.ResolvedAt)
+			}
+			alert.AgeDays = ageDays(alert.CreatedAt, alert.ClosedAt, now)
+			alerts = append(alerts, alert)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return alerts, nil
+}
+
+// normalizeSeverity maps advisory and code-scanning severities to critical/high/medium/low
+func normalizeSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return "critical"
+	case "high", "error":
+		return "high"
+	case "medium", "moderate", "warning":
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// ageBucket groups an alert age into the report buckets
+func ageBucket(days int) string {
+	switch {
+	case days <= 7:
+		return "0-7d"
+	case days <= 30:
+		return "8-30d"
+	case days <= 90:
+		return "31-90d"
+	default:
+		return "90d+"
+	}
+}
+
+// ageDays returns how long an alert has been (or was) open, in days
+func ageDays(created, closed, now time.Time) int {
+	if created.IsZero() {
+		return 0
+	}
+	end := now
+	if !closed.IsZero() {
+		end = closed
+	}
+	return int(end.Sub(created).Hours() / 24)
+}
+
+// firstTime returns the first non-nil timestamp
+func firstTime(ts ...*github.Timestamp) time.Time {
+	for _, t := range ts {
+		if t != nil && !t.Time.IsZero() {
+			return t.Time
+		}
+	}
+	return time.Time{}
+}
+
+// meanHours returns the mean of the durations in hours
+func meanHours(durations []time.Duration) float64 {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return (total / time.Duration(len(durations))).Hours()
+}
+
+// sortOldestFirst orders alerts by creation date, oldest first
+func sortOldestFirst(alerts []SecurityAlert) {
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].CreatedAt.Before(alerts[j].CreatedAt)
+	})
+}
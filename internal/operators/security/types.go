@@ -1,8 +1,51 @@
 package security
 
+import "time"
+
 // SSHKeyPair represents an SSH key pair
 type SSHKeyPair struct {
 	PublicKey  string `json:"public_key"`
 	PrivateKey string `json:"private_key"`
 	KeyID      int64  `json:"key_id,omitempty"`
 }
+
+// AlertsReport aggregates Dependabot, code-scanning and secret-scanning alerts for a repository
+type AlertsReport struct {
+	Owner          string          `json:"owner"`
+	Repo           string          `json:"repo"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+	Dependabot     *AlertSummary   `json:"dependabot"`
+	CodeScanning   *AlertSummary   `json:"code_scanning"`
+	SecretScanning *AlertSummary   `json:"secret_scanning"`
+	OpenTotal      int             `json:"open_total"`
+	BySeverity     map[string]int  `json:"by_severity"`
+	MTTRHours      float64         `json:"mttr_hours"`
+	OpenAlerts     []SecurityAlert `json:"open_alerts"`
+	CriticalFixes  []string        `json:"critical_fixes"`
+	Notes          []string        `json:"notes"`
+}
+
+// AlertSummary aggregates the alerts of a single source by severity and age
+type AlertSummary struct {
+	Available  bool           `json:"available"`
+	Open       int            `json:"open"`
+	Closed     int            `json:"closed"`
+	BySeverity map[string]int `json:"by_severity"`
+	ByAge      map[string]int `json:"by_age"`
+	MTTRHours  float64        `json:"mttr_hours"`
+}
+
+// SecurityAlert is a normalized view of an alert from any of the scanning sources
+type SecurityAlert struct {
+	Source    string    `json:"source"` // "dependabot", "code_scanning", "secret_scanning"
+	Owner     string    `json:"owner"`
+	Repo      string    `json:"repo"`
+	Number    int       `json:"number"`
+	Severity  string    `json:"severity"` // "critical", "high", "medium", "low"
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+	ClosedAt  time.Time `json:"closed_at,omitempty"`
+	AgeDays   int       `json:"age_days"`
+}
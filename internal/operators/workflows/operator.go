@@ -0,0 +1,45 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubex-ecosystem/ghbex/internal/defs/gitz"
+	rt "github.com/kubex-ecosystem/ghbex/internal/runtime"
+)
+
+// OperatorCleanup is the runtime name of the workflow run cleanup rule
+const OperatorCleanup = "runs.cleanup"
+
+// CleanupResult is the outcome of one cleanup run
+type CleanupResult struct {
+	Deleted int     `json:"deleted"`
+	Kept    int     `json:"kept"`
+	IDs     []int64 `json:"ids"`
+	DryRun  bool    `json:"dry_run"`
+}
+
+// Register adds the workflow run cleanup to a runtime registry. It reads max_age_days,
+// keep_success_last and only_workflows from the params and needs a *github.Client in the
+// GitHub client bundle.
+func Register(reg rt.Registry) {
+	reg.Register(rt.NewOperator(OperatorCleanup, "1.0.0", func(ctx context.Context, in rt.OpInput) (rt.OpOutput, error) {
+		cli, ok := rt.GitHubClient(in)
+		if !ok {
+			return rt.OpOutput{}, fmt.Errorf("%s needs a GitHub client", OperatorCleanup)
+		}
+		rule := gitz.NewRunsRuleType(
+			rt.ParamInt(in.Params, "max_age_days", 30),
+			rt.ParamInt(in.Params, "keep_success_last", 10),
+			rt.ParamStrings(in.Params, "only_workflows"),
+		)
+		deleted, kept, ids, err := CleanRuns(ctx, cli, in.Repo.Owner, in.Repo.Name, rule, in.DryRun)
+		if err != nil {
+			return rt.OpOutput{}, fmt.Errorf("failed to clean workflow runs: %w", err)
+		}
+		return rt.OpOutput{
+			Data:    &CleanupResult{Deleted: deleted, Kept: kept, IDs: ids, DryRun: in.DryRun},
+			Metrics: []rt.Metric{{Name: "runs_deleted", Value: float64(deleted), Unit: "count"}},
+		}, nil
+	}))
+}
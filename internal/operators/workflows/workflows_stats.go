@@ -0,0 +1,52 @@
+package workflows
+
+import (
+	"context"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/utils"
+)
+
+// failedConclusions are the run conclusions counted as failures; cancelled and skipped runs are ignored
+var failedConclusions = map[string]bool{
+	"failure":         true,
+	"timed_out":       true,
+	"startup_failure": true,
+}
+
+// FailureRate is the percentage of failed workflow runs among the runs that succeeded or failed
+// in the last days, looking at no more than maxRuns runs (0 for no limit).
+func FailureRate(ctx context.Context, cli *github.Client, owner, repo string, days, maxRuns int) (rate float64, counted int, err error) {
+	opt := &github.ListWorkflowRunsOptions{Status: "completed", ListOptions: github.ListOptions{PerPage: 100}}
+	if cut := utils.Cutoff(days); !cut.IsZero() {
+		opt.Created = ">=" + cut.Format("2006-01-02")
+	}
+
+	failed, seen := 0, 0
+	for {
+		rs, resp, e := cli.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opt)
+		if e != nil {
+			return 0, 0, e
+		}
+		for _, run := range rs.WorkflowRuns {
+			seen++
+			conclusion := run.GetConclusion()
+			switch {
+			case conclusion == "success":
+				counted++
+			case failedConclusions[conclusion]:
+				counted++
+				failed++
+			}
+		}
+		if resp.NextPage == 0 || (maxRuns > 0 && seen >= maxRuns) {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	if counted > 0 {
+		rate = float64(failed) / float64(counted) * 100
+	}
+	return rate, counted, nil
+}
@@ -0,0 +1,168 @@
+package worktime
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// maxPatchHeader bounds how much of the patch is read to find the Date header
+const maxPatchHeader = 8 << 10
+
+func normalizeOptions(opts Options) Options {
+	def := DefaultOptions()
+	if opts.WindowDays <= 0 {
+		opts.WindowDays = def.WindowDays
+	}
+	if opts.MaxCommits <= 0 {
+		opts.MaxCommits = def.MaxCommits
+	}
+	if opts.WorkStart < 0 || opts.WorkStart > 23 || opts.WorkEnd <= opts.WorkStart || opts.WorkEnd > 24 {
+		opts.WorkStart, opts.WorkEnd = def.WorkStart, def.WorkEnd
+	}
+	if opts.BurnoutRatio <= 0 {
+		opts.BurnoutRatio = def.BurnoutRatio
+	}
+	if opts.MinCommits <= 0 {
+		opts.MinCommits = def.MinCommits
+	}
+	return opts
+}
+
+// fetchAuthorDate reads the "Date:" header of the commit in patch format, which keeps the
+// author's original offset (the JSON API normalizes it to UTC)
+func fetchAuthorDate(ctx context.Context, cli *github.Client, owner, repo, sha string) (time.Time, error) {
+	req, err := cli.NewRequest("GET", fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, sha), nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.patch")
+
+	resp, err := cli.BareDo(ctx, req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxPatchHeader))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break // end of the mail headers
+		}
+		if strings.HasPrefix(line, "Date: ") {
+			return mail.ParseDate(strings.TrimPrefix(line, "Date: "))
+		}
+	}
+	return time.Time{}, fmt.Errorf("date header not found")
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+func pct(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
+
+// formatOffset renders the offset of t as "+hh:mm"
+func formatOffset(t time.Time) string {
+	_, seconds := t.Zone()
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// parseOffset converts "+hh:mm" to minutes
+func parseOffset(offset string) int {
+	var h, m int
+	if len(offset) != 6 {
+		return 0
+	}
+	if _, err := fmt.Sscanf(offset[1:], "%02d:%02d", &h, &m); err != nil {
+		return 0
+	}
+	minutes := h*60 + m
+	if offset[0] == '-' {
+		minutes = -minutes
+	}
+	return minutes
+}
+
+// primaryOffset returns the most used offset, the smallest one on ties
+func primaryOffset(offsets map[string]int) string {
+	keys := make([]string, 0, len(offsets))
+	for k := range offsets {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if offsets[keys[i]] != offsets[keys[j]] {
+			return offsets[keys[i]] > offsets[keys[j]]
+		}
+		return parseOffset(keys[i]) < parseOffset(keys[j])
+	})
+	if len(keys) == 0 {
+		return "+00:00"
+	}
+	return keys[0]
+}
+
+// markWorkingHours marks the UTC hours inside the local working window of an offset
+func markWorkingHours(covered *[24]bool, offsetMinutes, start, end int) {
+	for local := start; local < end; local++ {
+		minutes := ((local*60-offsetMinutes)%(24*60) + 24*60) % (24 * 60)
+		covered[minutes/60] = true
+	}
+}
+
+// spreadHours is the smallest arc of the 24h circle that contains every offset
+func spreadHours(offsets map[int]bool) float64 {
+	if len(offsets) < 2 {
+		return 0
+	}
+	values := make([]int, 0, len(offsets))
+	for m := range offsets {
+		values = append(values, m)
+	}
+	sort.Ints(values)
+
+	// the spread is the circle minus its largest gap between consecutive offsets
+	largestGap := values[0] + 24*60 - values[len(values)-1]
+	for i := 1; i < len(values); i++ {
+		if gap := values[i] - values[i-1]; gap > largestGap {
+			largestGap = gap
+		}
+	}
+	return float64(24*60-largestGap) / 60
+}
+
+// hourRanges compacts sorted hours into "0-5, 22-23"
+func hourRanges(hours []int) string {
+	var parts []string
+	for i := 0; i < len(hours); {
+		j := i
+		for j+1 < len(hours) && hours[j+1] == hours[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, fmt.Sprintf("%d", hours[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", hours[i], hours[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
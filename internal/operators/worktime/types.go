@@ -0,0 +1,58 @@
+package worktime
+
+import "time"
+
+// Options tunes the working-hours analysis
+type Options struct {
+	WindowDays int // History window (default 90)
+	MaxCommits int // Upper bound of commits inspected (default 300)
+	WorkStart  int // Local hour the working day starts (default 9)
+	WorkEnd    int // Local hour the working day ends, exclusive (default 18)
+	// ResolveOffsets fetches the patch header of commits whose API date has no offset.
+	// The REST API normalizes dates to UTC, so without it every author looks like UTC.
+	ResolveOffsets bool
+	// BurnoutRatio flags contributors whose weekend plus after-hours share exceeds it (default 0.4)
+	BurnoutRatio float64
+	// MinCommits is the minimum number of commits before a contributor can be flagged (default 10)
+	MinCommits int
+}
+
+// CommitTime is a commit author date in the author's own timezone
+type CommitTime struct {
+	SHA    string    `json:"sha"`
+	Author string    `json:"author"`
+	At     time.Time `json:"at"`
+}
+
+// Heatmap counts commits by local weekday (0 = Sunday) and hour
+type Heatmap [7][24]int
+
+// ContributorHours summarizes when one contributor works
+type ContributorHours struct {
+	Author        string         `json:"author"`
+	Commits       int            `json:"commits"`
+	Timezone      string         `json:"timezone"` // Most used UTC offset, e.g. "-03:00"
+	Offsets       map[string]int `json:"offsets"`
+	WeekendPct    float64        `json:"weekend_pct"`
+	AfterHoursPct float64        `json:"after_hours_pct"`
+	BurnoutRisk   bool           `json:"burnout_risk"`
+}
+
+// Report is the timezone and working-hours analysis of a repository
+type Report struct {
+	Owner           string             `json:"owner"`
+	Repo            string             `json:"repo"`
+	Since           time.Time          `json:"since"`
+	CommitsAnalyzed int                `json:"commits_analyzed"`
+	OffsetsResolved int                `json:"offsets_resolved"`
+	Timezones       map[string]int     `json:"timezones"` // Contributors per primary UTC offset
+	TimezoneSpread  int                `json:"timezone_spread"`
+	SpreadHours     float64            `json:"spread_hours"`         // Distance between the westmost and eastmost offsets
+	Diversity       float64            `json:"geographic_diversity"` // Normalized entropy of contributors per offset (0..100)
+	WeekendPct      float64            `json:"weekend_pct"`
+	AfterHoursPct   float64            `json:"after_hours_pct"`
+	FollowTheSun    float64            `json:"follow_the_sun"` // % of the UTC day inside someone's working hours
+	UncoveredHours  []int              `json:"uncovered_utc_hours"`
+	Heatmap         Heatmap            `json:"heatmap"` // Local weekday x hour
+	Contributors    []ContributorHours `json:"contributors"`
+}
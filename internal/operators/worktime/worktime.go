@@ -0,0 +1,217 @@
+// Package worktime analyzes when contributors work, using the UTC offsets of commit author
+// dates: timezone spread, weekend and after-hours ratios, follow-the-sun coverage and a
+// 7x24 activity heatmap.
+package worktime
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/identity"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// DefaultOptions returns a 90 day window, a 9-18 working day and offset resolution enabled
+func DefaultOptions() Options {
+	return Options{
+		WindowDays:     90,
+		MaxCommits:     300,
+		WorkStart:      9,
+		WorkEnd:        18,
+		ResolveOffsets: true,
+		BurnoutRatio:   0.4,
+		MinCommits:     10,
+	}
+}
+
+// Analyze lists the commits of the window, resolves their author offsets and computes the report
+func Analyze(ctx context.Context, cli *github.Client, owner, repo string, opts Options) (*Report, error) {
+	if cli == nil {
+		return nil, fmt.Errorf("GitHub client is nil")
+	}
+	opts = normalizeOptions(opts)
+	since := time.Now().AddDate(0, 0, -opts.WindowDays)
+
+	people := identity.ForRepository(ctx, cli, owner, repo)
+	commits, resolved, err := listCommitTimes(ctx, cli, people, owner, repo, since, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	for i := range commits {
+		commits[i].Author = people.Canonical(commits[i].Author)
+	}
+
+	report := Compute(commits, opts)
+	report.Owner = owner
+	report.Repo = repo
+	report.Since = since
+	report.OffsetsResolved = resolved
+	return report, nil
+}
+
+// Compute builds the report from commits whose At carries the author's offset
+func Compute(commits []CommitTime, opts Options) *Report {
+	opts = normalizeOptions(opts)
+	report := &Report{
+		CommitsAnalyzed: len(commits),
+		Timezones:       make(map[string]int),
+		Contributors:    []ContributorHours{},
+		UncoveredHours:  []int{},
+	}
+	if len(commits) == 0 {
+		return report
+	}
+
+	byAuthor := make(map[string]*ContributorHours)
+	weekend, afterHours := 0, 0
+	for _, c := range commits {
+		ch := byAuthor[c.Author]
+		if ch == nil {
+			ch = &ContributorHours{Author: c.Author, Offsets: make(map[string]int)}
+			byAuthor[c.Author] = ch
+		}
+		ch.Commits++
+		ch.Offsets[formatOffset(c.At)]++
+
+		report.Heatmap[c.At.Weekday()][c.At.Hour()]++
+		switch {
+		case isWeekend(c.At):
+			ch.WeekendPct++
+			weekend++
+		case c.At.Hour() < opts.WorkStart || c.At.Hour() >= opts.WorkEnd:
+			ch.AfterHoursPct++
+			afterHours++
+		}
+	}
+	report.WeekendPct = pct(weekend, len(commits))
+	report.AfterHoursPct = pct(afterHours, len(commits))
+
+	offsets := make(map[int]bool) // primary offsets in minutes
+	var covered [24]bool
+	for _, ch := range byAuthor {
+		ch.Timezone = primaryOffset(ch.Offsets)
+		ch.WeekendPct = ch.WeekendPct / float64(ch.Commits) * 100
+		ch.AfterHoursPct = ch.AfterHoursPct / float64(ch.Commits) * 100
+		ch.BurnoutRisk = ch.Commits >= opts.MinCommits && (ch.WeekendPct+ch.AfterHoursPct)/100 > opts.BurnoutRatio
+		report.Timezones[ch.Timezone]++
+
+		minutes := parseOffset(ch.Timezone)
+		offsets[minutes] = true
+		markWorkingHours(&covered, minutes, opts.WorkStart, opts.WorkEnd)
+		report.Contributors = append(report.Contributors, *ch)
+	}
+
+	sort.Slice(report.Contributors, func(i, j int) bool {
+		if report.Contributors[i].Commits != report.Contributors[j].Commits {
+			return report.Contributors[i].Commits > report.Contributors[j].Commits
+		}
+		return report.Contributors[i].Author < report.Contributors[j].Author
+	})
+
+	report.TimezoneSpread = len(offsets)
+	report.SpreadHours = spreadHours(offsets)
+	report.Diversity = diversity(report.Timezones, len(byAuthor))
+
+	hours := 0
+	for h, ok := range covered {
+		if ok {
+			hours++
+		} else {
+			report.UncoveredHours = append(report.UncoveredHours, h)
+		}
+	}
+	report.FollowTheSun = pct(hours, 24)
+
+	return report
+}
+
+// ToMarkdown renders a summary of the working-hours analysis
+func ToMarkdown(r *Report) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Working Hours: %s/%s\n\n", r.Owner, r.Repo))
+	sb.WriteString(fmt.Sprintf("- **Commits Analyzed:** %d (%d offsets resolved)\n", r.CommitsAnalyzed, r.OffsetsResolved))
+	sb.WriteString(fmt.Sprintf("- **Timezones:** %d, spread %.1fh, diversity %.0f/100\n", r.TimezoneSpread, r.SpreadHours, r.Diversity))
+	sb.WriteString(fmt.Sprintf("- **Weekend Work:** %.1f%%\n", r.WeekendPct))
+	sb.WriteString(fmt.Sprintf("- **After-hours Work:** %.1f%%\n", r.AfterHoursPct))
+	sb.WriteString(fmt.Sprintf("- **Follow-the-sun Coverage:** %.0f%%", r.FollowTheSun))
+	if len(r.UncoveredHours) > 0 && len(r.UncoveredHours) < 24 {
+		sb.WriteString(fmt.Sprintf(" (uncovered UTC hours: %s)", hourRanges(r.UncoveredHours)))
+	}
+	sb.WriteString("\n")
+
+	if len(r.Contributors) == 0 {
+		return sb.String()
+	}
+	sb.WriteString("\n| Contributor | Commits | Timezone | Weekend | After hours | Burnout risk |\n")
+	sb.WriteString("|-------------|---------|----------|---------|-------------|--------------|\n")
+	for _, c := range r.Contributors {
+		risk := ""
+		if c.BurnoutRisk {
+			risk = "⚠️"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | UTC%s | %.0f%% | %.0f%% | %s |\n",
+			c.Author, c.Commits, c.Timezone, c.WeekendPct, c.AfterHoursPct, risk))
+	}
+	return sb.String()
+}
+
+// listCommitTimes lists the commits of people (see identity) since the given time with the author's offset
+func listCommitTimes(ctx context.Context, cli *github.Client, people *identity.Resolver, owner, repo string, since time.Time, opts Options) ([]CommitTime, int, error) {
+	var commits []CommitTime
+	resolved := 0
+	list := &github.CommitsListOptions{Since: since, ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := cli.Repositories.ListCommits(ctx, owner, repo, list)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, c := range page {
+			author, counted := people.Key(c.GetAuthor().GetLogin(), c.GetCommit().GetAuthor().GetName(), c.GetCommit().GetAuthor().GetEmail())
+			if !counted {
+				continue
+			}
+
+			at := c.GetCommit().GetAuthor().GetDate().Time
+			if _, offset := at.Zone(); offset == 0 && opts.ResolveOffsets {
+				if local, err := fetchAuthorDate(ctx, cli, owner, repo, c.GetSHA()); err != nil {
+					gl.Log("debug", fmt.Sprintf("Failed to resolve author offset of %s: %v", c.GetSHA(), err))
+				} else {
+					at = local
+					resolved++
+				}
+			}
+			commits = append(commits, CommitTime{SHA: c.GetSHA(), Author: author, At: at})
+			if len(commits) >= opts.MaxCommits {
+				return commits, resolved, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		list.Page = resp.NextPage
+	}
+	return commits, resolved, nil
+}
+
+func diversity(timezones map[string]int, contributors int) float64 {
+	if len(timezones) <= 1 || contributors == 0 {
+		return 0
+	}
+	entropy := 0.0
+	for _, n := range timezones {
+		p := float64(n) / float64(contributors)
+		entropy -= p * math.Log(p)
+	}
+	// normalized by the entropy of contributors spread evenly over every possible zone
+	maxEntropy := math.Log(math.Min(float64(contributors), 24))
+	if maxEntropy == 0 {
+		return 0
+	}
+	return math.Min(entropy/maxEntropy*100, 100)
+}
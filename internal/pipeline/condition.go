@@ -0,0 +1,159 @@
+package pipeline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/runtime"
+)
+
+// Condition is a parsed edge condition: terms joined by && and ||, && binding tighter
+type Condition struct {
+	expr string
+	any  [][]term // OR of ANDs
+}
+
+// term is one test: metric.<name> [op value], [!]insight.<key> or insight.<key>.score op value
+type term struct {
+	negate bool
+	kind   string // metric | insight
+	name   string
+	score  bool // insight.<key>.score
+	op     string
+	value  float64
+}
+
+var comparisons = []string{"<=", ">=", "==", "!=", "<", ">"}
+
+// ParseCondition parses an edge condition
+func ParseCondition(expr string) (*Condition, error) {
+	c := &Condition{expr: strings.TrimSpace(expr)}
+	if c.expr == "" {
+		return c, nil
+	}
+	for _, or := range strings.Split(c.expr, "||") {
+		var all []term
+		for _, and := range strings.Split(or, "&&") {
+			t, err := parseTerm(strings.TrimSpace(and))
+			if err != nil {
+				return nil, fmt.Errorf("invalid condition %q: %w", expr, err)
+			}
+			all = append(all, t)
+		}
+		c.any = append(c.any, all)
+	}
+	return c, nil
+}
+
+func (c *Condition) String() string { return c.expr }
+
+// Eval tests the condition on an upstream output, explaining why it does not hold
+func (c *Condition) Eval(out runtime.OpOutput) (bool, string) {
+	if len(c.any) == 0 {
+		return true, ""
+	}
+	var reasons []string
+	for _, all := range c.any {
+		ok, reason := true, ""
+		for _, t := range all {
+			if ok, reason = t.eval(out); !ok {
+				break
+			}
+		}
+		if ok {
+			return true, ""
+		}
+		reasons = append(reasons, reason)
+	}
+	return false, strings.Join(reasons, " and ")
+}
+
+func parseTerm(text string) (term, error) {
+	var t term
+	if strings.HasPrefix(text, "!") {
+		t.negate = true
+		text = strings.TrimSpace(text[1:])
+	}
+	ref := text
+	for _, op := range comparisons {
+		if i := strings.Index(text, op); i >= 0 {
+			v, err := strconv.ParseFloat(strings.TrimSpace(text[i+len(op):]), 64)
+			if err != nil {
+				return t, fmt.Errorf("%q is not a number", strings.TrimSpace(text[i+len(op):]))
+			}
+			ref, t.op, t.value = strings.TrimSpace(text[:i]), op, v
+			break
+		}
+	}
+
+	kind, name, ok := strings.Cut(ref, ".")
+	if !ok || name == "" {
+		return t, fmt.Errorf("%q should be metric.<name> or insight.<key>", ref)
+	}
+	t.kind, t.name = kind, name
+	switch kind {
+	case "metric":
+	case "insight":
+		if key, ok := strings.CutSuffix(name, ".score"); ok {
+			t.name, t.score = key, true
+		}
+		if t.score != (t.op != "") {
+			return t, fmt.Errorf("compare insight scores as insight.<key>.score <op> <value>, test insights as insight.<key>")
+		}
+	default:
+		return t, fmt.Errorf("unknown reference %q, expected metric or insight", kind)
+	}
+	return t, nil
+}
+
+func (t term) eval(out runtime.OpOutput) (bool, string) {
+	var ok bool
+	var reason string
+	switch t.kind {
+	case "metric":
+		ok, reason = false, fmt.Sprintf("metric %s is missing", t.name)
+		for _, m := range out.Metrics {
+			if m.Name == t.name {
+				ok = compare(m.Value, t.op, t.value)
+				reason = fmt.Sprintf("metric %s is %s", t.name, strconv.FormatFloat(m.Value, 'f', -1, 64))
+				break
+			}
+		}
+	case "insight":
+		ok, reason = false, fmt.Sprintf("no %s insight", t.name)
+		for _, in := range out.Insights {
+			if in.Key == t.name {
+				ok, reason = true, fmt.Sprintf("%s insight present", t.name)
+				if t.score {
+					ok = compare(in.Score, t.op, t.value)
+					reason = fmt.Sprintf("%s insight score is %s", t.name, strconv.FormatFloat(in.Score, 'f', -1, 64))
+				}
+				break
+			}
+		}
+	}
+	if t.negate {
+		ok = !ok
+	}
+	return ok, reason
+}
+
+// compare applies op; without op a metric holds when it is not zero
+func compare(v float64, op string, x float64) bool {
+	switch op {
+	case "<":
+		return v < x
+	case "<=":
+		return v <= x
+	case ">":
+		return v > x
+	case ">=":
+		return v >= x
+	case "==":
+		return v == x
+	case "!=":
+		return v != x
+	}
+	return v != 0
+}
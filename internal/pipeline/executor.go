@@ -0,0 +1,158 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/runtime"
+)
+
+// Executor runs pipelines through a runtime Manager, so every node goes through its middlewares
+type Executor struct {
+	Manager *runtime.Manager
+	Clients runtime.ClientBundle
+	// DryRun is passed to every operator; nodes can also force it
+	DryRun bool
+	// Params returns the base params of a node on a repository, e.g. from the configured rules
+	// of its operator; the node params override them. An error fails the node. Nil for none.
+	Params func(repo runtime.RepoRef, node Node) (map[string]any, error)
+	// OnNode is called as each node finishes
+	OnNode func(repo runtime.RepoRef, res NodeResult)
+}
+
+// Run runs the pipeline on one repository. Each node starts as soon as its upstream nodes are
+// done and its edges hold; nodes whose edges do not hold are skipped, and so are the nodes
+// depending on them on success. When ctx is canceled, the nodes not started are skipped.
+func (e *Executor) Run(ctx context.Context, def *Definition, repo runtime.RepoRef) (*Result, error) {
+	if err := def.Validate(nil); err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(def.Nodes))
+	for i, n := range def.Nodes {
+		index[n.ID] = i
+	}
+	conds := map[string]*Condition{}
+	for _, n := range def.Nodes {
+		for _, edge := range n.Needs {
+			conds[edge.When], _ = ParseCondition(edge.When)
+		}
+	}
+
+	res := &Result{Pipeline: def.Name, Repo: repo.Owner + "/" + repo.Name, DryRun: e.DryRun, Nodes: make([]NodeResult, len(def.Nodes))}
+	done := make([]chan struct{}, len(def.Nodes))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	sem := make(chan struct{}, def.maxParallel())
+
+	var wg sync.WaitGroup
+	for i, node := range def.Nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+			for _, edge := range node.Needs {
+				<-done[index[edge.Node]]
+			}
+			// Upstream results are final once their channel is closed
+			nr := e.runNode(ctx, node, repo, sem, func(edge Edge) (NodeResult, *Condition) {
+				return res.Nodes[index[edge.Node]], conds[edge.When]
+			}, func(id string) (NodeResult, bool) {
+				j, ok := index[id]
+				if !ok {
+					return NodeResult{}, false
+				}
+				return res.Nodes[j], true
+			})
+			res.Nodes[i] = nr
+			if e.OnNode != nil {
+				e.OnNode(repo, nr)
+			}
+		}()
+	}
+	wg.Wait()
+	return res, nil
+}
+
+// runNode checks the edges of a node, resolves its params and dispatches its operator
+func (e *Executor) runNode(ctx context.Context, node Node, repo runtime.RepoRef, sem chan struct{}, edge func(Edge) (NodeResult, *Condition), upstream func(string) (NodeResult, bool)) NodeResult {
+	nr := NodeResult{Node: node.ID, Operator: node.Operator, Started: time.Now()}
+	for _, ed := range node.Needs {
+		up, cond := edge(ed)
+		if ok, reason := edgeHolds(ed, up, cond); !ok {
+			nr.Status, nr.Reason = StatusSkipped, reason
+			return nr
+		}
+	}
+
+	params := map[string]any{}
+	if e.Params != nil {
+		base, err := e.Params(repo, node)
+		if err != nil {
+			nr.Status, nr.Error = StatusFailed, err.Error()
+			return nr
+		}
+		for k, v := range base {
+			params[k] = v
+		}
+	}
+	resolved, err := resolveParams(node.Params, upstream)
+	if err != nil {
+		nr.Status, nr.Error = StatusFailed, err.Error()
+		return nr
+	}
+	for k, v := range resolved {
+		params[k] = v
+	}
+	nr.Params = params
+
+	select {
+	case sem <- struct{}{}:
+		defer func() { <-sem }()
+	case <-ctx.Done():
+	}
+	if ctx.Err() != nil {
+		nr.Status, nr.Reason = StatusSkipped, "pipeline canceled"
+		return nr
+	}
+
+	nr.Started = time.Now()
+	out, err := e.Manager.Dispatch(ctx, node.Operator, runtime.OpInput{
+		Repo:    repo,
+		Params:  params,
+		Clients: e.Clients,
+		DryRun:  e.DryRun || node.DryRun,
+	})
+	nr.Duration = time.Since(nr.Started)
+	nr.Output, nr.Metrics, nr.Insights = &out, out.Metrics, out.Insights
+	nr.Status = StatusSucceeded
+	if err != nil {
+		nr.Status, nr.Error = StatusFailed, err.Error()
+	}
+	return nr
+}
+
+// edgeHolds reports whether an edge lets its node run, and why not
+func edgeHolds(edge Edge, up NodeResult, cond *Condition) (bool, string) {
+	switch {
+	case up.Status == StatusSkipped && edge.On != OnAlways:
+		return false, fmt.Sprintf("%s was skipped", edge.Node)
+	case (edge.On == "" || edge.On == OnSuccess) && up.Status != StatusSucceeded:
+		return false, fmt.Sprintf("%s failed", edge.Node)
+	case edge.On == OnFailure && up.Status != StatusFailed:
+		return false, fmt.Sprintf("%s did not fail", edge.Node)
+	}
+	if cond == nil {
+		return true, ""
+	}
+	out := runtime.OpOutput{}
+	if up.Output != nil {
+		out = *up.Output
+	}
+	if ok, reason := cond.Eval(out); !ok {
+		return false, fmt.Sprintf("%s: %s is false (%s)", edge.Node, cond, reason)
+	}
+	return true, ""
+}
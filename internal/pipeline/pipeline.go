@@ -0,0 +1,223 @@
+// Package pipeline runs operator pipelines declared as DAGs in YAML: nodes reference
+// registered runtime operators with their params, edges wait for upstream nodes and carry
+// conditions on their metrics and insights, and params can take values from upstream outputs.
+// Independent nodes run in parallel through the runtime Manager and its middlewares.
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kubex-ecosystem/ghbex/internal/runtime"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultMaxParallel = 4
+
+// refPattern matches ${node.metrics.name}, ${node.insights.key}, ${node.data[.path]} and
+// ${node.artifacts.name}
+var refPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_-]+)\.(metrics|insights|data|artifacts)(?:\.([^}]+))?\}`)
+
+// Load reads and validates the structure of a pipeline file. Environment variables in the
+// file are expanded; references to upstream outputs (${node.kind...}) are kept as they are.
+func Load(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pipeline: %w", err)
+	}
+	return Parse([]byte(os.Expand(string(data), func(key string) string {
+		if strings.Contains(key, ".") {
+			return "${" + key + "}"
+		}
+		return os.Getenv(key)
+	})))
+}
+
+// Parse decodes and validates the structure of a pipeline definition
+func Parse(data []byte) (*Definition, error) {
+	def := &Definition{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(def); err != nil {
+		return nil, fmt.Errorf("failed to decode pipeline: %w", err)
+	}
+	if err := def.Validate(nil); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// Validate checks node IDs, edges, conditions, references and cycles, and with a registry
+// that every operator is registered
+func (d *Definition) Validate(reg runtime.Registry) error {
+	var errs []error
+	if len(d.Nodes) == 0 {
+		errs = append(errs, errors.New("pipeline has no nodes"))
+	}
+	index := map[string]int{}
+	for i, n := range d.Nodes {
+		if _, dup := index[n.ID]; dup {
+			errs = append(errs, fmt.Errorf("duplicate node %s", n.ID))
+		} else if n.ID == "" {
+			errs = append(errs, fmt.Errorf("node %d has no id", i+1))
+		}
+		index[n.ID] = i
+		if n.Operator == "" {
+			errs = append(errs, fmt.Errorf("node %s has no operator", n.ID))
+		} else if reg != nil {
+			if _, ok := reg.Get(n.Operator); !ok {
+				errs = append(errs, fmt.Errorf("node %s: unknown operator %s", n.ID, n.Operator))
+			}
+		}
+	}
+	for _, n := range d.Nodes {
+		for _, e := range n.Needs {
+			if _, ok := index[e.Node]; !ok {
+				errs = append(errs, fmt.Errorf("node %s needs unknown node %s", n.ID, e.Node))
+			}
+			switch e.On {
+			case "", OnSuccess, OnFailure, OnAlways:
+			default:
+				errs = append(errs, fmt.Errorf("node %s: edge from %s has invalid on %q, expected success, failure or always", n.ID, e.Node, e.On))
+			}
+			if _, err := ParseCondition(e.When); err != nil {
+				errs = append(errs, fmt.Errorf("node %s: %w", n.ID, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	levels, err := d.Levels()
+	if err != nil {
+		return err
+	}
+	// References must point to upstream nodes, which are done when the node starts
+	upstream := d.ancestors(levels)
+	for _, n := range d.Nodes {
+		for _, ref := range references(n.Params) {
+			if !upstream[n.ID][ref] {
+				errs = append(errs, fmt.Errorf("node %s references %s, which is not upstream of it", n.ID, ref))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Levels groups the nodes into steps: every node runs after the steps holding its upstream
+// nodes, and the nodes of a step are independent of each other
+func (d *Definition) Levels() ([][]Node, error) {
+	index := make(map[string]int, len(d.Nodes))
+	for i, n := range d.Nodes {
+		index[n.ID] = i
+	}
+	depth := make([]int, len(d.Nodes))
+	state := make([]int, len(d.Nodes)) // 0 new, 1 visiting, 2 done
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch state[i] {
+		case 1:
+			return fmt.Errorf("pipeline has a cycle: %s", strings.Join(append(path, d.Nodes[i].ID), " → "))
+		case 2:
+			return nil
+		}
+		state[i] = 1
+		for _, e := range d.Nodes[i].Needs {
+			j, ok := index[e.Node]
+			if !ok {
+				return fmt.Errorf("node %s needs unknown node %s", d.Nodes[i].ID, e.Node)
+			}
+			if err := visit(j, append(path, d.Nodes[i].ID)); err != nil {
+				return err
+			}
+			depth[i] = max(depth[i], depth[j]+1)
+		}
+		state[i] = 2
+		return nil
+	}
+	levels := [][]Node{}
+	for i := range d.Nodes {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+	for i, n := range d.Nodes {
+		for len(levels) <= depth[i] {
+			levels = append(levels, nil)
+		}
+		levels[depth[i]] = append(levels[depth[i]], n)
+	}
+	return levels, nil
+}
+
+// FormatPlan renders the execution plan of a pipeline over repos
+func FormatPlan(d *Definition, repos []string, dryRun bool) (string, error) {
+	levels, err := d.Levels()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pipeline %s: %d nodes in %d steps on %d repositories (up to %d nodes in parallel, dry run %t)\n",
+		d.Name, len(d.Nodes), len(levels), len(repos), d.maxParallel(), dryRun)
+	if d.Description != "" {
+		fmt.Fprintf(&b, "  %s\n", d.Description)
+	}
+	for i, level := range levels {
+		mode := ""
+		if len(level) > 1 {
+			mode = " (parallel)"
+		}
+		fmt.Fprintf(&b, "\nStep %d%s\n", i+1, mode)
+		for _, n := range level {
+			fmt.Fprintf(&b, "  • %s → %s", n.ID, n.Operator)
+			if n.DryRun && !dryRun {
+				b.WriteString(" (dry run)")
+			}
+			b.WriteString("\n")
+			for _, e := range n.Needs {
+				fmt.Fprintf(&b, "      after %s\n", e)
+			}
+			keys := make([]string, 0, len(n.Params))
+			for k := range n.Params {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				fmt.Fprintf(&b, "      %s = %v\n", k, n.Params[k])
+			}
+		}
+	}
+	fmt.Fprintf(&b, "\nRepositories: %s\n", strings.Join(repos, ", "))
+	return b.String(), nil
+}
+
+func (d *Definition) maxParallel() int {
+	if d.MaxParallel > 0 {
+		return d.MaxParallel
+	}
+	return defaultMaxParallel
+}
+
+// ancestors maps every node to the set of nodes upstream of it
+func (d *Definition) ancestors(levels [][]Node) map[string]map[string]bool {
+	out := make(map[string]map[string]bool, len(d.Nodes))
+	for _, level := range levels {
+		for _, n := range level {
+			set := map[string]bool{}
+			for _, e := range n.Needs {
+				set[e.Node] = true
+				for a := range out[e.Node] {
+					set[a] = true
+				}
+			}
+			out[n.ID] = set
+		}
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// references lists the nodes referenced by the string params, nested ones included
+func references(params map[string]any) []string {
+	var out []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case string:
+			for _, m := range refPattern.FindAllStringSubmatch(v, -1) {
+				out = append(out, m[1])
+			}
+		case map[string]any:
+			for _, x := range v {
+				walk(x)
+			}
+		case []any:
+			for _, x := range v {
+				walk(x)
+			}
+		}
+	}
+	walk(params)
+	return out
+}
+
+// resolveParams copies params, replacing references with upstream outputs
+func resolveParams(params map[string]any, upstream func(string) (NodeResult, bool)) (map[string]any, error) {
+	var resolve func(v any) (any, error)
+	resolve = func(v any) (any, error) {
+		switch v := v.(type) {
+		case string:
+			if m := refPattern.FindStringSubmatch(v); m != nil && m[0] == v {
+				return lookup(m, upstream)
+			}
+			var err error
+			out := refPattern.ReplaceAllStringFunc(v, func(ref string) string {
+				value, lerr := lookup(refPattern.FindStringSubmatch(ref), upstream)
+				if lerr != nil {
+					err = lerr
+					return ref
+				}
+				if s, ok := value.(string); ok {
+					return s
+				}
+				if f, ok := value.(float64); ok {
+					return strconv.FormatFloat(f, 'f', -1, 64)
+				}
+				b, _ := json.Marshal(value)
+				return string(b)
+			})
+			return out, err
+		case map[string]any:
+			out := make(map[string]any, len(v))
+			for k, x := range v {
+				r, err := resolve(x)
+				if err != nil {
+					return nil, err
+				}
+				out[k] = r
+			}
+			return out, nil
+		case []any:
+			out := make([]any, len(v))
+			for i, x := range v {
+				r, err := resolve(x)
+				if err != nil {
+					return nil, err
+				}
+				out[i] = r
+			}
+			return out, nil
+		}
+		return v, nil
+	}
+	out, err := resolve(params)
+	if err != nil || out == nil {
+		return nil, err
+	}
+	return out.(map[string]any), nil
+}
+
+// lookup returns the value of a reference match: node, section and optional path
+func lookup(m []string, upstream func(string) (NodeResult, bool)) (any, error) {
+	node, section, path := m[1], m[2], m[3]
+	up, ok := upstream(node)
+	if !ok || up.Output == nil {
+		return nil, fmt.Errorf("%s: %s has no output", m[0], node)
+	}
+	out := up.Output
+	switch section {
+	case "metrics":
+		for _, metric := range out.Metrics {
+			if metric.Name == path {
+				return metric.Value, nil
+			}
+		}
+	case "insights":
+		for _, in := range out.Insights {
+			if in.Key == path {
+				return in.Summary, nil
+			}
+		}
+	case "artifacts":
+		if data, ok := out.Artifacts[path]; ok {
+			return string(data), nil
+		}
+	case "data":
+		// Navigate the JSON form of the data, so paths use its JSON field names
+		b, err := json.Marshal(out.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m[0], err)
+		}
+		var value any
+		if err := json.Unmarshal(b, &value); err != nil {
+			return nil, fmt.Errorf("%s: %w", m[0], err)
+		}
+		if path == "" {
+			return value, nil
+		}
+		for _, key := range strings.Split(path, ".") {
+			switch cur := value.(type) {
+			case map[string]any:
+				value, ok = cur[key]
+			case []any:
+				i, err := strconv.Atoi(key)
+				ok = err == nil && i >= 0 && i < len(cur)
+				if ok {
+					value = cur[i]
+				}
+			default:
+				ok = false
+			}
+			if !ok {
+				break
+			}
+		}
+		if ok {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("%s not found in the output of %s", m[0], node)
+}
@@ -0,0 +1,116 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/runtime"
+	"gopkg.in/yaml.v3"
+)
+
+// Edge triggers (On)
+const (
+	OnSuccess = "success" // Upstream succeeded (default)
+	OnFailure = "failure" // Upstream failed
+	OnAlways  = "always"  // Upstream finished or was skipped
+)
+
+// Definition is a pipeline file
+type Definition struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Repos       []string `yaml:"repos,omitempty" json:"repos,omitempty"` // owner/name, the configured repositories when empty
+	// MaxParallel bounds the nodes of one repository running at once (default 4)
+	MaxParallel int    `yaml:"max_parallel,omitempty" json:"max_parallel,omitempty"`
+	Nodes       []Node `yaml:"nodes" json:"nodes"`
+}
+
+// Node runs a registered operator. String params may reference upstream outputs:
+// ${node.metrics.<name>}, ${node.insights.<key>}, ${node.data}, ${node.data.<field path>}
+// and ${node.artifacts.<name>}. A param that is a single reference takes the referenced
+// value; references inside longer strings are interpolated as text.
+type Node struct {
+	ID       string         `yaml:"id" json:"id"`
+	Operator string         `yaml:"operator" json:"operator"`
+	Params   map[string]any `yaml:"params,omitempty" json:"params,omitempty"`
+	Needs    []Edge         `yaml:"needs,omitempty" json:"needs,omitempty"`
+	DryRun   bool           `yaml:"dry_run,omitempty" json:"dry_run,omitempty"` // Always dry run this node
+}
+
+// Edge makes a node wait for an upstream node. The node runs when, for every edge, the
+// upstream finished as On requires and When holds on its output, and is skipped otherwise.
+type Edge struct {
+	Node string `yaml:"node" json:"node"`
+	On   string `yaml:"on,omitempty" json:"on,omitempty"`
+	// When is a condition on the upstream metrics and insights, e.g. "metric.health < 60",
+	// "insight.inactive" or "metric.health < 60 && !insight.archived"
+	When string `yaml:"when,omitempty" json:"when,omitempty"`
+}
+
+// UnmarshalYAML also accepts the bare node ID of an unconditional edge
+func (e *Edge) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		e.Node = value.Value
+		return nil
+	}
+	type plain Edge
+	return value.Decode((*plain)(e))
+}
+
+func (e Edge) String() string {
+	s := e.Node
+	if e.On != "" && e.On != OnSuccess {
+		s += " on " + e.On
+	}
+	if e.When != "" {
+		s += " when " + e.When
+	}
+	return s
+}
+
+// Status is the outcome of a node
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusSkipped   Status = "skipped"
+)
+
+// NodeResult is the outcome of one node on one repository
+type NodeResult struct {
+	Node     string            `json:"node"`
+	Operator string            `json:"operator"`
+	Status   Status            `json:"status"`
+	Reason   string            `json:"reason,omitempty"` // Why a node was skipped
+	Error    string            `json:"error,omitempty"`
+	Metrics  []runtime.Metric  `json:"metrics,omitempty"`
+	Insights []runtime.Insight `json:"insights,omitempty"`
+	Output   *runtime.OpOutput `json:"-"`
+	Params   map[string]any    `json:"params,omitempty"` // After resolving references
+	Started  time.Time         `json:"started"`
+	Duration time.Duration     `json:"duration"`
+}
+
+// Result is the run of a pipeline on one repository, with the nodes in definition order
+type Result struct {
+	Pipeline string       `json:"pipeline"`
+	Repo     string       `json:"repo"`
+	DryRun   bool         `json:"dry_run"`
+	Nodes    []NodeResult `json:"nodes"`
+}
+
+// Err lists the failed nodes, nil when there is none
+func (r *Result) Err() error {
+	var failed []string
+	for _, n := range r.Nodes {
+		if n.Status == StatusFailed {
+			failed = append(failed, fmt.Sprintf("%s: %s", n.Node, n.Error))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %d nodes failed (%s)", r.Repo, len(failed), strings.Join(failed, "; "))
+}
@@ -0,0 +1,58 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+var heatmapDays = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// WriteHeatmapSVG gera um heatmap 7x24 (dia da semana x hora) com a intensidade
+// proporcional ao maior valor da grade. cell é o lado de cada célula em pixels.
+func WriteHeatmapSVG(path string, grid [7][24]int, cell int) error {
+	if cell <= 0 {
+		cell = 14
+	}
+	maxV := 0
+	for _, row := range grid {
+		for _, v := range row {
+			if v > maxV {
+				maxV = v
+			}
+		}
+	}
+
+	// margem para os rótulos de dias (esquerda) e horas (topo)
+	left, top := 32, 16
+	width := left + 24*cell + 2
+	height := top + 7*cell + 2
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<svg width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg" role="img" aria-label="activity heatmap" font-family="sans-serif" font-size="9">
+`, width, height, width, height))
+
+	for h := 0; h < 24; h += 3 {
+		sb.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" fill="currentColor">%02d</text>
+`, left+h*cell, top-4, h))
+	}
+	for d, row := range grid {
+		y := top + d*cell
+		sb.WriteString(fmt.Sprintf(`  <text x="0" y="%d" fill="currentColor">%s</text>
+`, y+cell-3, heatmapDays[d]))
+		for h, v := range row {
+			opacity := 0.06
+			if maxV > 0 && v > 0 {
+				opacity = 0.15 + 0.85*float64(v)/float64(maxV)
+			}
+			sb.WriteString(fmt.Sprintf(`  <rect x="%d" y="%d" width="%d" height="%d" fill="#2ea043" fill-opacity="%.2f"><title>%s %02d:00 — %d</title></rect>
+`, left+h*cell, y, cell-1, cell-1, opacity, heatmapDays[d], h, v))
+		}
+	}
+	sb.WriteString("</svg>\n")
+
+	return os.WriteFile(path,
+		[]byte(sb.String()),
+		0644)
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"sync"
+	"time"
 )
 
 // MakeIDKey gera uma chave determinística baseada no operador, repo e params.
@@ -20,6 +22,10 @@ func MakeIDKey(op Operator, in OpInput) string {
 	h.Write([]byte("|"))
 	b := MarshalParamsDeterministic(in.Params)
 	h.Write(b)
+	if in.DryRun {
+		// Um dry-run nunca compartilha resultado com uma execução real (nem o contrário).
+		h.Write([]byte("|dry-run"))
+	}
 	return hex.EncodeToString(h.Sum(nil))
 }
 
@@ -31,3 +37,61 @@ func CanonicalJSON(v any) string {
 	b, _ := json.Marshal(v)
 	return string(b)
 }
+
+// ===== Replay =====
+
+// IdempotencyStore guarda resultados concluídos por chave de idempotência, para que o
+// Manager os devolva a despachos repetidos dentro da janela de replay.
+type IdempotencyStore interface {
+	Get(key string) (OpOutput, bool)
+	Put(key string, val OpOutput, ttl time.Duration)
+}
+
+type memoryIdempotency struct {
+	mu sync.Mutex
+	m  map[string]storedOutput
+}
+
+type storedOutput struct {
+	out     OpOutput
+	expires time.Time
+}
+
+// NewMemoryIdempotencyStore guarda os resultados em memória, descartando os expirados.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotency{m: make(map[string]storedOutput)}
+}
+
+func (s *memoryIdempotency) Get(key string) (OpOutput, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.m[key]
+	if !ok {
+		return OpOutput{}, false
+	}
+	if time.Now().After(v.expires) {
+		delete(s.m, key)
+		return OpOutput{}, false
+	}
+	return v.out, true
+}
+
+func (s *memoryIdempotency) Put(key string, val OpOutput, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for k, v := range s.m {
+		if now.After(v.expires) {
+			delete(s.m, k)
+		}
+	}
+	s.m[key] = storedOutput{out: val, expires: now.Add(ttl)}
+}
+
+// cloneOutput copia as listas de um resultado compartilhado, para que quem o recebe possa
+// acrescentar métricas e insights sem afetar os demais (Data e Artifacts são só leitura).
+func cloneOutput(out OpOutput) OpOutput {
+	out.Metrics = append([]Metric(nil), out.Metrics...)
+	out.Insights = append([]Insight(nil), out.Insights...)
+	return out
+}
@@ -4,19 +4,52 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // Manager despacha operadores com middlewares, idempotência e cancelamento por job.
+// Despachos concorrentes com a mesma IdempotencyKey compartilham uma única execução
+// (single-flight), e resultados concluídos de despachos com IdempotencyKey explícita podem
+// ser reaproveitados por uma janela configurável (ver SetReplay).
 type Manager struct {
 	reg Registry
 	mws []Middleware
 
 	muJobs sync.Mutex
-	jobs   map[string]context.CancelFunc // key = IdempotencyKey
+	jobs   map[string]*flight // key = IdempotencyKey
+
+	store  IdempotencyStore
+	window time.Duration
+}
+
+// flight é uma execução em andamento, compartilhada por todos os despachos com a mesma chave.
+// O cancelamento é por contagem de referências: a execução só é cancelada quando o último
+// despacho desiste (ou via Cancel).
+type flight struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+	refs   int
+	out    OpOutput
+	err    error
 }
 
 func NewManager(reg Registry, mws ...Middleware) *Manager {
-	return &Manager{reg: reg, mws: mws, jobs: make(map[string]context.CancelFunc)}
+	return &Manager{reg: reg, mws: mws, jobs: make(map[string]*flight)}
+}
+
+// Registry devolve o registro de operadores do Manager.
+func (m *Manager) Registry() Registry { return m.reg }
+
+// SetReplay guarda os resultados bem-sucedidos em store e os devolve, sem executar de novo,
+// a despachos com a mesma chave dentro de window. Só vale para despachos com IdempotencyKey
+// explícita: chaves geradas (operador, repo, params) não dizem se o trabalho já foi feito,
+// e um agendamento ou nova execução precisa rodar de fato. Com store nil ou window <= 0 não
+// há replay.
+func (m *Manager) SetReplay(store IdempotencyStore, window time.Duration) *Manager {
+	m.muJobs.Lock()
+	defer m.muJobs.Unlock()
+	m.store, m.window = store, window
+	return m
 }
 
 // Dispatch executa um operador pelo nome, aplicando middlewares e idempotência.
@@ -26,15 +59,80 @@ func (m *Manager) Dispatch(ctx context.Context, name string, in OpInput) (OpOutp
 		return OpOutput{}, fmt.Errorf("unknown operator: %s", name)
 	}
 	op = Chain(op, m.mws...)
-	if in.IdempotencyKey == "" {
+	replay := in.IdempotencyKey != ""
+	if !replay {
 		in.IdempotencyKey = MakeIDKey(op, in)
 	}
+	key := in.IdempotencyKey
 
-	ctx, cancel := context.WithCancel(ctx)
-	m.track(in.IdempotencyKey, cancel)
-	defer m.untrack(in.IdempotencyKey)
+	m.muJobs.Lock()
+	if replay && m.store != nil && m.window > 0 {
+		if out, ok := m.store.Get(key); ok {
+			m.muJobs.Unlock()
+			out = cloneOutput(out)
+			out.Replayed = true
+			return out, nil
+		}
+	}
+	f, shared := m.jobs[key]
+	if !shared {
+		// A execução não herda o cancelamento de quem a iniciou, só os valores do contexto:
+		// ela segue enquanto houver algum despacho esperando por ela.
+		runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		m.jobs[key] = f
+		go m.run(runCtx, op, in, f, replay)
+	}
+	f.refs++
+	m.muJobs.Unlock()
 
-	return op.Run(ctx, in)
+	select {
+	case <-f.done:
+		m.release(key, f)
+		out := cloneOutput(f.out)
+		out.Shared = shared
+		return out, f.err
+	case <-ctx.Done():
+		m.release(key, f)
+		return OpOutput{}, ctx.Err()
+	}
+}
+
+// run executa o operador de um flight e publica o resultado (guardando-o para replay, se
+// a chave foi explícita).
+func (m *Manager) run(ctx context.Context, op Operator, in OpInput, f *flight, replay bool) {
+	defer f.cancel()
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = fmt.Errorf("operator %s panicked: %v", op.Name(), r)
+			}
+		}()
+		f.out, f.err = op.Run(ctx, in)
+	}()
+
+	m.muJobs.Lock()
+	if m.jobs[in.IdempotencyKey] == f {
+		delete(m.jobs, in.IdempotencyKey)
+	}
+	if replay && f.err == nil && m.store != nil && m.window > 0 {
+		m.store.Put(in.IdempotencyKey, cloneOutput(f.out), m.window)
+	}
+	m.muJobs.Unlock()
+	close(f.done)
+}
+
+// release solta a referência de um despacho; sem referências, a execução é cancelada.
+func (m *Manager) release(key string, f *flight) {
+	m.muJobs.Lock()
+	defer m.muJobs.Unlock()
+	if f.refs--; f.refs > 0 {
+		return
+	}
+	f.cancel()
+	if m.jobs[key] == f {
+		delete(m.jobs, key)
+	}
 }
 
 // OperatorStatus representa o status de um operador em execução.
@@ -60,21 +158,26 @@ func (m *Manager) Monitor(ctx context.Context, name string, in OpInput) (<-chan
 	return ch, nil
 }
 
+// Cancel cancela a execução em andamento da chave para todos os despachos que a compartilham.
 func (m *Manager) Cancel(idem string) {
 	m.muJobs.Lock()
 	defer m.muJobs.Unlock()
-	if c, ok := m.jobs[idem]; ok {
-		c()
+	if f, ok := m.jobs[idem]; ok {
+		f.cancel()
 		delete(m.jobs, idem)
 	}
 }
 
-func (m *Manager) track(idem string, c context.CancelFunc) {
+// Running devolve as chaves das execuções em andamento e quantos despachos esperam por cada uma.
+func (m *Manager) Running() map[string]int {
 	m.muJobs.Lock()
-	m.jobs[idem] = c
-	m.muJobs.Unlock()
+	defer m.muJobs.Unlock()
+	out := make(map[string]int, len(m.jobs))
+	for k, f := range m.jobs {
+		out[k] = f.refs
+	}
+	return out
 }
-func (m *Manager) untrack(idem string) { m.muJobs.Lock(); delete(m.jobs, idem); m.muJobs.Unlock() }
 
 // Defaults para uso imediato.
 var (
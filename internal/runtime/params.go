@@ -0,0 +1,55 @@
+package runtime
+
+import "github.com/google/go-github/v61/github"
+
+// ParamInt lê um inteiro de Params, aceitando também float64 (Params decodificados de JSON).
+func ParamInt(params map[string]any, key string, def int) int {
+	switch v := params[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// ParamString lê uma string de Params.
+func ParamString(params map[string]any, key, def string) string {
+	if v, ok := params[key].(string); ok {
+		return v
+	}
+	return def
+}
+
+// ParamBool lê um booleano de Params.
+func ParamBool(params map[string]any, key string, def bool) bool {
+	if v, ok := params[key].(bool); ok {
+		return v
+	}
+	return def
+}
+
+// ParamStrings lê uma lista de strings de Params, aceitando também []any (JSON).
+func ParamStrings(params map[string]any, key string) []string {
+	switch v := params[key].(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// GitHubClient extrai o client do GitHub do bundle.
+func GitHubClient(in OpInput) (*github.Client, bool) {
+	cli, ok := in.Clients.GitHub.(*github.Client)
+	return cli, ok && cli != nil
+}
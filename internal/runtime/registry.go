@@ -55,6 +55,10 @@ type OpOutput struct {
 	Metrics   []Metric
 	Insights  []Insight
 	Artifacts map[string][]byte
+	// Shared indica que o resultado veio de uma execução concorrente com a mesma chave, e
+	// Replayed que veio do IdempotencyStore, sem executar o operador.
+	Shared   bool `json:"shared,omitempty"`
+	Replayed bool `json:"replayed,omitempty"`
 }
 
 // Operator descreve uma unidade executável plugável.
@@ -101,6 +105,11 @@ func (o opFunc) Name() string                                          { return
 func (o opFunc) Version() string                                       { return o.version }
 func (o opFunc) Run(ctx context.Context, in OpInput) (OpOutput, error) { return o.run(ctx, in) }
 
+// NewOperator cria um Operator a partir de uma função, sem precisar de um tipo próprio.
+func NewOperator(name, version string, run func(context.Context, OpInput) (OpOutput, error)) Operator {
+	return opFunc{name: name, version: version, run: run}
+}
+
 // ===== Registry =====
 
 type Descriptor struct {
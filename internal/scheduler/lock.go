@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrLocked is returned when another scheduler instance holds the lock
+var ErrLocked = errors.New("scheduler is already running")
+
+// ErrLockLost is returned when the lock was taken over by another instance
+var ErrLockLost = errors.New("scheduler lock was taken over by another instance")
+
+// Lock is a single-instance lock file. The holder refreshes its modification time while it
+// runs (see Keep), so a lock left behind by a crashed instance goes stale and is taken over.
+type Lock struct {
+	path  string
+	token string
+}
+
+// AcquireLock creates the lock file, taking over a lock not refreshed within staleAfter
+func AcquireLock(path string, staleAfter time.Duration) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	host, _ := os.Hostname()
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	data, _ := json.Marshal(LockInfo{PID: os.Getpid(), Host: host, Started: time.Now().UTC(), Token: hex.EncodeToString(token)})
+
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_, werr := f.Write(data)
+			cerr := f.Close()
+			if werr != nil || cerr != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file: %w", errors.Join(werr, cerr))
+			}
+			return &Lock{path: path, token: hex.EncodeToString(token)}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+		info, statErr := os.Stat(path)
+		if statErr != nil || time.Since(info.ModTime()) <= staleAfter {
+			if holder, ok := ReadLock(path); ok {
+				return nil, fmt.Errorf("%w (pid %d on %s since %s)", ErrLocked, holder.PID, holder.Host, holder.Started.Local().Format("2006-01-02 15:04"))
+			}
+			return nil, ErrLocked
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale lock file: %w", err)
+		}
+	}
+	return nil, ErrLocked
+}
+
+// ReadLock returns the holder of a lock file, if any
+func ReadLock(path string) (LockInfo, bool) {
+	var info LockInfo
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &info) != nil {
+		return info, false
+	}
+	return info, true
+}
+
+// Touch refreshes the lock, failing with ErrLockLost when it no longer belongs to l
+func (l *Lock) Touch() error {
+	if !l.held() {
+		return ErrLockLost
+	}
+	now := time.Now()
+	return os.Chtimes(l.path, now, now)
+}
+
+// Keep refreshes the lock every interval until the returned stop function is called, so
+// that the lock stays fresh while long jobs run
+func (l *Lock) Keep(every time.Duration, onError func(error)) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := l.Touch(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done); wg.Wait() }) }
+}
+
+// Release removes the lock file, unless another instance has taken it over
+func (l *Lock) Release() error {
+	if !l.held() {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	return nil
+}
+
+// held reports whether the lock file is still the one written by l
+func (l *Lock) held() bool {
+	info, ok := ReadLock(l.path)
+	return ok && info.Token == l.token
+}
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"time"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// next is the first run of job after t: the cron slot shifted by the job's jitter offset
+func (s *Scheduler) next(job Job, t time.Time) time.Time {
+	offset := jitterOffset(job)
+	slot := job.Cron.Next(t.Add(-offset).In(s.opts.Location))
+	if slot.IsZero() {
+		return slot
+	}
+	return slot.Add(offset)
+}
+
+// jitterOffset is a stable offset in [0, Jitter) derived from the job ID, so repositories
+// sharing a schedule spread out and each keeps the same slot across restarts
+func jitterOffset(job Job) time.Duration {
+	if job.Jitter <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(job.ID))
+	return time.Duration(h.Sum64() % uint64(job.Jitter))
+}
+
+// due returns the jobs whose next run has come, and how long until the next one otherwise
+func (s *Scheduler) due(now time.Time) ([]Job, time.Duration, error) {
+	state, err := loadState(s.opts.StatePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	var due []Job
+	wait := time.Duration(-1)
+	for _, job := range s.jobs {
+		st := state.Jobs[job.ID]
+		if st == nil || st.NextRun.IsZero() {
+			continue
+		}
+		if !st.NextRun.After(now) {
+			due = append(due, job)
+			continue
+		}
+		if d := st.NextRun.Sub(now); wait < 0 || d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = s.opts.Tick
+	}
+	return due, wait, nil
+}
+
+// execute runs a job and records the outcome. Scheduled runs also move the job to its next slot.
+func (s *Scheduler) execute(ctx context.Context, job Job, scheduled bool) (err error) {
+	started := time.Now()
+	gl.Log("info", fmt.Sprintf("▶️  Running %s (%s)", job.ID, job.Operator))
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		err = s.run(ctx, job)
+	}()
+	duration := time.Since(started)
+
+	if err != nil {
+		gl.Log("error", fmt.Sprintf("%s failed after %s: %v", job.ID, duration.Round(time.Millisecond), err))
+	} else {
+		gl.Log("success", fmt.Sprintf("%s done in %s", job.ID, duration.Round(time.Millisecond)))
+	}
+
+	saveErr := s.update(func(state *State) {
+		st := state.Jobs[job.ID]
+		if st == nil {
+			st = &JobState{}
+			state.Jobs[job.ID] = st
+		}
+		st.LastRun, st.LastDuration = started.UTC(), duration
+		st.Runs++
+		st.LastStatus, st.LastError = StatusOK, ""
+		if err != nil {
+			st.LastStatus, st.LastError = StatusFailed, err.Error()
+			st.Failures++
+		}
+		if scheduled || st.NextRun.IsZero() || st.Schedule != job.Expr {
+			st.NextRun = s.next(job, time.Now())
+			st.Schedule = job.Expr
+		}
+	})
+	if saveErr != nil {
+		gl.Log("error", saveErr.Error())
+	}
+	return err
+}
+
+// update applies fn to the state file. The file is read again first so that runs recorded by
+// `schedule run-now` while the scheduler is running are kept.
+func (s *Scheduler) update(fn func(*State)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, err := loadState(s.opts.StatePath)
+	if err != nil {
+		return err
+	}
+	fn(state)
+	state.UpdatedAt = time.Now().UTC()
+	return saveState(s.opts.StatePath, state)
+}
+
+func loadState(path string) (*State, error) {
+	state := &State{Jobs: map[string]*JobState{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read scheduler state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduler state: %w", err)
+	}
+	if state.Jobs == nil {
+		state.Jobs = map[string]*JobState{}
+	}
+	return state, nil
+}
+
+// saveState replaces the state file atomically
+func saveState(path string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduler state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create scheduler directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write scheduler state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace scheduler state: %w", err)
+	}
+	return nil
+}
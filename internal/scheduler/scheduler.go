@@ -0,0 +1,270 @@
+// Package scheduler runs operators on cron schedules in the background: per-repository or
+// global schedules from the configuration, spread with a per-job jitter, guarded by a
+// single-instance lock file, with last and next runs persisted so that schedules missed while
+// ghbex was down are caught up on start.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// DefaultJitter spreads the repositories of a schedule when it sets no jitter
+const DefaultJitter = 10 * time.Minute
+
+const defaultTick = 30 * time.Second
+
+// DefaultStatePath is GHBEX_SCHEDULER_STATE, defaulting to ~/.kubex/ghbex/scheduler/state.json
+func DefaultStatePath() string {
+	return config.GetEnvOrDefault("GHBEX_SCHEDULER_STATE", filepath.Join(config.GetBaseFilesPath(), "scheduler", "state.json"))
+}
+
+// DefaultLockPath is GHBEX_SCHEDULER_LOCK, defaulting to ~/.kubex/ghbex/scheduler/scheduler.lock
+func DefaultLockPath() string {
+	return config.GetEnvOrDefault("GHBEX_SCHEDULER_LOCK", filepath.Join(config.GetBaseFilesPath(), "scheduler", "scheduler.lock"))
+}
+
+// JobsFromConfig expands the global schedules over the configured repositories and adds the
+// per-repository ones. Invalid schedules are returned as errors and left out.
+func JobsFromConfig(cfg interfaces.IMainConfig) ([]Job, []error) {
+	var jobs []Job
+	var errs []error
+	if cfg == nil || cfg.GetGitHub() == nil {
+		return nil, nil
+	}
+	seen := map[string]bool{}
+	add := func(s interfaces.ISchedule, rc interfaces.IRepoCfg) {
+		job, err := NewJob(s, rc.GetOwner(), rc.GetName())
+		if err == nil && seen[job.ID] {
+			err = fmt.Errorf("duplicate schedule %s", job.ID)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		seen[job.ID] = true
+		jobs = append(jobs, job)
+	}
+
+	repos := cfg.GetGitHub().GetRepos()
+	if rt := cfg.GetRuntime(); rt != nil {
+		for _, s := range rt.GetSchedules() {
+			for _, rc := range repos {
+				if len(s.GetRepos()) == 0 || slices.Contains(s.GetRepos(), rc.GetOwner()+"/"+rc.GetName()) {
+					add(s, rc)
+				}
+			}
+		}
+	}
+	for _, rc := range repos {
+		for _, s := range rc.GetSchedules() {
+			add(s, rc)
+		}
+	}
+	return jobs, errs
+}
+
+// NewJob validates a schedule for one repository
+func NewJob(s interfaces.ISchedule, owner, repo string) (Job, error) {
+	name := s.GetName()
+	if name == "" {
+		name = s.GetOperator()
+	}
+	job := Job{
+		ID:       fmt.Sprintf("%s:%s/%s", name, owner, repo),
+		Name:     name,
+		Operator: s.GetOperator(),
+		Owner:    owner,
+		Repo:     repo,
+		Expr:     s.GetCron(),
+		Jitter:   DefaultJitter,
+		CatchUp:  s.GetCatchUp(),
+		DryRun:   s.GetDryRun(),
+	}
+	if job.Operator == "" {
+		return job, fmt.Errorf("schedule %s has no operator", job.ID)
+	}
+	cron, err := ParseCron(s.GetCron())
+	if err != nil {
+		return job, fmt.Errorf("schedule %s: %w", job.ID, err)
+	}
+	job.Cron = cron
+	if s.GetJitter() != "" {
+		if job.Jitter, err = time.ParseDuration(s.GetJitter()); err != nil || job.Jitter < 0 {
+			return job, fmt.Errorf("schedule %s: invalid jitter %q", job.ID, s.GetJitter())
+		}
+	}
+	return job, nil
+}
+
+// Scheduler runs jobs on their schedules, one at a time
+type Scheduler struct {
+	opts Options
+	jobs []Job
+	run  RunFunc
+	mu   sync.Mutex // Serializes state file updates within the process
+}
+
+// New builds a scheduler for jobs
+func New(jobs []Job, run RunFunc, opts Options) *Scheduler {
+	if opts.StatePath == "" {
+		opts.StatePath = DefaultStatePath()
+	}
+	if opts.LockPath == "" {
+		opts.LockPath = DefaultLockPath()
+	}
+	if opts.Tick <= 0 {
+		opts.Tick = defaultTick
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	return &Scheduler{opts: opts, jobs: jobs, run: run}
+}
+
+// Jobs returns the scheduled jobs
+func (s *Scheduler) Jobs() []Job { return s.jobs }
+
+// LockPath is the single-instance lock file
+func (s *Scheduler) LockPath() string { return s.opts.LockPath }
+
+// Plan returns every job with its persisted state and next run, soonest first
+func (s *Scheduler) Plan(now time.Time) ([]Status, error) {
+	state, err := loadState(s.opts.StatePath)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Status, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		st := JobState{}
+		if saved := state.Jobs[job.ID]; saved != nil {
+			st = *saved
+		}
+		status := Status{Job: job, JobState: st}
+		if st.NextRun.IsZero() || st.Schedule != job.Expr {
+			status.NextRun = s.next(job, now)
+		} else if st.NextRun.Before(now) {
+			status.Missed = true
+		}
+		out = append(out, status)
+	}
+	slices.SortStableFunc(out, func(a, b Status) int { return a.NextRun.Compare(b.NextRun) })
+	return out, nil
+}
+
+// Start runs the scheduler until ctx is canceled. It fails with ErrLocked when another
+// instance is running. Jobs whose next run passed while no instance was running run once
+// right away when they catch up, and are moved to their next slot otherwise.
+func (s *Scheduler) Start(ctx context.Context) error {
+	lock, err := AcquireLock(s.opts.LockPath, 4*s.opts.Tick)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+	// Jobs run one after another on this goroutine, so the lock is refreshed on its own
+	// ticker to stay fresh during long jobs. An instance that lost its lock stops.
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stopKeep := lock.Keep(s.opts.Tick, func(err error) {
+		gl.Log("warning", fmt.Sprintf("Failed to refresh scheduler lock: %v", err))
+		if errors.Is(err, ErrLockLost) {
+			cancel(err)
+		}
+	})
+	defer stopKeep()
+
+	now := time.Now()
+	err = s.update(func(state *State) {
+		for _, job := range s.jobs {
+			st := state.Jobs[job.ID]
+			if st == nil {
+				st = &JobState{}
+				state.Jobs[job.ID] = st
+			}
+			switch {
+			case st.NextRun.IsZero() || st.Schedule != job.Expr:
+				st.NextRun = s.next(job, now)
+			case st.NextRun.Before(now) && job.CatchUp:
+				gl.Log("info", fmt.Sprintf("⏰ %s missed its run at %s, catching up", job.ID, st.NextRun.In(s.opts.Location).Format("2006-01-02 15:04")))
+				st.NextRun = now
+			case st.NextRun.Before(now):
+				st.NextRun = s.next(job, now)
+			}
+			st.Schedule = job.Expr
+		}
+	})
+	if err != nil {
+		return err
+	}
+	gl.Log("info", fmt.Sprintf("⏰ Scheduler started with %d jobs", len(s.jobs)))
+
+	for {
+		due, wait, err := s.due(time.Now())
+		if err != nil {
+			return err
+		}
+		for _, job := range due {
+			if ctx.Err() != nil {
+				return lostLock(ctx)
+			}
+			s.execute(ctx, job, true)
+		}
+		if len(due) > 0 {
+			continue
+		}
+
+		timer := time.NewTimer(min(wait, s.opts.Tick))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			gl.Log("info", "⏰ Scheduler stopped")
+			return lostLock(ctx)
+		case <-timer.C:
+		}
+	}
+}
+
+// lostLock returns ErrLockLost when the scheduler stopped because another instance took its
+// lock over, nil when it was stopped by its caller
+func lostLock(ctx context.Context) error {
+	if err := context.Cause(ctx); errors.Is(err, ErrLockLost) {
+		return err
+	}
+	return nil
+}
+
+// RunNow runs the jobs matching any of the selectors (job ID, schedule name, operator or
+// owner/repo) immediately, recording the run without moving their next scheduled run.
+func (s *Scheduler) RunNow(ctx context.Context, selectors ...string) ([]Job, error) {
+	var matched []Job
+	for _, job := range s.jobs {
+		for _, sel := range selectors {
+			if strings.EqualFold(sel, job.ID) || strings.EqualFold(sel, job.Name) || strings.EqualFold(sel, job.Operator) ||
+				strings.EqualFold(sel, job.Owner+"/"+job.Repo) {
+				matched = append(matched, job)
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no scheduled job matches %s", strings.Join(selectors, ", "))
+	}
+	var errs []error
+	for _, job := range matched {
+		if err := s.execute(ctx, job, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", job.ID, err))
+		}
+	}
+	return matched, errors.Join(errs...)
+}
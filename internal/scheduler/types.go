@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Run outcomes
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+// Job is one schedule applied to one repository
+type Job struct {
+	ID       string        `json:"id"` // <name or operator>:<owner>/<repo>
+	Name     string        `json:"name"`
+	Operator string        `json:"operator"`
+	Owner    string        `json:"owner"`
+	Repo     string        `json:"repo"`
+	Expr     string        `json:"cron"`
+	Cron     *Cron         `json:"-"`
+	Jitter   time.Duration `json:"jitter"`
+	CatchUp  bool          `json:"catch_up"`
+	DryRun   bool          `json:"dry_run,omitempty"`
+}
+
+// JobState is the persisted run history of a job
+type JobState struct {
+	Schedule     string        `json:"schedule"` // Cron expression NextRun was computed from
+	LastRun      time.Time     `json:"last_run,omitempty"`
+	LastStatus   string        `json:"last_status,omitempty"`
+	LastError    string        `json:"last_error,omitempty"`
+	LastDuration time.Duration `json:"last_duration,omitempty"`
+	NextRun      time.Time     `json:"next_run"`
+	Runs         int           `json:"runs"`
+	Failures     int           `json:"failures"`
+}
+
+// State is the scheduler state file
+type State struct {
+	UpdatedAt time.Time            `json:"updated_at"`
+	Jobs      map[string]*JobState `json:"jobs"`
+}
+
+// Status is a job with its state, as listed by Plan
+type Status struct {
+	Job
+	JobState
+	// Missed is set when the persisted next run is in the past, i.e. ghbex was down
+	Missed bool `json:"missed"`
+}
+
+// LockInfo identifies the scheduler instance holding the lock
+type LockInfo struct {
+	PID     int       `json:"pid"`
+	Host    string    `json:"host"`
+	Started time.Time `json:"started"`
+	Token   string    `json:"token"` // Random per acquisition, so an instance only touches its own lock
+}
+
+// RunFunc runs one job
+type RunFunc func(ctx context.Context, job Job) error
+
+// Options configures a Scheduler
+type Options struct {
+	// StatePath is the state file, DefaultStatePath when empty
+	StatePath string
+	// LockPath is the single-instance lock file, DefaultLockPath when empty
+	LockPath string
+	// Tick bounds the sleep between checks and refreshes the lock (default 30s)
+	Tick time.Duration
+	// Location is the time zone of the cron expressions (default local time)
+	Location *time.Location
+}
@@ -1,7 +1,11 @@
 // Package state provides types and functions for managing application state.
 package state
 
-import "sync/atomic"
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
 
 type Stage uint64
 
@@ -13,9 +17,64 @@ const (
 	StageReportPersist
 )
 
+// Stages lists every stage in declaration order
+var Stages = []Stage{StageRunsCleanup, StageArtifactsCleanup, StageReleaseCleanup, StageNotify, StageReportPersist}
+
+var stageNames = map[Stage]string{
+	StageRunsCleanup:      "runs",
+	StageArtifactsCleanup: "artifacts",
+	StageReleaseCleanup:   "releases",
+	StageNotify:           "notify",
+	StageReportPersist:    "persist",
+}
+
+// Has reports whether every stage of other is set in s
+func (s Stage) Has(other Stage) bool { return s&other == other }
+
+// String lists the stage names of s, e.g. "runs,artifacts"
+func (s Stage) String() string {
+	var names []string
+	for _, st := range Stages {
+		if s&st != 0 {
+			names = append(names, stageNames[st])
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+func (s Stage) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+func (s *Stage) UnmarshalText(text []byte) error {
+	*s = 0
+	for _, name := range strings.Split(string(text), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for st, n := range stageNames {
+			if n == name {
+				*s |= st
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown stage %q", name)
+		}
+	}
+	return nil
+}
+
 type FlagSet struct{ v atomic.Uint64 }
 
-func (f *FlagSet) Enable(s Stage) { f.v.Add(uint64(s)) }
+func (f *FlagSet) Enable(s Stage) {
+	for {
+		old := f.v.Load()
+		if f.v.CompareAndSwap(old, old|uint64(s)) {
+			return
+		}
+	}
+}
 func (f *FlagSet) Disable(s Stage) {
 	for {
 		old := f.v.Load()
@@ -25,3 +84,5 @@ func (f *FlagSet) Disable(s Stage) {
 	}
 }
 func (f *FlagSet) Has(s Stage) bool { return f.v.Load()&uint64(s) != 0 }
+func (f *FlagSet) Load() Stage      { return Stage(f.v.Load()) }
+func (f *FlagSet) Store(s Stage)    { f.v.Store(uint64(s)) }
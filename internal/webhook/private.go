@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+)
+
+// weekStart is the Monday of the ISO week of t, in UTC
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// setRepository fills the owner and name from a full name
+func setRepository(ev *Event, fullName string) error {
+	owner, name, ok := strings.Cut(fullName, "/")
+	if !ok || owner == "" || name == "" {
+		return fmt.Errorf("%s payload without a repository", ev.Type)
+	}
+	ev.Owner, ev.Repo = owner, name
+	return nil
+}
+
+func normalize(ev *Event, parsed any) error {
+	switch e := parsed.(type) {
+	case *github.PushEvent:
+		ev.Ref, ev.SHA = e.GetRef(), e.GetAfter()
+		ev.Sender = e.GetSender().GetLogin()
+		if e.GetDeleted() {
+			// A branch deletion, not a push of commits; push events have no action otherwise
+			ev.Action = "deleted"
+		}
+		// The commits list is capped at 20, size (when sent) is the full count
+		ev.Commits = e.GetSize()
+		if ev.Commits == 0 {
+			ev.Commits = len(e.Commits)
+		}
+		ev.DefaultBranch = strings.TrimPrefix(ev.Ref, "refs/heads/") == e.GetRepo().GetDefaultBranch()
+		ev.At = e.GetHeadCommit().GetTimestamp().Time
+		return setRepository(ev, e.GetRepo().GetFullName())
+
+	case *github.PullRequestEvent:
+		pr := e.GetPullRequest()
+		ev.Action, ev.Number = e.GetAction(), pr.GetNumber()
+		ev.Sender = e.GetSender().GetLogin()
+		ev.Ref, ev.SHA = pr.GetBase().GetRef(), pr.GetHead().GetSHA()
+		ev.State, ev.Merged = pr.GetState(), pr.GetMerged()
+		ev.OpenedAt, ev.At = pr.GetCreatedAt().Time, pr.GetUpdatedAt().Time
+		return setRepository(ev, e.GetRepo().GetFullName())
+
+	case *github.PullRequestReviewEvent:
+		ev.Action, ev.Number = e.GetAction(), e.GetPullRequest().GetNumber()
+		ev.Sender = e.GetReview().GetUser().GetLogin()
+		ev.State, ev.SHA = strings.ToLower(e.GetReview().GetState()), e.GetReview().GetCommitID()
+		ev.OpenedAt, ev.At = e.GetPullRequest().GetCreatedAt().Time, e.GetReview().GetSubmittedAt().Time
+		return setRepository(ev, e.GetRepo().GetFullName())
+
+	case *github.IssuesEvent:
+		issue := e.GetIssue()
+		ev.Action, ev.Number = e.GetAction(), issue.GetNumber()
+		ev.Sender = e.GetSender().GetLogin()
+		ev.State = issue.GetState()
+		ev.OpenedAt, ev.At = issue.GetCreatedAt().Time, issue.GetUpdatedAt().Time
+		return setRepository(ev, e.GetRepo().GetFullName())
+
+	case *github.WorkflowRunEvent:
+		run := e.GetWorkflowRun()
+		ev.Action, ev.Name = e.GetAction(), run.GetName()
+		ev.Sender = e.GetSender().GetLogin()
+		ev.Ref, ev.SHA, ev.State = run.GetHeadBranch(), run.GetHeadSHA(), run.GetConclusion()
+		ev.OpenedAt, ev.At = run.GetRunStartedAt().Time, run.GetUpdatedAt().Time
+		return setRepository(ev, e.GetRepo().GetFullName())
+
+	case *github.ReleaseEvent:
+		rel := e.GetRelease()
+		ev.Action, ev.Name = e.GetAction(), rel.GetTagName()
+		ev.Sender = e.GetSender().GetLogin()
+		ev.Ref = rel.GetTargetCommitish()
+		if rel.GetPrerelease() {
+			ev.State = "prerelease"
+		}
+		ev.At = rel.GetPublishedAt().Time
+		return setRepository(ev, e.GetRepo().GetFullName())
+
+	case *github.DeploymentStatusEvent:
+		status, deploy := e.GetDeploymentStatus(), e.GetDeployment()
+		ev.Action, ev.Name = "created", deploy.GetEnvironment() // The only action GitHub sends
+		ev.Sender = e.GetSender().GetLogin()
+		ev.Ref, ev.SHA, ev.State = deploy.GetRef(), deploy.GetSHA(), status.GetState()
+		ev.OpenedAt, ev.At = deploy.GetCreatedAt().Time, status.GetCreatedAt().Time
+		return setRepository(ev, e.GetRepo().GetFullName())
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupported, parsed)
+}
+
+// apply folds one event into an aggregate
+func apply(agg *Aggregate, ev *Event) {
+	switch ev.Type {
+	case EventPush:
+		if ev.Action == "deleted" {
+			break
+		}
+		agg.Pushes++
+		if ev.DefaultBranch {
+			agg.Commits += ev.Commits
+		}
+	case EventPullRequest:
+		switch {
+		case ev.Action == "opened":
+			agg.PROpened++
+		case ev.Action == "closed" && ev.Merged:
+			agg.PRMerged++
+			if !ev.OpenedAt.IsZero() {
+				agg.CycleHours = append(agg.CycleHours, ev.At.Sub(ev.OpenedAt).Hours())
+			}
+		case ev.Action == "closed":
+			agg.PRClosed++
+		}
+	case EventPullRequestReview:
+		if ev.Action == "submitted" {
+			agg.Reviews++
+			if ev.State == "approved" {
+				agg.Approvals++
+			}
+		}
+	case EventIssues:
+		switch ev.Action {
+		case "opened", "reopened":
+			agg.IssuesOpened++
+		case "closed":
+			agg.IssuesClosed++
+		}
+	case EventWorkflowRun:
+		if ev.Action == "completed" && ev.State != "skipped" && ev.State != "cancelled" {
+			agg.WorkflowRuns++
+			if ev.State == "failure" || ev.State == "timed_out" || ev.State == "startup_failure" {
+				agg.WorkflowFailures++
+			}
+		}
+	case EventRelease:
+		if ev.Action == "published" && ev.State != "prerelease" {
+			agg.Releases++
+		}
+	case EventDeploymentStatus:
+		switch ev.State {
+		case "success":
+			agg.Deploys++
+		case "failure", "error":
+			agg.DeployFailures++
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+
+	gl "github.com/kubex-ecosystem/ghbex/internal/module/logger"
+)
+
+// recentDeliveries bounds the delivery IDs remembered to drop redeliveries
+const recentDeliveries = 4096
+
+// Receiver is the http.Handler of the webhook endpoint
+type Receiver struct {
+	opts       Options
+	aggregator *Aggregator
+	triggers   sync.WaitGroup
+
+	mu     sync.Mutex
+	seen   map[string]bool
+	recent []string
+}
+
+// NewReceiver builds a receiver. With a log, the aggregates and the redelivery filter are
+// rebuilt from the logged events first, so a restart does not lose or double count anything.
+func NewReceiver(opts Options) (*Receiver, error) {
+	if opts.Secret == "" {
+		return nil, errors.New("a webhook secret is required to verify deliveries")
+	}
+	r := &Receiver{opts: opts, aggregator: NewAggregator(), seen: map[string]bool{}}
+	if opts.Log != nil {
+		err := opts.Log.Replay(time.Time{}, func(ev *Event) error {
+			if r.remember(ev.Delivery) {
+				r.aggregator.Apply(ev)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay event log: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Aggregator exposes the aggregates updated by the receiver
+func (r *Receiver) Aggregator() *Aggregator { return r.aggregator }
+
+// Wait blocks until the triggers started so far have finished
+func (r *Receiver) Wait() { r.triggers.Wait() }
+
+// ServeHTTP verifies, normalizes, logs and aggregates one delivery, then starts its triggers
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := io.ReadAll(io.LimitReader(req.Body, maxPayload+1))
+	if err != nil || len(payload) > maxPayload {
+		http.Error(w, "unreadable payload", http.StatusBadRequest)
+		return
+	}
+	if err := Verify(r.opts.Secret, payload, req.Header.Get(github.SHA256SignatureHeader)); err != nil {
+		gl.Log("warning", fmt.Sprintf("Rejected webhook delivery %s: %v", github.DeliveryID(req), err))
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	eventType, delivery := github.WebHookType(req), github.DeliveryID(req)
+	if eventType == EventPing {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "pong"})
+		return
+	}
+	ev, err := r.Handle(eventType, delivery, payload, time.Now())
+	switch {
+	case errors.Is(err, ErrUnsupported):
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "ignored", "event": eventType})
+	case err != nil:
+		gl.Log("error", fmt.Sprintf("Failed to handle %s delivery %s: %v", eventType, delivery, err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ev == nil:
+		writeJSON(w, http.StatusOK, map[string]string{"status": "duplicate", "delivery": delivery})
+	default:
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "accepted", "delivery": delivery, "event": ev.Type})
+	}
+}
+
+// Handle processes a verified delivery. It returns a nil event for a redelivery already seen.
+func (r *Receiver) Handle(eventType, delivery string, payload []byte, receivedAt time.Time) (*Event, error) {
+	ev, err := Normalize(eventType, delivery, payload, receivedAt)
+	if err != nil {
+		return nil, err
+	}
+	if delivery != "" && !r.remember(delivery) {
+		return nil, nil
+	}
+	if r.opts.Log != nil {
+		if err := r.opts.Log.Append(ev); err != nil {
+			// Not logged, so a redelivery of it must be accepted
+			r.forget(delivery)
+			return nil, err
+		}
+	}
+	r.aggregator.Apply(ev)
+	gl.Log("debug", fmt.Sprintf("Webhook %s %s on %s/%s", ev.Type, ev.Action, ev.Owner, ev.Repo))
+
+	if r.opts.OnTrigger == nil {
+		return ev, nil
+	}
+	for _, t := range r.opts.Triggers {
+		if !t.Matches(ev) {
+			continue
+		}
+		r.triggers.Add(1)
+		go func(t Trigger) {
+			defer r.triggers.Done()
+			if err := r.opts.OnTrigger(context.Background(), t, ev); err != nil {
+				gl.Log("error", fmt.Sprintf("Trigger %s on %s/%s failed: %v", t.Operator, ev.Owner, ev.Repo, err))
+			}
+		}(t)
+	}
+	return ev, nil
+}
+
+// remember records a delivery ID and reports whether it was new
+func (r *Receiver) remember(delivery string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.seen[delivery] {
+		return false
+	}
+	r.seen[delivery] = true
+	r.recent = append(r.recent, delivery)
+	if len(r.recent) > recentDeliveries {
+		delete(r.seen, r.recent[0])
+		r.recent = r.recent[1:]
+	}
+	return true
+}
+
+// forget drops a delivery ID remembered for a delivery that could not be handled
+func (r *Receiver) forget(delivery string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.seen[delivery] {
+		return
+	}
+	delete(r.seen, delivery)
+	for i := len(r.recent) - 1; i >= 0; i-- {
+		if r.recent[i] == delivery {
+			r.recent = append(r.recent[:i], r.recent[i+1:]...)
+			break
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
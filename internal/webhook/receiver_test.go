@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+)
+
+const testSecret = "It's a Secret to Everybody"
+
+func loadFixture(t *testing.T, name string) Fixture {
+	t.Helper()
+	fixtures, err := LoadFixtures(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatalf("LoadFixtures(%s): %v", name, err)
+	}
+	return fixtures[0]
+}
+
+func TestVerify(t *testing.T) {
+	payload := loadFixture(t, "push").Payload
+
+	tests := []struct {
+		name      string
+		secret    string
+		payload   []byte
+		signature string
+		wantErr   bool
+	}{
+		{name: "valid", secret: testSecret, payload: payload, signature: Sign(testSecret, payload)},
+		{name: "wrong secret", secret: testSecret, payload: payload, signature: Sign("another secret", payload), wantErr: true},
+		{name: "tampered payload", secret: testSecret, payload: append([]byte(" "), payload...), signature: Sign(testSecret, payload), wantErr: true},
+		{name: "malformed signature", secret: testSecret, payload: payload, signature: "sha256=zz", wantErr: true},
+		{name: "missing signature", secret: testSecret, payload: payload, wantErr: true},
+		{name: "no secret configured", payload: payload, signature: Sign(testSecret, payload), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.payload, tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeFixtures(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    Event
+	}{
+		{"push", Event{Type: EventPush, Ref: "refs/heads/main", SHA: "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c", Sender: "octocat", Commits: 2, DefaultBranch: true}},
+		{"pull_request.opened", Event{Type: EventPullRequest, Action: "opened", Number: 42, Ref: "main", State: "open", Sender: "octocat"}},
+		{"pull_request.closed", Event{Type: EventPullRequest, Action: "closed", Number: 42, Ref: "main", State: "closed", Merged: true, Sender: "octocat"}},
+		{"pull_request_review.submitted", Event{Type: EventPullRequestReview, Action: "submitted", Number: 42, State: "approved", Sender: "hubot"}},
+		{"issues.opened", Event{Type: EventIssues, Action: "opened", Number: 43, State: "open", Sender: "octocat"}},
+		{"issues.closed", Event{Type: EventIssues, Action: "closed", Number: 43, State: "closed", Sender: "octocat"}},
+		{"workflow_run.completed", Event{Type: EventWorkflowRun, Action: "completed", Name: "CI", State: "failure", Ref: "main", Sender: "octocat"}},
+		{"release.published", Event{Type: EventRelease, Action: "published", Name: "v1.4.0", Ref: "main", Sender: "octocat"}},
+		{"deployment_status.created", Event{Type: EventDeploymentStatus, Action: "created", Name: "production", State: "success", Ref: "v1.4.0", Sender: "octocat"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			fx := loadFixture(t, tt.fixture)
+			if fx.Event != tt.want.Type {
+				t.Fatalf("fixture event = %q, want %q", fx.Event, tt.want.Type)
+			}
+			ev, err := Normalize(fx.Event, fx.Delivery, fx.Payload, time.Now())
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if ev.Owner != "octo-org" || ev.Repo != "octo-repo" {
+				t.Errorf("repository = %s/%s, want octo-org/octo-repo", ev.Owner, ev.Repo)
+			}
+			if ev.Delivery != fx.Delivery {
+				t.Errorf("Delivery = %q, want %q", ev.Delivery, fx.Delivery)
+			}
+			if ev.At.IsZero() || ev.At.Location() != time.UTC {
+				t.Errorf("At = %v, want a UTC time from the payload", ev.At)
+			}
+			got := *ev
+			got.Delivery, got.Owner, got.Repo, got.At, got.ReceivedAt, got.OpenedAt = "", "", "", time.Time{}, time.Time{}, time.Time{}
+			if tt.want.SHA == "" {
+				got.SHA = ""
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeUnsupported(t *testing.T) {
+	_, err := Normalize("star", "d-1", []byte(`{}`), time.Now())
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("Normalize(star) error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestPushAggregates(t *testing.T) {
+	payload := string(loadFixture(t, "push").Payload)
+	tests := []struct {
+		name        string
+		payload     string
+		wantPushes  int
+		wantCommits int
+	}{
+		{name: "default branch", payload: payload, wantPushes: 1, wantCommits: 2},
+		{name: "other branch", payload: strings.Replace(payload, `"ref":"refs/heads/main"`, `"ref":"refs/heads/feature"`, 1), wantPushes: 1},
+		{name: "branch deletion", payload: strings.Replace(payload, `"deleted":false`, `"deleted":true`, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.payload == payload && tt.name != "default branch" {
+				t.Fatal("fixture does not contain the field the test replaces")
+			}
+			ev, err := Normalize(EventPush, "d-1", []byte(tt.payload), time.Now())
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			agg := &Aggregate{}
+			apply(agg, ev)
+			if agg.Pushes != tt.wantPushes || agg.Commits != tt.wantCommits {
+				t.Errorf("pushes, commits = %d, %d, want %d, %d", agg.Pushes, agg.Commits, tt.wantPushes, tt.wantCommits)
+			}
+		})
+	}
+}
+
+func TestReceiverDuplicateDelivery(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "events.jsonl")
+	fx := loadFixture(t, "issues.opened")
+
+	r, err := NewReceiver(Options{Secret: testSecret, Log: NewLog(logPath)})
+	if err != nil {
+		t.Fatalf("NewReceiver() error = %v", err)
+	}
+	if ev, err := r.Handle(fx.Event, fx.Delivery, fx.Payload, time.Now()); err != nil || ev == nil {
+		t.Fatalf("first Handle() = %v, %v, want an event", ev, err)
+	}
+	if ev, err := r.Handle(fx.Event, fx.Delivery, fx.Payload, time.Now()); err != nil || ev != nil {
+		t.Fatalf("redelivery Handle() = %v, %v, want a nil event", ev, err)
+	}
+	if got := issuesOpened(r); got != 1 {
+		t.Errorf("issues opened = %d, want 1", got)
+	}
+
+	// A restarted receiver rebuilds its filter and aggregates from the log
+	restarted, err := NewReceiver(Options{Secret: testSecret, Log: NewLog(logPath)})
+	if err != nil {
+		t.Fatalf("NewReceiver() after restart error = %v", err)
+	}
+	if ev, err := restarted.Handle(fx.Event, fx.Delivery, fx.Payload, time.Now()); err != nil || ev != nil {
+		t.Fatalf("redelivery after restart Handle() = %v, %v, want a nil event", ev, err)
+	}
+	if got := issuesOpened(restarted); got != 1 {
+		t.Errorf("issues opened after restart = %d, want 1", got)
+	}
+}
+
+func TestReceiverRetriesUnloggedDelivery(t *testing.T) {
+	fx := loadFixture(t, "issues.opened")
+	r, err := NewReceiver(Options{Secret: testSecret, Log: NewLog(filepath.Join(t.TempDir(), "events.jsonl"))})
+	if err != nil {
+		t.Fatalf("NewReceiver() error = %v", err)
+	}
+	logged := r.opts.Log
+
+	// The log directory is a file, so appending fails
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r.opts.Log = NewLog(filepath.Join(blocker, "events.jsonl"))
+	if _, err := r.Handle(fx.Event, fx.Delivery, fx.Payload, time.Now()); err == nil {
+		t.Fatal("Handle() with an unwritable log succeeded")
+	}
+
+	// Once the log is writable again, the redelivery is accepted rather than dropped
+	r.opts.Log = logged
+	if ev, err := r.Handle(fx.Event, fx.Delivery, fx.Payload, time.Now()); err != nil || ev == nil {
+		t.Fatalf("redelivery Handle() = %v, %v, want an event", ev, err)
+	}
+	if got := issuesOpened(r); got != 1 {
+		t.Errorf("issues opened = %d, want 1", got)
+	}
+}
+
+func TestReceiverServeHTTP(t *testing.T) {
+	r, err := NewReceiver(Options{Secret: testSecret})
+	if err != nil {
+		t.Fatalf("NewReceiver() error = %v", err)
+	}
+	fx := loadFixture(t, "workflow_run.completed")
+
+	deliver := func(signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, DefaultPath, bytes.NewReader(fx.Payload))
+		req.Header.Set(github.EventTypeHeader, fx.Event)
+		req.Header.Set(github.DeliveryIDHeader, fx.Delivery)
+		if signature != "" {
+			req.Header.Set(github.SHA256SignatureHeader, signature)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := deliver(""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned delivery status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := deliver(Sign("another secret", fx.Payload)); rec.Code != http.StatusUnauthorized {
+		t.Errorf("badly signed delivery status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := deliver(Sign(testSecret, fx.Payload)); rec.Code != http.StatusAccepted {
+		t.Errorf("signed delivery status = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+	rec := deliver(Sign(testSecret, fx.Payload))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"duplicate"`) {
+		t.Errorf("redelivery = %d %s, want 200 duplicate", rec.Code, rec.Body.String())
+	}
+}
+
+func issuesOpened(r *Receiver) int {
+	total := 0
+	for _, agg := range r.Aggregator().Aggregates() {
+		total += agg.IssuesOpened
+	}
+	return total
+}
@@ -0,0 +1,161 @@
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubex-ecosystem/ghbex/internal/history"
+	"github.com/kubex-ecosystem/ghbex/internal/metrics"
+)
+
+// Log is an append-only JSON lines file of normalized events. It is safe for concurrent use
+// within a process.
+type Log struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewLog opens the log at path, DefaultLogPath when empty
+func NewLog(path string) *Log {
+	if path == "" {
+		path = DefaultLogPath()
+	}
+	return &Log{path: path}
+}
+
+// Path is the log file
+func (l *Log) Path() string { return l.path }
+
+// Append writes one event
+func (l *Log) Append(ev *Event) error {
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create event log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	return nil
+}
+
+// Replay calls fn for every logged event received at or after since, oldest first.
+// A missing log has no events; a torn line from an interrupted write is skipped.
+func (l *Log) Replay(since time.Time, fn func(*Event) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal(line, &ev); err != nil {
+			continue
+		}
+		if ev.ReceivedAt.Before(since) {
+			continue
+		}
+		if err := fn(&ev); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read event log: %w", err)
+	}
+	return nil
+}
+
+// Aggregator folds events into weekly per-repository aggregates. It is safe for concurrent use.
+type Aggregator struct {
+	mu    sync.Mutex
+	weeks map[string]*Aggregate
+}
+
+// NewAggregator returns an empty aggregator
+func NewAggregator() *Aggregator {
+	return &Aggregator{weeks: map[string]*Aggregate{}}
+}
+
+// Apply folds one event into the aggregate of its repository and week
+func (a *Aggregator) Apply(ev *Event) {
+	week := weekStart(ev.At)
+	key := ev.Owner + "/" + ev.Repo + "@" + week.Format("2006-01-02")
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	agg, ok := a.weeks[key]
+	if !ok {
+		agg = &Aggregate{Owner: ev.Owner, Repo: ev.Repo, Week: week}
+		a.weeks[key] = agg
+	}
+	agg.Events++
+	if ev.At.After(agg.LastEventAt) {
+		agg.LastEventAt = ev.At
+	}
+	apply(agg, ev)
+}
+
+// Aggregates returns a copy of every aggregate, by repository then week
+func (a *Aggregator) Aggregates() []Aggregate {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]Aggregate, 0, len(a.weeks))
+	for _, agg := range a.weeks {
+		cp := *agg
+		cp.CycleHours = append([]float64(nil), agg.CycleHours...)
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Owner+"/"+out[i].Repo != out[j].Owner+"/"+out[j].Repo {
+			return out[i].Owner+"/"+out[i].Repo < out[j].Owner+"/"+out[j].Repo
+		}
+		return out[i].Week.Before(out[j].Week)
+	})
+	return out
+}
+
+// Metrics maps the aggregate to history metric names, leaving out rates without events
+func (agg *Aggregate) Metrics() map[string]float64 {
+	values := map[string]float64{
+		history.MetricCommits:      float64(agg.Commits),
+		history.MetricMergedPRs:    float64(agg.PRMerged),
+		history.MetricIssuesOpened: float64(agg.IssuesOpened),
+		history.MetricIssuesClosed: float64(agg.IssuesClosed),
+		history.MetricReleases:     float64(agg.Releases),
+		history.MetricDeployFreq:   float64(agg.Deploys),
+	}
+	if len(agg.CycleHours) > 0 {
+		values[history.MetricPRCycleTimeP50] = metrics.Percentile(agg.CycleHours, 50)
+	}
+	if agg.WorkflowRuns > 0 {
+		values[history.MetricWorkflowFailureRate] = float64(agg.WorkflowFailures) / float64(agg.WorkflowRuns) * 100
+	}
+	return values
+}
@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// Supported GitHub event types
+const (
+	EventPush              = "push"
+	EventPullRequest       = "pull_request"
+	EventPullRequestReview = "pull_request_review"
+	EventIssues            = "issues"
+	EventWorkflowRun       = "workflow_run"
+	EventRelease           = "release"
+	EventDeploymentStatus  = "deployment_status"
+	EventPing              = "ping"
+)
+
+// Event is a GitHub webhook delivery normalized to the fields the aggregates and triggers use
+type Event struct {
+	Delivery   string    `json:"delivery"`
+	Type       string    `json:"type"`
+	Action     string    `json:"action,omitempty"`
+	Owner      string    `json:"owner"`
+	Repo       string    `json:"repo"`
+	Sender     string    `json:"sender,omitempty"`
+	At         time.Time `json:"at"` // When it happened, from the payload
+	ReceivedAt time.Time `json:"received_at"`
+	Number     int       `json:"number,omitempty"` // Pull request or issue
+	Ref        string    `json:"ref,omitempty"`
+	SHA        string    `json:"sha,omitempty"`
+	Name       string    `json:"name,omitempty"`  // Workflow, release tag or deployment environment
+	State      string    `json:"state,omitempty"` // Review state, run conclusion or deployment state
+	Merged     bool      `json:"merged,omitempty"`
+	Commits    int       `json:"commits,omitempty"`
+	// DefaultBranch marks a push to the default branch of the repository
+	DefaultBranch bool `json:"default_branch,omitempty"`
+	// OpenedAt is the creation time of the pull request or issue, for cycle times
+	OpenedAt time.Time `json:"opened_at,omitempty"`
+}
+
+// Aggregate counts the events of one repository in one ISO week
+type Aggregate struct {
+	Owner            string    `json:"owner"`
+	Repo             string    `json:"repo"`
+	Week             time.Time `json:"week"`
+	Events           int       `json:"events"`
+	LastEventAt      time.Time `json:"last_event_at"`
+	Pushes           int       `json:"pushes"`  // Branch deletions excluded
+	Commits          int       `json:"commits"` // Pushed to the default branch
+	PROpened         int       `json:"pr_opened"`
+	PRMerged         int       `json:"pr_merged"`
+	PRClosed         int       `json:"pr_closed"` // Closed without merge
+	Reviews          int       `json:"reviews"`
+	Approvals        int       `json:"approvals"`
+	IssuesOpened     int       `json:"issues_opened"`
+	IssuesClosed     int       `json:"issues_closed"`
+	WorkflowRuns     int       `json:"workflow_runs"`
+	WorkflowFailures int       `json:"workflow_failures"`
+	Releases         int       `json:"releases"`
+	Deploys          int       `json:"deploys"`
+	DeployFailures   int       `json:"deploy_failures"`
+	CycleHours       []float64 `json:"cycle_hours,omitempty"` // Open to merge of the merged pull requests
+}
+
+// Trigger runs an operator on the events of a type and, optionally, action and repositories
+type Trigger struct {
+	Event    string   `json:"event"`
+	Action   string   `json:"action,omitempty"`
+	Operator string   `json:"operator"`
+	Repos    []string `json:"repos,omitempty"`
+	DryRun   bool     `json:"dry_run,omitempty"`
+}
+
+// Fixture is a recorded delivery. The event type comes from the file name, e.g.
+// workflow_run.completed.json, and the delivery ID from the payload hash.
+type Fixture struct {
+	Path     string
+	Event    string
+	Delivery string
+	Payload  []byte
+}
+
+// Options configures a Receiver
+type Options struct {
+	// Secret verifies X-Hub-Signature-256; it is required
+	Secret string
+	// Log stores the normalized events; nil keeps them in memory only
+	Log *Log
+	// Triggers are matched against every accepted event
+	Triggers []Trigger
+	// OnTrigger runs a matched trigger. It is called in its own goroutine after the delivery
+	// is acknowledged, since GitHub expects an answer within 10 seconds.
+	OnTrigger func(ctx context.Context, trigger Trigger, event *Event) error
+}
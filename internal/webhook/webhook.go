@@ -0,0 +1,149 @@
+// Package webhook receives GitHub webhooks for poll-free metrics. Deliveries are verified
+// against X-Hub-Signature-256, normalized into an append-only JSON lines event log, folded into
+// weekly per-repository aggregates in real time and matched against triggers that run
+// operators, e.g. the artifact cleanup on every completed workflow run.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/kubex-ecosystem/ghbex/internal/config"
+	"github.com/kubex-ecosystem/ghbex/internal/defs/interfaces"
+)
+
+// DefaultPath is where the receiver listens when the configuration sets no path
+const DefaultPath = "/webhooks/github"
+
+// maxPayload is the largest payload GitHub delivers
+const maxPayload = 25 << 20
+
+// ErrUnsupported is returned by Normalize for event types the receiver does not handle
+var ErrUnsupported = errors.New("unsupported event type")
+
+// SupportedEvents lists the event types that are normalized
+func SupportedEvents() []string {
+	return []string{EventPush, EventPullRequest, EventPullRequestReview, EventIssues, EventWorkflowRun, EventRelease, EventDeploymentStatus}
+}
+
+// DefaultLogPath is GHBEX_EVENT_LOG, defaulting to ~/.kubex/ghbex/events/events.jsonl
+func DefaultLogPath() string {
+	return config.GetEnvOrDefault("GHBEX_EVENT_LOG", filepath.Join(config.GetBaseFilesPath(), "events", "events.jsonl"))
+}
+
+// Sign returns the X-Hub-Signature-256 value of a payload
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the X-Hub-Signature-256 value of a payload in constant time
+func Verify(secret string, payload []byte, signature string) error {
+	if secret == "" {
+		return errors.New("webhook secret is not configured")
+	}
+	if signature == "" {
+		return errors.New("missing " + github.SHA256SignatureHeader + " header")
+	}
+	if err := github.ValidateSignature(signature, payload, []byte(secret)); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	return nil
+}
+
+// Normalize parses a delivery of a supported event type into an Event
+func Normalize(eventType, delivery string, payload []byte, receivedAt time.Time) (*Event, error) {
+	if !slices.Contains(SupportedEvents(), eventType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, eventType)
+	}
+	parsed, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s payload: %w", eventType, err)
+	}
+	ev := &Event{Delivery: delivery, Type: eventType, ReceivedAt: receivedAt.UTC()}
+	if err := normalize(ev, parsed); err != nil {
+		return nil, err
+	}
+	if ev.At.IsZero() {
+		ev.At = ev.ReceivedAt
+	}
+	ev.At = ev.At.UTC()
+	return ev, nil
+}
+
+// Matches reports whether the trigger applies to the event
+func (t Trigger) Matches(ev *Event) bool {
+	if t.Event != ev.Type || (t.Action != "" && t.Action != ev.Action) {
+		return false
+	}
+	return len(t.Repos) == 0 || slices.Contains(t.Repos, ev.Owner+"/"+ev.Repo)
+}
+
+// TriggersFromConfig converts the configured triggers, skipping incomplete ones
+func TriggersFromConfig(cfg interfaces.IWebhooks) []Trigger {
+	if cfg == nil {
+		return nil
+	}
+	var triggers []Trigger
+	for _, t := range cfg.GetTriggers() {
+		if t.GetEvent() == "" || t.GetOperator() == "" {
+			continue
+		}
+		triggers = append(triggers, Trigger{
+			Event:    t.GetEvent(),
+			Action:   t.GetAction(),
+			Operator: t.GetOperator(),
+			Repos:    t.GetRepos(),
+			DryRun:   t.GetDryRun(),
+		})
+	}
+	return triggers
+}
+
+// LoadFixtures reads recorded deliveries from files or directories of *.json files, in name order
+func LoadFixtures(paths ...string) ([]Fixture, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(matches)
+		files = append(files, matches...)
+	}
+
+	fixtures := make([]Fixture, 0, len(files))
+	for _, file := range files {
+		payload, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		event, _, _ := strings.Cut(filepath.Base(file), ".")
+		sum := sha256.Sum256(payload)
+		fixtures = append(fixtures, Fixture{
+			Path:     file,
+			Event:    event,
+			Delivery: "fixture-" + hex.EncodeToString(sum[:8]),
+			Payload:  payload,
+		})
+	}
+	return fixtures, nil
+}